@@ -2,6 +2,7 @@ package inbound
 
 import (
 	"context"
+	"time"
 
 	"v2ray.com/core"
 	"v2ray.com/core/app/proxyman"
@@ -41,6 +42,11 @@ func getStatCounter(v *core.Instance, tag string) (stats.Counter, stats.Counter)
 	return uplinkCounter, downlinkCounter
 }
 
+// getHandshakeTimeout returns the handshake timeout of connections whose users are not known yet.
+func getHandshakeTimeout(v *core.Instance) time.Duration {
+	return v.GetFeature(policy.ManagerType()).(policy.Manager).ForLevel(0).Timeouts.Handshake
+}
+
 type AlwaysOnInboundHandler struct {
 	proxy   proxy.Inbound
 	workers []worker
@@ -64,7 +70,9 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 		tag:   tag,
 	}
 
-	uplinkCounter, downlinkCounter := getStatCounter(core.MustFromContext(ctx), tag)
+	v := core.MustFromContext(ctx)
+	uplinkCounter, downlinkCounter := getStatCounter(v, tag)
+	handshakeTimeout := getHandshakeTimeout(v)
 
 	nl := p.Network()
 	pr := receiverConfig.PortRange
@@ -93,16 +101,17 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 			newError("creating stream worker on ", address, ":", port).AtDebug().WriteToLog()
 
 			worker := &tcpWorker{
-				address:         address,
-				port:            net.Port(port),
-				proxy:           p,
-				stream:          mss,
-				recvOrigDest:    receiverConfig.ReceiveOriginalDestination,
-				tag:             tag,
-				dispatcher:      h.mux,
-				sniffingConfig:  receiverConfig.GetEffectiveSniffingSettings(),
-				uplinkCounter:   uplinkCounter,
-				downlinkCounter: downlinkCounter,
+				address:          address,
+				port:             net.Port(port),
+				proxy:            p,
+				stream:           mss,
+				recvOrigDest:     receiverConfig.ReceiveOriginalDestination,
+				tag:              tag,
+				dispatcher:       h.mux,
+				sniffingConfig:   receiverConfig.GetEffectiveSniffingSettings(),
+				uplinkCounter:    uplinkCounter,
+				downlinkCounter:  downlinkCounter,
+				handshakeTimeout: handshakeTimeout,
 			}
 			h.workers = append(h.workers, worker)
 		}
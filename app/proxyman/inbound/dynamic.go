@@ -112,6 +112,7 @@ func (h *DynamicInboundHandler) refresh() error {
 	}
 
 	uplinkCounter, downlinkCounter := getStatCounter(h.v, h.tag)
+	handshakeTimeout := getHandshakeTimeout(h.v)
 
 	for i := uint32(0); i < concurrency; i++ {
 		port := h.allocatePort()
@@ -124,16 +125,17 @@ func (h *DynamicInboundHandler) refresh() error {
 		nl := p.Network()
 		if net.HasNetwork(nl, net.Network_TCP) {
 			worker := &tcpWorker{
-				tag:             h.tag,
-				address:         address,
-				port:            port,
-				proxy:           p,
-				stream:          h.streamSettings,
-				recvOrigDest:    h.receiverConfig.ReceiveOriginalDestination,
-				dispatcher:      h.mux,
-				sniffingConfig:  h.receiverConfig.GetEffectiveSniffingSettings(),
-				uplinkCounter:   uplinkCounter,
-				downlinkCounter: downlinkCounter,
+				tag:              h.tag,
+				address:          address,
+				port:             port,
+				proxy:            p,
+				stream:           h.streamSettings,
+				recvOrigDest:     h.receiverConfig.ReceiveOriginalDestination,
+				dispatcher:       h.mux,
+				sniffingConfig:   h.receiverConfig.GetEffectiveSniffingSettings(),
+				uplinkCounter:    uplinkCounter,
+				downlinkCounter:  downlinkCounter,
+				handshakeTimeout: handshakeTimeout,
 			}
 			if err := worker.Start(); err != nil {
 				newError("failed to create TCP worker").Base(err).AtWarning().WriteToLog()
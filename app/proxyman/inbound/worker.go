@@ -10,6 +10,7 @@ import (
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/common/serial"
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/common/signal/done"
@@ -41,6 +42,8 @@ type tcpWorker struct {
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
+	// handshakeTimeout bounds the TLS handshake that identifies users by client certificates.
+	handshakeTimeout time.Duration
 
 	hub internet.Listener
 }
@@ -52,6 +55,11 @@ func getTProxyType(s *internet.MemoryStreamConfig) internet.SocketConfig_TProxyM
 	return s.SocketSettings.Tproxy
 }
 
+// hasVerifiedUser is implemented by connections that identify users by client certificates.
+type hasVerifiedUser interface {
+	VerifiedUser(handshakeTimeout time.Duration) *protocol.MemoryUser
+}
+
 func (w *tcpWorker) callback(conn internet.Connection) {
 	ctx, cancel := context.WithCancel(context.Background())
 	sid := session.NewID()
@@ -76,11 +84,15 @@ func (w *tcpWorker) callback(conn internet.Connection) {
 			})
 		}
 	}
-	ctx = session.ContextWithInbound(ctx, &session.Inbound{
+	inbound := &session.Inbound{
 		Source:  net.DestinationFromAddr(conn.RemoteAddr()),
 		Gateway: net.TCPDestination(w.address, w.port),
 		Tag:     w.tag,
-	})
+	}
+	if verified, ok := conn.(hasVerifiedUser); ok {
+		inbound.User = verified.VerifiedUser(w.handshakeTimeout)
+	}
+	ctx = session.ContextWithInbound(ctx, inbound)
 	if w.sniffingConfig != nil {
 		ctx = proxyman.ContextWithSniffingConfig(ctx, w.sniffingConfig)
 	}
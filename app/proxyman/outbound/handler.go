@@ -2,10 +2,14 @@ package outbound
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"time"
 
 	"v2ray.com/core"
 	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/mux"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/session"
@@ -129,17 +133,12 @@ func (h *Handler) Dial(ctx context.Context, dest net.Destination) (internet.Conn
 			tag := h.senderSettings.ProxySettings.Tag
 			handler := h.outboundManager.GetHandler(tag)
 			if handler != nil {
+				if err := h.checkProxyChain(); err != nil {
+					return nil, err
+				}
 				newError("proxying to ", tag, " for dest ", dest).AtDebug().WriteToLog(session.ExportIDToError(ctx))
-				ctx = session.ContextWithOutbound(ctx, &session.Outbound{
-					Target: dest,
-				})
-
-				opts := pipe.OptionsFromContext(ctx)
-				uplinkReader, uplinkWriter := pipe.New(opts...)
-				downlinkReader, downlinkWriter := pipe.New(opts...)
-
-				go handler.Dispatch(ctx, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter})
-				return net.NewConnection(net.ConnectionInputMulti(uplinkWriter), net.ConnectionOutputMulti(downlinkReader)), nil
+				ctx = internet.ContextWithSystemDialer(ctx, proxyDialer{handler: handler})
+				return internet.Dial(ctx, dest, h.streamSettings)
 			}
 
 			newError("failed to get outbound handler with tag: ", tag).AtWarning().WriteToLog(session.ExportIDToError(ctx))
@@ -158,6 +157,148 @@ func (h *Handler) Dial(ctx context.Context, dest net.Destination) (internet.Conn
 	return internet.Dial(ctx, dest, h.streamSettings)
 }
 
+// checkProxyChain follows the proxy settings from this handler, and returns an error if the chain comes back to a
+// handler in it.
+func (h *Handler) checkProxyChain() error {
+	chain := []string{h.tag}
+	visited := map[string]bool{h.tag: true}
+	current := h
+	for current.senderSettings != nil && current.senderSettings.ProxySettings.HasTag() {
+		tag := current.senderSettings.ProxySettings.Tag
+		chain = append(chain, tag)
+		if visited[tag] {
+			return newError("proxy loop detected: ", strings.Join(chain, " -> ")).AtWarning()
+		}
+		visited[tag] = true
+
+		next, ok := h.outboundManager.GetHandler(tag).(*Handler)
+		if !ok {
+			break
+		}
+		current = next
+	}
+	return nil
+}
+
+// proxyDialer opens raw connections through an outbound handler.
+type proxyDialer struct {
+	handler outbound.Handler
+}
+
+// Dial implements internet.SystemDialer.
+func (d proxyDialer) Dial(ctx context.Context, src net.Address, dest net.Destination, sockopt *internet.SocketConfig) (net.Conn, error) {
+	ctx = session.ContextWithOutbound(ctx, &session.Outbound{
+		Target: dest,
+	})
+
+	opts := pipe.OptionsFromContext(ctx)
+	uplinkReader, uplinkWriter := pipe.New(opts...)
+	downlinkReader, downlinkWriter := pipe.New(opts...)
+
+	go d.handler.Dispatch(ctx, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter})
+	return net.NewConnection(net.ConnectionInputMulti(uplinkWriter), net.ConnectionOutputMulti(downlinkReader)), nil
+}
+
+// DialPacket implements internet.PacketDialer.
+func (d proxyDialer) DialPacket(ctx context.Context, dest net.Destination, sockopt *internet.SocketConfig) (net.PacketConn, error) {
+	dest.Network = net.Network_UDP
+	ctx = session.ContextWithOutbound(ctx, &session.Outbound{
+		Target: dest,
+	})
+
+	opts := pipe.OptionsFromContext(ctx)
+	uplinkReader, uplinkWriter := pipe.New(opts...)
+	downlinkReader, downlinkWriter := pipe.New(opts...)
+
+	// Packets are read as from the destination. A domain is reported as an unspecified IP.
+	remote := &net.UDPAddr{
+		IP:   net.AnyIP.IP(),
+		Port: int(dest.Port),
+	}
+	if dest.Address.Family().IsIP() {
+		remote.IP = dest.Address.IP()
+	}
+
+	go d.handler.Dispatch(ctx, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter})
+	return &packetConn{
+		reader: downlinkReader,
+		writer: uplinkWriter,
+		remote: remote,
+	}, nil
+}
+
+// packetConn is a net.PacketConn over an outbound link. Each buffer in the link carries one packet.
+type packetConn struct {
+	access sync.Mutex
+	reader *pipe.Reader
+	writer *pipe.Writer
+	remote net.Addr
+	cache  buf.MultiBuffer
+}
+
+// ReadFrom implements net.PacketConn.
+func (c *packetConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	for c.cache.IsEmpty() {
+		mb, err := c.reader.ReadMultiBuffer()
+		if err != nil {
+			return 0, nil, err
+		}
+		c.cache = mb
+	}
+
+	var b *buf.Buffer
+	c.cache, b = buf.SplitFirst(c.cache)
+	defer b.Release()
+
+	n := copy(p, b.Bytes())
+	return n, c.remote, nil
+}
+
+// WriteTo implements net.PacketConn. Packets are always sent to the destination of the connection.
+func (c *packetConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	if len(p) > buf.Size {
+		return 0, newError("packet too large: ", len(p))
+	}
+	b := buf.New()
+	common.Must2(b.Write(p))
+	if err := c.writer.WriteMultiBuffer(buf.MultiBuffer{b}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close implements net.PacketConn.
+func (c *packetConn) Close() error {
+	c.reader.CloseError()
+	return c.writer.Close()
+}
+
+// LocalAddr implements net.PacketConn.
+func (c *packetConn) LocalAddr() net.Addr {
+	return &net.UDPAddr{
+		IP:   []byte{0, 0, 0, 0},
+		Port: 0,
+	}
+}
+
+// SetDeadline implements net.PacketConn.
+func (c *packetConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// SetReadDeadline implements net.PacketConn.
+func (c *packetConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline implements net.PacketConn.
+func (c *packetConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
 // GetOutbound implements proxy.GetOutbound.
 func (h *Handler) GetOutbound() proxy.Outbound {
 	return h.proxy
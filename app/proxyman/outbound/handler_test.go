@@ -1,10 +1,25 @@
 package outbound_test
 
 import (
+	"context"
+	"io"
 	"testing"
 
+	"v2ray.com/core"
+	"v2ray.com/core/app/proxyman"
 	. "v2ray.com/core/app/proxyman/outbound"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/common/serial"
 	"v2ray.com/core/features/outbound"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/servers/udp"
+	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/quic"
+	_ "v2ray.com/core/transport/internet/tcp"
+	_ "v2ray.com/core/transport/internet/udp"
+	"v2ray.com/core/transport/internet/websocket"
 	. "v2ray.com/ext/assert"
 )
 
@@ -14,3 +29,160 @@ func TestInterfaces(t *testing.T) {
 	assert((*Handler)(nil), Implements, (*outbound.Handler)(nil))
 	assert((*Manager)(nil), Implements, (*outbound.Manager)(nil))
 }
+
+func getHandler(t *testing.T, outbounds []*core.OutboundHandlerConfig, tag string) *Handler {
+	t.Helper()
+
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		},
+		Outbound: outbounds,
+	})
+	common.Must(err)
+	return v.GetFeature(outbound.ManagerType()).(outbound.Manager).GetHandler(tag).(*Handler)
+}
+
+func TestProxyChainWithStreamSettings(t *testing.T) {
+	listener, err := websocket.ListenWS(context.Background(), net.LocalHostIP, 0, &internet.MemoryStreamConfig{
+		ProtocolName:     "websocket",
+		ProtocolSettings: &websocket.Config{Path: "/ws"},
+	}, func(conn internet.Connection) {
+		go func() {
+			defer conn.Close()
+			io.Copy(conn, conn)
+		}()
+	})
+	common.Must(err)
+	defer listener.Close()
+	port := net.Port(listener.Addr().(*net.TCPAddr).Port)
+
+	handler := getHandler(t, []*core.OutboundHandlerConfig{
+		{
+			Tag:           "ws",
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			SenderSettings: serial.ToTypedMessage(&proxyman.SenderConfig{
+				StreamSettings: &internet.StreamConfig{
+					ProtocolName: "websocket",
+					TransportSettings: []*internet.TransportConfig{
+						{
+							ProtocolName: "websocket",
+							Settings:     serial.ToTypedMessage(&websocket.Config{Path: "/ws"}),
+						},
+					},
+				},
+				ProxySettings: &internet.ProxyConfig{Tag: "hop1"},
+			}),
+		},
+		{
+			Tag:           "hop1",
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			SenderSettings: serial.ToTypedMessage(&proxyman.SenderConfig{
+				ProxySettings: &internet.ProxyConfig{Tag: "hop2"},
+			}),
+		},
+		{
+			// The last hop reaches the WebSocket server regardless of the destination.
+			Tag: "hop2",
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{
+				DestinationOverride: &freedom.DestinationOverride{
+					Server: &protocol.ServerEndpoint{
+						Address: net.NewIPOrDomain(net.LocalHostIP),
+						Port:    uint32(port),
+					},
+				},
+			}),
+		},
+	}, "ws")
+
+	conn, err := handler.Dial(context.Background(), net.TCPDestination(net.DomainAddress("example.com"), 80))
+	common.Must(err)
+	defer conn.Close()
+
+	common.Must2(conn.Write([]byte("test payload")))
+	b := make([]byte, 12)
+	common.Must2(io.ReadFull(conn, b))
+	if string(b) != "test payload" {
+		t.Error("unexpected response: ", string(b))
+	}
+}
+
+func TestProxyChainWithQUIC(t *testing.T) {
+	port := udp.PickPort()
+	listener, err := quic.Listen(context.Background(), net.LocalHostIP, port, &internet.MemoryStreamConfig{
+		ProtocolName:     "quic",
+		ProtocolSettings: &quic.Config{},
+	}, func(conn internet.Connection) {
+		go func() {
+			defer conn.Close()
+			io.Copy(conn, conn)
+		}()
+	})
+	common.Must(err)
+	defer listener.Close()
+
+	handler := getHandler(t, []*core.OutboundHandlerConfig{
+		{
+			Tag:           "quic",
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			SenderSettings: serial.ToTypedMessage(&proxyman.SenderConfig{
+				StreamSettings: &internet.StreamConfig{
+					ProtocolName: "quic",
+					TransportSettings: []*internet.TransportConfig{
+						{
+							ProtocolName: "quic",
+							Settings:     serial.ToTypedMessage(&quic.Config{}),
+						},
+					},
+				},
+				ProxySettings: &internet.ProxyConfig{Tag: "hop"},
+			}),
+		},
+		{
+			// The hop reaches the QUIC server regardless of the destination, so a direct dial would fail.
+			Tag: "hop",
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{
+				DestinationOverride: &freedom.DestinationOverride{
+					Server: &protocol.ServerEndpoint{
+						Address: net.NewIPOrDomain(net.LocalHostIP),
+						Port:    uint32(port),
+					},
+				},
+			}),
+		},
+	}, "quic")
+
+	conn, err := handler.Dial(context.Background(), net.TCPDestination(net.LocalHostIP, udp.PickPort()))
+	common.Must(err)
+	defer conn.Close()
+
+	common.Must2(conn.Write([]byte("test payload")))
+	b := make([]byte, 12)
+	common.Must2(io.ReadFull(conn, b))
+	if string(b) != "test payload" {
+		t.Error("unexpected response: ", string(b))
+	}
+}
+
+func TestProxyChainLoop(t *testing.T) {
+	handler := getHandler(t, []*core.OutboundHandlerConfig{
+		{
+			Tag:           "a",
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			SenderSettings: serial.ToTypedMessage(&proxyman.SenderConfig{
+				ProxySettings: &internet.ProxyConfig{Tag: "b"},
+			}),
+		},
+		{
+			Tag:           "b",
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			SenderSettings: serial.ToTypedMessage(&proxyman.SenderConfig{
+				ProxySettings: &internet.ProxyConfig{Tag: "a"},
+			}),
+		},
+	}, "a")
+
+	if _, err := handler.Dial(context.Background(), net.TCPDestination(net.LocalHostIP, 80)); err == nil {
+		t.Error("expected proxy loop error, but got nil")
+	}
+}
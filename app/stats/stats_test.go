@@ -30,3 +30,19 @@ func TestStatsCounter(t *testing.T) {
 	assert(c.Set(0), Equals, int64(1))
 	assert(c.Value(), Equals, int64(0))
 }
+
+func TestUnregisterCounter(t *testing.T) {
+	assert := With(t)
+
+	raw, err := common.CreateObject(context.Background(), &Config{})
+	assert(err, IsNil)
+
+	m := raw.(stats.Manager)
+	c, err := m.RegisterCounter("test.counter")
+	assert(err, IsNil)
+	assert(m.GetCounter("test.counter"), Equals, c)
+
+	assert(stats.UnregisterCounter(m, "test.counter"), IsNil)
+	assert(m.GetCounter("test.counter"), IsNil)
+	assert(stats.UnregisterCounter(m, "test.counter"), IsNil)
+}
@@ -0,0 +1,86 @@
+// Package antireplay provides a time-bounded filter for detecting replayed nonces, IVs and authentication hashes.
+package antireplay
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common"
+)
+
+// ReplayFilter remembers items seen in a time window, using two rotating bloom filters.
+// An item is remembered for at least one window and at most two. The filters rotate by
+// time only, so a flood of items can't make the filter forget earlier ones. Instead, once
+// capacity items are seen in one window, further new items are rejected until the window ends.
+type ReplayFilter struct {
+	sync.Mutex
+	seed     [16]byte
+	current  *bloomFilter
+	previous *bloomFilter
+	capacity uint32
+	window   time.Duration
+	lastSwap time.Time
+	now      func() time.Time
+}
+
+// NewReplayFilter creates a new ReplayFilter with the given window and capacity.
+func NewReplayFilter(window time.Duration, capacity uint32) *ReplayFilter {
+	if capacity == 0 {
+		capacity = defaultCapacity
+	}
+	filter := &ReplayFilter{
+		current:  newBloomFilter(capacity),
+		previous: newBloomFilter(capacity),
+		capacity: capacity,
+		window:   window,
+		lastSwap: time.Now(),
+		now:      time.Now,
+	}
+	common.Must2(rand.Read(filter.seed[:]))
+	return filter
+}
+
+// Window returns the window of this filter.
+func (f *ReplayFilter) Window() time.Duration {
+	return f.window
+}
+
+// Check adds the given item into the filter. It returns false if the item was seen before, i.e., a replay,
+// or if the filter is full in the current window.
+func (f *ReplayFilter) Check(item []byte) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	now := f.now()
+	if elapsed := now.Sub(f.lastSwap); elapsed >= f.window {
+		f.previous, f.current = f.current, f.previous
+		f.current.Reset()
+		if elapsed >= f.window*2 {
+			f.previous.Reset()
+		}
+		f.lastSwap = now
+	}
+
+	h1, h2 := f.hash(item)
+	if f.current.Test(h1, h2) || f.previous.Test(h1, h2) {
+		return false
+	}
+	if f.current.count >= f.capacity {
+		return false
+	}
+	f.current.Add(h1, h2)
+	return true
+}
+
+// hash returns two independent hashes of the item, keyed by the random seed of the filter.
+func (f *ReplayFilter) hash(item []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	common.Must2(h.Write(f.seed[:]))
+	common.Must2(h.Write(item))
+	var sum [16]byte
+	h.Sum(sum[:0])
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:])
+}
@@ -0,0 +1,40 @@
+package antireplay_test
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	. "v2ray.com/core/common/antireplay"
+)
+
+func TestReplayFilter(t *testing.T) {
+	filter := NewReplayFilter(time.Second, 1024)
+
+	items := make([][]byte, 512)
+	for i := range items {
+		items[i] = make([]byte, 16)
+		common.Must2(rand.Read(items[i]))
+		if !filter.Check(items[i]) {
+			t.Fatal("unexpected replay of item ", i)
+		}
+	}
+
+	for i, item := range items {
+		if filter.Check(item) {
+			t.Error("replay of item ", i, " not detected")
+		}
+	}
+}
+
+func BenchmarkReplayFilter(b *testing.B) {
+	filter := NewReplayFilter(time.Minute, 100000)
+	item := make([]byte, 16)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		common.Must2(rand.Read(item))
+		filter.Check(item)
+	}
+}
@@ -0,0 +1,54 @@
+package antireplay
+
+import "math"
+
+// falsePositiveRate is the target false positive rate of each bloom filter, when it holds as many items as its capacity.
+const falsePositiveRate = 0.000001
+
+type bloomFilter struct {
+	bits  []uint64
+	m     uint64
+	k     uint32
+	count uint32
+}
+
+func newBloomFilter(capacity uint32) *bloomFilter {
+	m := uint64(math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	k := uint32(math.Round(float64(m) / float64(capacity) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+	return &bloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// Test returns true if the item of the given hashes may have been added.
+func (f *bloomFilter) Test(h1, h2 uint64) bool {
+	for i := uint32(0); i < f.k; i++ {
+		pos := (h1 + uint64(i)*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds the item of the given hashes into the filter.
+func (f *bloomFilter) Add(h1, h2 uint64) {
+	for i := uint32(0); i < f.k; i++ {
+		pos := (h1 + uint64(i)*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+	f.count++
+}
+
+// Reset removes all items from the filter.
+func (f *bloomFilter) Reset() {
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+	f.count = 0
+}
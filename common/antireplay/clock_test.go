@@ -0,0 +1,90 @@
+package antireplay
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestFilter(window time.Duration, capacity uint32) (*ReplayFilter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1000000, 0)}
+	filter := NewReplayFilter(window, capacity)
+	filter.now = clock.Now
+	filter.lastSwap = clock.now
+	return filter, clock
+}
+
+func TestReplayFilterExpire(t *testing.T) {
+	filter, clock := newTestFilter(time.Minute, 1024)
+
+	item := []byte("test item")
+	if !filter.Check(item) {
+		t.Fatal("unexpected replay")
+	}
+
+	clock.now = clock.now.Add(time.Minute + time.Second*59)
+	if filter.Check(item) {
+		t.Error("item is forgotten within two windows")
+	}
+
+	clock.now = clock.now.Add(time.Minute * 2)
+	if !filter.Check(item) {
+		t.Error("item is not forgotten after two windows")
+	}
+}
+
+func TestReplayFilterIdle(t *testing.T) {
+	filter, clock := newTestFilter(time.Minute, 1024)
+
+	item := []byte("test item")
+	if !filter.Check(item) {
+		t.Fatal("unexpected replay")
+	}
+
+	clock.now = clock.now.Add(time.Minute * 2)
+	if !filter.Check(item) {
+		t.Error("item is not forgotten after two idle windows")
+	}
+}
+
+func TestReplayFilterCapacity(t *testing.T) {
+	filter, clock := newTestFilter(time.Minute, 16)
+
+	item := []byte("test item")
+	if !filter.Check(item) {
+		t.Fatal("unexpected replay")
+	}
+
+	for i := 0; i < 64; i++ {
+		b := make([]byte, 16)
+		common.Must2(rand.Read(b))
+		accepted := filter.Check(b)
+		if i < 15 && !accepted {
+			t.Fatal("item ", i, " rejected before the filter is full")
+		}
+		if i >= 15 && accepted {
+			t.Fatal("item ", i, " accepted after the filter is full")
+		}
+	}
+	if filter.Check(item) {
+		t.Error("item is forgotten after the filter is full")
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if !filter.Check([]byte("another item")) {
+		t.Error("new item is rejected in the next window")
+	}
+	if filter.Check(item) {
+		t.Error("item is forgotten after one rotation")
+	}
+}
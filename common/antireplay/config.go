@@ -0,0 +1,29 @@
+package antireplay
+
+import "time"
+
+const (
+	defaultWindow   = 240
+	defaultCapacity = 100000
+)
+
+// GetWindowValue returns the window of the filter, or the default value if not set.
+func (c *Config) GetWindowValue() time.Duration {
+	if c == nil || c.Window == 0 {
+		return time.Second * defaultWindow
+	}
+	return time.Second * time.Duration(c.Window)
+}
+
+// GetCapacityValue returns the capacity of the filter, or the default value if not set.
+func (c *Config) GetCapacityValue() uint32 {
+	if c == nil || c.Capacity == 0 {
+		return defaultCapacity
+	}
+	return c.Capacity
+}
+
+// NewFilter creates a new ReplayFilter from the config. The config may be nil.
+func (c *Config) NewFilter() *ReplayFilter {
+	return NewReplayFilter(c.GetWindowValue(), c.GetCapacityValue())
+}
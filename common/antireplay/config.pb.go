@@ -0,0 +1,92 @@
+package antireplay
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Config is the config of a replay filter.
+type Config struct {
+	// Window in seconds, during which a seen item is remembered. 240 if not set.
+	Window uint32 `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	// Maximum number of new items accepted in each window. It bounds the memory usage
+	// of the filter. Further new items are rejected until the window ends. 100000 if not set.
+	Capacity             uint32   `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Config) Reset()         { *m = Config{} }
+func (m *Config) String() string { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()    {}
+func (*Config) Descriptor() ([]byte, []int) {
+	return fileDescriptor_700ef06ce37b576a, []int{0}
+}
+
+func (m *Config) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Config.Unmarshal(m, b)
+}
+func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+}
+func (m *Config) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Config.Merge(m, src)
+}
+func (m *Config) XXX_Size() int {
+	return xxx_messageInfo_Config.Size(m)
+}
+func (m *Config) XXX_DiscardUnknown() {
+	xxx_messageInfo_Config.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Config proto.InternalMessageInfo
+
+func (m *Config) GetWindow() uint32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+func (m *Config) GetCapacity() uint32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Config)(nil), "v2ray.core.common.antireplay.Config")
+}
+
+func init() {
+	proto.RegisterFile("v2ray.com/core/common/antireplay/config.proto", fileDescriptor_700ef06ce37b576a)
+}
+
+var fileDescriptor_700ef06ce37b576a = []byte{
+	// 166 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x2d, 0x33, 0x2a, 0x4a,
+	0xac, 0xd4, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xce, 0x2f, 0x4a, 0xd5, 0x4f, 0xce, 0xcf, 0xcd, 0xcd,
+	0xcf, 0xd3, 0x4f, 0xcc, 0x2b, 0xc9, 0x2c, 0x4a, 0x2d, 0xc8, 0x49, 0xac, 0xd4, 0x4f, 0xce, 0xcf,
+	0x4b, 0xcb, 0x4c, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x92, 0x81, 0x29, 0x2f, 0x4a, 0xd5,
+	0x83, 0x28, 0xd5, 0x43, 0x28, 0x55, 0xb2, 0xe1, 0x62, 0x73, 0x06, 0xab, 0x16, 0x12, 0xe3, 0x62,
+	0x2b, 0xcf, 0xcc, 0x4b, 0xc9, 0x2f, 0x97, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0d, 0x82, 0xf2, 0x84,
+	0xa4, 0xb8, 0x38, 0x92, 0x13, 0x0b, 0x12, 0x93, 0x33, 0x4b, 0x2a, 0x25, 0x98, 0xc0, 0x32, 0x70,
+	0xbe, 0x93, 0x3f, 0x97, 0x42, 0x72, 0x7e, 0xae, 0x1e, 0x3e, 0x1b, 0x02, 0x18, 0xa3, 0xb8, 0x10,
+	0xbc, 0x55, 0x4c, 0x32, 0x61, 0x46, 0x41, 0x89, 0x95, 0x7a, 0xce, 0x20, 0xc5, 0xce, 0x10, 0xc5,
+	0x8e, 0x70, 0xe9, 0x24, 0x36, 0xb0, 0x9b, 0x8d, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xde, 0x89,
+	0x33, 0xda, 0xe4, 0x00, 0x00, 0x00,
+}
@@ -28,6 +28,20 @@ type Manager interface {
 	GetCounter(string) Counter
 }
 
+// CounterUnregisterer is optionally implemented by a Manager that can remove counters.
+type CounterUnregisterer interface {
+	// UnregisterCounter removes a counter from the manager by its identifier.
+	UnregisterCounter(string) error
+}
+
+// UnregisterCounter removes a counter from the manager, if the manager supports it.
+func UnregisterCounter(m Manager, name string) error {
+	if u, ok := m.(CounterUnregisterer); ok {
+		return u.UnregisterCounter(name)
+	}
+	return nil
+}
+
 // GetOrRegisterCounter tries to get the StatCounter first. If not exist, it then tries to create a new counter.
 func GetOrRegisterCounter(m Manager, name string) (Counter, error) {
 	counter := m.GetCounter(name)
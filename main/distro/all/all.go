@@ -35,6 +35,7 @@ import (
 
 	// Transports
 	_ "v2ray.com/core/transport/internet/domainsocket"
+	_ "v2ray.com/core/transport/internet/grpc"
 	_ "v2ray.com/core/transport/internet/http"
 	_ "v2ray.com/core/transport/internet/kcp"
 	_ "v2ray.com/core/transport/internet/quic"
@@ -44,8 +45,10 @@ import (
 	_ "v2ray.com/core/transport/internet/websocket"
 
 	// Transport headers
+	_ "v2ray.com/core/transport/internet/headers/dns"
 	_ "v2ray.com/core/transport/internet/headers/http"
 	_ "v2ray.com/core/transport/internet/headers/noop"
+	_ "v2ray.com/core/transport/internet/headers/quic"
 	_ "v2ray.com/core/transport/internet/headers/srtp"
 	_ "v2ray.com/core/transport/internet/headers/tls"
 	_ "v2ray.com/core/transport/internet/headers/utp"
@@ -1,5 +1,18 @@
 package freedom
 
+import (
+	"time"
+
+	"v2ray.com/core/transport/internet"
+)
+
 func (c *Config) useIP() bool {
 	return c.DomainStrategy == Config_USE_IP || c.DomainStrategy == Config_USE_IP4 || c.DomainStrategy == Config_USE_IP6
 }
+
+func (c *Config) happyEyeballsDelay() time.Duration {
+	if c.HappyEyeballsDelay == 0 {
+		return internet.DefaultHappyEyeballsDelay
+	}
+	return time.Duration(c.HappyEyeballsDelay) * time.Millisecond
+}
@@ -89,13 +89,17 @@ func (m *DestinationOverride) GetServer() *protocol.ServerEndpoint {
 }
 
 type Config struct {
-	DomainStrategy       Config_DomainStrategy `protobuf:"varint,1,opt,name=domain_strategy,json=domainStrategy,proto3,enum=v2ray.core.proxy.freedom.Config_DomainStrategy" json:"domain_strategy,omitempty"`
-	Timeout              uint32                `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"` // Deprecated: Do not use.
-	DestinationOverride  *DestinationOverride  `protobuf:"bytes,3,opt,name=destination_override,json=destinationOverride,proto3" json:"destination_override,omitempty"`
-	UserLevel            uint32                `protobuf:"varint,4,opt,name=user_level,json=userLevel,proto3" json:"user_level,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	DomainStrategy      Config_DomainStrategy `protobuf:"varint,1,opt,name=domain_strategy,json=domainStrategy,proto3,enum=v2ray.core.proxy.freedom.Config_DomainStrategy" json:"domain_strategy,omitempty"`
+	Timeout             uint32                `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"` // Deprecated: Do not use.
+	DestinationOverride *DestinationOverride  `protobuf:"bytes,3,opt,name=destination_override,json=destinationOverride,proto3" json:"destination_override,omitempty"`
+	UserLevel           uint32                `protobuf:"varint,4,opt,name=user_level,json=userLevel,proto3" json:"user_level,omitempty"`
+	// Delay in milliseconds between connection attempts to the IP addresses of
+	// a domain, when domain_strategy is USE_IP. Default value 250 ms is used if
+	// zero.
+	HappyEyeballsDelay   uint32   `protobuf:"varint,5,opt,name=happy_eyeballs_delay,json=happyEyeballsDelay,proto3" json:"happy_eyeballs_delay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Config) Reset()         { *m = Config{} }
@@ -152,6 +156,13 @@ func (m *Config) GetUserLevel() uint32 {
 	return 0
 }
 
+func (m *Config) GetHappyEyeballsDelay() uint32 {
+	if m != nil {
+		return m.HappyEyeballsDelay
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("v2ray.core.proxy.freedom.Config_DomainStrategy", Config_DomainStrategy_name, Config_DomainStrategy_value)
 	proto.RegisterType((*DestinationOverride)(nil), "v2ray.core.proxy.freedom.DestinationOverride")
@@ -163,28 +174,30 @@ func init() {
 }
 
 var fileDescriptor_66807b6fe2cca4da = []byte{
-	// 357 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x51, 0x5d, 0x4b, 0xeb, 0x40,
-	0x10, 0xbd, 0x49, 0xef, 0x4d, 0xe9, 0x94, 0xdb, 0x1b, 0xb6, 0xf7, 0x21, 0x48, 0x85, 0xd2, 0xa7,
-	0x2a, 0xb8, 0x91, 0x28, 0xbe, 0xf7, 0x4b, 0x28, 0x08, 0x96, 0x04, 0x45, 0x7d, 0x89, 0x31, 0x99,
-	0x96, 0x40, 0xb3, 0x1b, 0x36, 0xdb, 0x60, 0xfe, 0x92, 0xbf, 0xc3, 0x1f, 0x26, 0xd9, 0xa4, 0xd4,
-	0x4a, 0xfb, 0x36, 0x73, 0xf6, 0x9c, 0x33, 0x73, 0x66, 0xe1, 0x2c, 0x77, 0x44, 0x50, 0xd0, 0x90,
-	0x27, 0x76, 0xc8, 0x05, 0xda, 0xa9, 0xe0, 0xef, 0x85, 0xbd, 0x14, 0x88, 0x91, 0x82, 0xd8, 0x32,
-	0x5e, 0xd1, 0x54, 0x70, 0xc9, 0x89, 0xb5, 0xa5, 0x0a, 0xa4, 0x8a, 0x46, 0x6b, 0xda, 0xc9, 0xe5,
-	0x0f, 0x93, 0x90, 0x27, 0x09, 0x67, 0xb6, 0x92, 0x85, 0x7c, 0x6d, 0x67, 0x28, 0x72, 0x14, 0x7e,
-	0x96, 0x62, 0x58, 0x79, 0x0d, 0x9e, 0xa1, 0x3b, 0xc5, 0x4c, 0xc6, 0x2c, 0x90, 0x31, 0x67, 0xf7,
-	0x39, 0x0a, 0x11, 0x47, 0x48, 0xc6, 0x60, 0x54, 0x5c, 0x4b, 0xeb, 0x6b, 0xc3, 0xb6, 0x73, 0x4e,
-	0xbf, 0xcd, 0xac, 0x5c, 0xe9, 0xd6, 0x95, 0x7a, 0x8a, 0x39, 0x63, 0x51, 0xca, 0x63, 0x26, 0xdd,
-	0x5a, 0x39, 0xf8, 0xd4, 0xc1, 0x98, 0xa8, 0xbd, 0xc9, 0x13, 0xfc, 0x8b, 0x78, 0x12, 0xc4, 0xcc,
-	0xcf, 0xa4, 0x08, 0x24, 0xae, 0x0a, 0xe5, 0xdb, 0x71, 0x6c, 0x7a, 0x2c, 0x0b, 0xad, 0xa4, 0x74,
-	0xaa, 0x74, 0x5e, 0x2d, 0x73, 0x3b, 0xd1, 0x5e, 0x4f, 0x7a, 0xd0, 0x94, 0x71, 0x82, 0x7c, 0x23,
-	0x2d, 0xbd, 0xaf, 0x0d, 0xff, 0x8e, 0x75, 0x4b, 0x73, 0xb7, 0x10, 0x79, 0x85, 0xff, 0xd1, 0x2e,
-	0x9d, 0xcf, 0xeb, 0x78, 0x56, 0x43, 0x85, 0xba, 0x38, 0x3e, 0xfc, 0xc0, 0x4d, 0xdc, 0x6e, 0x74,
-	0xe0, 0x50, 0xa7, 0x00, 0x9b, 0x0c, 0x85, 0xbf, 0xc6, 0x1c, 0xd7, 0xd6, 0xef, 0x72, 0x05, 0xb7,
-	0x55, 0x22, 0x77, 0x25, 0x30, 0x18, 0x41, 0x67, 0x3f, 0x00, 0x69, 0xc1, 0x9f, 0x91, 0xe7, 0xcf,
-	0x3d, 0xf3, 0x17, 0x01, 0x30, 0x1e, 0xbc, 0x99, 0x3f, 0x5f, 0x98, 0x1a, 0x69, 0x43, 0xb3, 0xaa,
-	0xaf, 0x4d, 0x7d, 0xd7, 0xdc, 0x98, 0x8d, 0xf1, 0x14, 0x7a, 0x21, 0x4f, 0x8e, 0xae, 0xba, 0xd0,
-	0x5e, 0x9a, 0x75, 0xf9, 0xa1, 0x5b, 0x8f, 0x8e, 0x1b, 0x14, 0x74, 0x52, 0xb2, 0x16, 0x8a, 0x75,
-	0x5b, 0x3d, 0xbd, 0x19, 0xea, 0xb7, 0xae, 0xbe, 0x02, 0x00, 0x00, 0xff, 0xff, 0x72, 0x98, 0x5b,
-	0x40, 0x67, 0x02, 0x00, 0x00,
+	// 386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xe1, 0x8b, 0xd3, 0x30,
+	0x18, 0xc6, 0x6d, 0xcf, 0xeb, 0xb8, 0xf7, 0x70, 0x96, 0xdc, 0x7d, 0x08, 0x72, 0xc2, 0xb1, 0x4f,
+	0xa7, 0x60, 0x7a, 0x54, 0xf1, 0xfb, 0xb6, 0x4e, 0x18, 0x08, 0x8e, 0x16, 0x45, 0xfd, 0x12, 0xb3,
+	0xe6, 0xdd, 0x2c, 0xb4, 0x4d, 0x49, 0xb3, 0x62, 0xff, 0x25, 0xff, 0x40, 0x3f, 0x4b, 0xd3, 0x8e,
+	0x39, 0xd9, 0xbe, 0x25, 0xef, 0xf3, 0x7b, 0x9e, 0xe4, 0x49, 0xe0, 0x55, 0x13, 0x6a, 0xd1, 0xb2,
+	0x54, 0x15, 0x41, 0xaa, 0x34, 0x06, 0x95, 0x56, 0xbf, 0xda, 0x60, 0xa3, 0x11, 0xa5, 0x1d, 0x95,
+	0x9b, 0x6c, 0xcb, 0x2a, 0xad, 0x8c, 0x22, 0x74, 0x8f, 0x6a, 0x64, 0x16, 0x63, 0x03, 0xf6, 0xe2,
+	0xf1, 0xbf, 0x90, 0x54, 0x15, 0x85, 0x2a, 0x03, 0x6b, 0x4b, 0x55, 0x1e, 0xd4, 0xa8, 0x1b, 0xd4,
+	0xbc, 0xae, 0x30, 0xed, 0xb3, 0x26, 0xdf, 0xe0, 0x26, 0xc2, 0xda, 0x64, 0xa5, 0x30, 0x99, 0x2a,
+	0x3f, 0x35, 0xa8, 0x75, 0x26, 0x91, 0xcc, 0xc0, 0xeb, 0x59, 0xea, 0xdc, 0x3b, 0x0f, 0xd7, 0xe1,
+	0x6b, 0xf6, 0xcf, 0x99, 0x7d, 0x2a, 0xdb, 0xa7, 0xb2, 0xc4, 0x92, 0x8b, 0x52, 0x56, 0x2a, 0x2b,
+	0x4d, 0x3c, 0x38, 0x27, 0x7f, 0x5c, 0xf0, 0xe6, 0xf6, 0xde, 0xe4, 0x2b, 0x3c, 0x97, 0xaa, 0x10,
+	0x59, 0xc9, 0x6b, 0xa3, 0x85, 0xc1, 0x6d, 0x6b, 0x73, 0xc7, 0x61, 0xc0, 0xce, 0x75, 0x61, 0xbd,
+	0x95, 0x45, 0xd6, 0x97, 0x0c, 0xb6, 0x78, 0x2c, 0x8f, 0xf6, 0xe4, 0x0e, 0x46, 0x26, 0x2b, 0x50,
+	0xed, 0x0c, 0x75, 0xef, 0x9d, 0x87, 0x67, 0x33, 0x97, 0x3a, 0xf1, 0x7e, 0x44, 0x7e, 0xc0, 0xad,
+	0x3c, 0xb4, 0xe3, 0x6a, 0xa8, 0x47, 0x2f, 0x6c, 0xa9, 0x37, 0xe7, 0x0f, 0x3f, 0xf1, 0x26, 0xf1,
+	0x8d, 0x3c, 0xf1, 0x50, 0x2f, 0x01, 0x76, 0x35, 0x6a, 0x9e, 0x63, 0x83, 0x39, 0x7d, 0xda, 0x5d,
+	0x21, 0xbe, 0xea, 0x26, 0x1f, 0xbb, 0x01, 0x79, 0x84, 0xdb, 0x9f, 0xa2, 0xaa, 0x5a, 0x8e, 0x2d,
+	0xae, 0x45, 0x9e, 0xd7, 0x5c, 0x62, 0x2e, 0x5a, 0x7a, 0x69, 0x41, 0x62, 0xb5, 0xc5, 0x20, 0x45,
+	0x9d, 0x32, 0x99, 0xc2, 0xf8, 0xb8, 0x32, 0xb9, 0x82, 0xcb, 0x69, 0xc2, 0x97, 0x89, 0xff, 0x84,
+	0x00, 0x78, 0x9f, 0x93, 0x05, 0x5f, 0xae, 0x7c, 0x87, 0x5c, 0xc3, 0xa8, 0x5f, 0xbf, 0xf3, 0xdd,
+	0xc3, 0xe6, 0xbd, 0x7f, 0x31, 0x8b, 0xe0, 0x2e, 0x55, 0xc5, 0xd9, 0x72, 0x2b, 0xe7, 0xfb, 0x68,
+	0x58, 0xfe, 0x76, 0xe9, 0x97, 0x30, 0x16, 0x2d, 0x9b, 0x77, 0xd4, 0xca, 0x52, 0x1f, 0x7a, 0x69,
+	0xed, 0xd9, 0xff, 0x7d, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0xce, 0x48, 0xa2, 0x6a, 0x99, 0x02,
+	0x00, 0x00,
 }
@@ -57,7 +57,7 @@ func (h *Handler) policy() policy.Session {
 	return p
 }
 
-func (h *Handler) resolveIP(ctx context.Context, domain string, localAddr net.Address) net.Address {
+func (h *Handler) lookupIP(ctx context.Context, domain string, localAddr net.Address) []net.IP {
 	var lookupFunc func(string) ([]net.IP, error) = h.dns.LookupIP
 
 	if h.config.DomainStrategy == Config_USE_IP4 || (localAddr != nil && localAddr.Family().IsIPv4()) {
@@ -74,12 +74,41 @@ func (h *Handler) resolveIP(ctx context.Context, domain string, localAddr net.Ad
 	if err != nil {
 		newError("failed to get IP address for domain ", domain).Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
+	return ips
+}
+
+func (h *Handler) resolveIP(ctx context.Context, domain string, localAddr net.Address) net.Address {
+	ips := h.lookupIP(ctx, domain, localAddr)
 	if len(ips) == 0 {
 		return nil
 	}
 	return net.IPAddress(ips[dice.Roll(len(ips))])
 }
 
+// dialHappyEyeballs connects to one of the IP addresses of the domain in the destination, trying IPv6 and IPv4
+// alternately. It returns nil if the domain doesn't resolve.
+func (h *Handler) dialHappyEyeballs(ctx context.Context, dest net.Destination, dialer internet.Dialer) (internet.Connection, error) {
+	ips := h.lookupIP(ctx, dest.Address.Domain(), dialer.Address())
+	if len(ips) == 0 {
+		return nil, nil
+	}
+	addresses := make([]net.Address, len(ips))
+	for i, ip := range ips {
+		addresses[i] = net.IPAddress(ip)
+	}
+	conn, err := internet.DialHappyEyeballs(ctx, addresses, h.config.happyEyeballsDelay(), func(ctx context.Context, addr net.Address) (net.Conn, error) {
+		return dialer.Dial(ctx, net.Destination{
+			Network: dest.Network,
+			Address: addr,
+			Port:    dest.Port,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conn.(internet.Connection), nil
+}
+
 func isValidAddress(addr *net.IPOrDomain) bool {
 	if addr == nil {
 		return false
@@ -113,7 +142,16 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 	var conn internet.Connection
 	err := retry.ExponentialBackoff(5, 100).On(func() error {
 		dialDest := destination
-		if h.config.useIP() && dialDest.Address.Family().IsDomain() {
+		if h.config.DomainStrategy == Config_USE_IP && dialDest.Network == net.Network_TCP && dialDest.Address.Family().IsDomain() {
+			rawConn, err := h.dialHappyEyeballs(ctx, dialDest, dialer)
+			if err != nil {
+				return err
+			}
+			if rawConn != nil {
+				conn = rawConn
+				return nil
+			}
+		} else if h.config.useIP() && dialDest.Address.Family().IsDomain() {
 			ip := h.resolveIP(ctx, dialDest.Address.Domain(), dialer.Address())
 			if ip != nil {
 				dialDest = net.Destination{
@@ -0,0 +1,121 @@
+package freedom_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/features/dns"
+	"v2ray.com/core/features/policy"
+	. "v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/servers/tcp"
+	"v2ray.com/core/transport"
+	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/pipe"
+)
+
+type staticDNS struct {
+	ips []net.IP
+}
+
+func (*staticDNS) Type() interface{} {
+	return dns.ClientType()
+}
+
+func (*staticDNS) Start() error {
+	return nil
+}
+
+func (*staticDNS) Close() error {
+	return nil
+}
+
+func (d *staticDNS) LookupIP(domain string) ([]net.IP, error) {
+	return d.ips, nil
+}
+
+// blackholeIPv6Dialer never connects to IPv6 addresses, and dials IPv4 addresses with the system dialer.
+type blackholeIPv6Dialer struct {
+	sync.Mutex
+	cancelled []net.Destination
+	winnerCtx context.Context
+}
+
+func (d *blackholeIPv6Dialer) Dial(ctx context.Context, dest net.Destination) (internet.Connection, error) {
+	if dest.Address.Family().IsIPv6() {
+		<-ctx.Done()
+		d.Lock()
+		d.cancelled = append(d.cancelled, dest)
+		d.Unlock()
+		return nil, ctx.Err()
+	}
+
+	d.Lock()
+	d.winnerCtx = ctx
+	d.Unlock()
+	return internet.DialSystem(ctx, dest, nil)
+}
+
+func (d *blackholeIPv6Dialer) Address() net.Address {
+	return nil
+}
+
+func TestUseIPHappyEyeballs(t *testing.T) {
+	server := tcp.Server{
+		MsgProcessor: func(b []byte) []byte {
+			return b
+		},
+	}
+	dest, err := server.Start()
+	common.Must(err)
+	defer server.Close()
+
+	handler := new(Handler)
+	common.Must(handler.Init(&Config{
+		DomainStrategy:     Config_USE_IP,
+		HappyEyeballsDelay: 50,
+	}, policy.DefaultManager{}, &staticDNS{
+		ips: []net.IP{net.ParseAddress("2001:db8::1").IP(), net.LocalHostIP.IP()},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = session.ContextWithOutbound(ctx, &session.Outbound{
+		Target: net.TCPDestination(net.DomainAddress("example.com"), dest.Port),
+	})
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	dialer := &blackholeIPv6Dialer{}
+	go handler.Process(ctx, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter}, dialer)
+
+	payload := buf.New()
+	common.Must2(payload.WriteString("test payload"))
+	common.Must(uplinkWriter.WriteMultiBuffer(buf.MultiBuffer{payload}))
+
+	var response buf.MultiBuffer
+	deadline := time.Now().Add(time.Second * 5)
+	for response.Len() < 12 && time.Now().Before(deadline) {
+		mb, err := downlinkReader.ReadMultiBufferTimeout(time.Second)
+		if err != nil && err != buf.ErrReadTimeout {
+			t.Fatal(err)
+		}
+		response = append(response, mb...)
+	}
+	if response.String() != "test payload" {
+		t.Fatal("unexpected response: ", response.String())
+	}
+
+	dialer.Lock()
+	defer dialer.Unlock()
+	if len(dialer.cancelled) != 1 || dialer.cancelled[0].Address.String() != "[2001:db8::1]" {
+		t.Error("IPv6 attempt not cancelled: ", dialer.cancelled)
+	}
+	if dialer.winnerCtx == nil || dialer.winnerCtx.Err() != nil {
+		t.Error("context of the established connection is cancelled")
+	}
+}
@@ -1,5 +1,22 @@
 package http
 
+import (
+	"strings"
+
+	"v2ray.com/core/common/protocol"
+)
+
+func (a *Account) Equals(another protocol.Account) bool {
+	if account, ok := another.(*Account); ok {
+		return a.Username == account.Username
+	}
+	return false
+}
+
+func (a *Account) AsAccount() (protocol.Account, error) {
+	return a, nil
+}
+
 func (sc *ServerConfig) HasAccount(username, password string) bool {
 	if sc.Accounts == nil {
 		return false
@@ -11,3 +28,35 @@ func (sc *ServerConfig) HasAccount(username, password string) bool {
 	}
 	return p == password
 }
+
+// GetRealmValue returns the realm of the Proxy-Authenticate challenge.
+func (sc *ServerConfig) GetRealmValue() string {
+	if len(sc.Realm) == 0 {
+		return "proxy"
+	}
+	return sc.Realm
+}
+
+// Validate checks whether the settings are valid.
+func (sc *ServerConfig) Validate() error {
+	for _, c := range sc.Realm {
+		if (c < 0x20 && c != '\t') || c == 0x7f {
+			return newError("invalid character in realm: ", int(c))
+		}
+	}
+	return nil
+}
+
+// quoteString returns s as a quoted-string of RFC 7230. s must not contain control characters.
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -4,6 +4,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
+	protocol "v2ray.com/core/common/protocol"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -17,22 +18,78 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Account is an HTTP proxy account, authenticated by Proxy-Authorization.
+type Account struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Account) Reset()         { *m = Account{} }
+func (m *Account) String() string { return proto.CompactTextString(m) }
+func (*Account) ProtoMessage()    {}
+func (*Account) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e66c3db3a635d8e4, []int{0}
+}
+
+func (m *Account) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Account.Unmarshal(m, b)
+}
+func (m *Account) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Account.Marshal(b, m, deterministic)
+}
+func (m *Account) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Account.Merge(m, src)
+}
+func (m *Account) XXX_Size() int {
+	return xxx_messageInfo_Account.Size(m)
+}
+func (m *Account) XXX_DiscardUnknown() {
+	xxx_messageInfo_Account.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Account proto.InternalMessageInfo
+
+func (m *Account) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *Account) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
 // Config for HTTP proxy server.
 type ServerConfig struct {
-	Timeout              uint32            `protobuf:"varint,1,opt,name=timeout,proto3" json:"timeout,omitempty"` // Deprecated: Do not use.
-	Accounts             map[string]string `protobuf:"bytes,2,rep,name=accounts,proto3" json:"accounts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	AllowTransparent     bool              `protobuf:"varint,3,opt,name=allow_transparent,json=allowTransparent,proto3" json:"allow_transparent,omitempty"`
-	UserLevel            uint32            `protobuf:"varint,4,opt,name=user_level,json=userLevel,proto3" json:"user_level,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Timeout          uint32            `protobuf:"varint,1,opt,name=timeout,proto3" json:"timeout,omitempty"` // Deprecated: Do not use.
+	Accounts         map[string]string `protobuf:"bytes,2,rep,name=accounts,proto3" json:"accounts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AllowTransparent bool              `protobuf:"varint,3,opt,name=allow_transparent,json=allowTransparent,proto3" json:"allow_transparent,omitempty"`
+	UserLevel        uint32            `protobuf:"varint,4,opt,name=user_level,json=userLevel,proto3" json:"user_level,omitempty"`
+	// Users of the proxy, with Account as their account type. Clients must
+	// authenticate if there is any user here or in accounts. Users added or
+	// removed at runtime don't change that. Users in accounts take their
+	// usernames as email.
+	User []*protocol.User `protobuf:"bytes,5,rep,name=user,proto3" json:"user,omitempty"`
+	// Realm in the Proxy-Authenticate challenge. "proxy" if empty. It must not
+	// contain control characters.
+	Realm                string   `protobuf:"bytes,6,opt,name=realm,proto3" json:"realm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ServerConfig) Reset()         { *m = ServerConfig{} }
 func (m *ServerConfig) String() string { return proto.CompactTextString(m) }
 func (*ServerConfig) ProtoMessage()    {}
 func (*ServerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e66c3db3a635d8e4, []int{0}
+	return fileDescriptor_e66c3db3a635d8e4, []int{1}
 }
 
 func (m *ServerConfig) XXX_Unmarshal(b []byte) error {
@@ -82,6 +139,20 @@ func (m *ServerConfig) GetUserLevel() uint32 {
 	return 0
 }
 
+func (m *ServerConfig) GetUser() []*protocol.User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *ServerConfig) GetRealm() string {
+	if m != nil {
+		return m.Realm
+	}
+	return ""
+}
+
 // ClientConfig for HTTP proxy client.
 type ClientConfig struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -93,7 +164,7 @@ func (m *ClientConfig) Reset()         { *m = ClientConfig{} }
 func (m *ClientConfig) String() string { return proto.CompactTextString(m) }
 func (*ClientConfig) ProtoMessage()    {}
 func (*ClientConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e66c3db3a635d8e4, []int{1}
+	return fileDescriptor_e66c3db3a635d8e4, []int{2}
 }
 
 func (m *ClientConfig) XXX_Unmarshal(b []byte) error {
@@ -115,6 +186,7 @@ func (m *ClientConfig) XXX_DiscardUnknown() {
 var xxx_messageInfo_ClientConfig proto.InternalMessageInfo
 
 func init() {
+	proto.RegisterType((*Account)(nil), "v2ray.core.proxy.http.Account")
 	proto.RegisterType((*ServerConfig)(nil), "v2ray.core.proxy.http.ServerConfig")
 	proto.RegisterMapType((map[string]string)(nil), "v2ray.core.proxy.http.ServerConfig.AccountsEntry")
 	proto.RegisterType((*ClientConfig)(nil), "v2ray.core.proxy.http.ClientConfig")
@@ -125,24 +197,29 @@ func init() {
 }
 
 var fileDescriptor_e66c3db3a635d8e4 = []byte{
-	// 296 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xcf, 0x4a, 0x33, 0x31,
-	0x14, 0xc5, 0x99, 0x69, 0xbf, 0xcf, 0xf6, 0xda, 0x4a, 0x0d, 0x16, 0x46, 0x51, 0x28, 0x5d, 0x48,
-	0x41, 0xc8, 0x60, 0xdd, 0x88, 0x5d, 0xd9, 0x22, 0xb8, 0x50, 0x28, 0x51, 0x5c, 0xb8, 0x29, 0x31,
-	0x5c, 0xb5, 0x98, 0x26, 0x43, 0xe6, 0xce, 0xe8, 0xec, 0x7d, 0x1a, 0x9f, 0x52, 0x92, 0x5a, 0xff,
-	0x40, 0x57, 0x49, 0x7e, 0xe7, 0xe4, 0xe4, 0x9e, 0xc0, 0x61, 0x39, 0x74, 0xb2, 0xe2, 0xca, 0x2e,
-	0x52, 0x65, 0x1d, 0xa6, 0x99, 0xb3, 0x6f, 0x55, 0xfa, 0x4c, 0x94, 0xa5, 0xca, 0x9a, 0xc7, 0xf9,
-	0x13, 0xcf, 0x9c, 0x25, 0xcb, 0xba, 0x2b, 0x9f, 0x43, 0x1e, 0x3c, 0xdc, 0x7b, 0xfa, 0xef, 0x31,
-	0xb4, 0x6e, 0xd0, 0x95, 0xe8, 0x26, 0xc1, 0xcd, 0xf6, 0x61, 0x83, 0xe6, 0x0b, 0xb4, 0x05, 0x25,
-	0x51, 0x2f, 0x1a, 0xb4, 0xc7, 0x71, 0x12, 0x89, 0x15, 0x62, 0xd7, 0xd0, 0x90, 0x4a, 0xd9, 0xc2,
-	0x50, 0x9e, 0xc4, 0xbd, 0xda, 0x60, 0x73, 0x78, 0xcc, 0xd7, 0x06, 0xf3, 0xdf, 0xa1, 0xfc, 0xfc,
-	0xeb, 0xce, 0x85, 0x21, 0x57, 0x89, 0xef, 0x08, 0x76, 0x04, 0xdb, 0x52, 0x6b, 0xfb, 0x3a, 0x23,
-	0x27, 0x4d, 0x9e, 0x49, 0x87, 0x86, 0x92, 0x5a, 0x2f, 0x1a, 0x34, 0x44, 0x27, 0x08, 0xb7, 0x3f,
-	0x9c, 0x1d, 0x00, 0x14, 0x39, 0xba, 0x99, 0xc6, 0x12, 0x75, 0x52, 0xf7, 0xc3, 0x89, 0xa6, 0x27,
-	0x57, 0x1e, 0xec, 0x8d, 0xa0, 0xfd, 0xe7, 0x19, 0xd6, 0x81, 0xda, 0x0b, 0x56, 0xa1, 0x45, 0x53,
-	0xf8, 0x2d, 0xdb, 0x81, 0x7f, 0xa5, 0xd4, 0x05, 0x26, 0x71, 0x60, 0xcb, 0xc3, 0x59, 0x7c, 0x1a,
-	0xf5, 0xb7, 0xa0, 0x35, 0xd1, 0x73, 0x34, 0xb4, 0x1c, 0x78, 0x3c, 0x82, 0x5d, 0x65, 0x17, 0xeb,
-	0xab, 0x4d, 0xa3, 0xfb, 0xba, 0x5f, 0x3f, 0xe2, 0xee, 0xdd, 0x50, 0xc8, 0x8a, 0x4f, 0xbc, 0x3e,
-	0x0d, 0xfa, 0x25, 0x51, 0xf6, 0xf0, 0x3f, 0xfc, 0xf8, 0xc9, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x69, 0x94, 0x9f, 0xa7, 0x9b, 0x01, 0x00, 0x00,
+	// 376 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x50, 0x5d, 0x6b, 0xdb, 0x30,
+	0x14, 0xc5, 0xce, 0xb7, 0x96, 0x8c, 0x4c, 0x2c, 0xa0, 0x85, 0x0d, 0x4c, 0x1e, 0x46, 0xc6, 0x40,
+	0x66, 0xd9, 0x1e, 0x46, 0xf3, 0x94, 0x84, 0x42, 0x1f, 0x5a, 0x08, 0xee, 0xc7, 0x43, 0x5f, 0x82,
+	0xaa, 0xde, 0xb6, 0xa1, 0xb2, 0x64, 0x64, 0xd9, 0xa9, 0xff, 0x52, 0x7f, 0x4d, 0x7f, 0x52, 0x91,
+	0x1c, 0xa7, 0x69, 0xc9, 0x93, 0x7d, 0xee, 0x39, 0xf7, 0xe8, 0xdc, 0x83, 0x7e, 0xe6, 0x13, 0xcd,
+	0x0a, 0xca, 0x55, 0x1c, 0x72, 0xa5, 0x21, 0x4c, 0xb4, 0x7a, 0x2a, 0xc2, 0x07, 0x63, 0x92, 0x90,
+	0x2b, 0x79, 0xb7, 0xbe, 0xa7, 0x89, 0x56, 0x46, 0xe1, 0x41, 0xa5, 0xd3, 0x40, 0x9d, 0x86, 0x5a,
+	0xcd, 0xf0, 0xd7, 0x87, 0x75, 0xae, 0xe2, 0x58, 0xc9, 0xd0, 0xed, 0x70, 0x25, 0xc2, 0x2c, 0x05,
+	0x5d, 0x3a, 0x8c, 0x66, 0xa8, 0x35, 0xe3, 0x5c, 0x65, 0xd2, 0xe0, 0x21, 0x6a, 0x5b, 0x42, 0xb2,
+	0x18, 0x88, 0x17, 0x78, 0xe3, 0x4e, 0xb4, 0xc3, 0x96, 0x4b, 0x58, 0x9a, 0x6e, 0x94, 0xbe, 0x25,
+	0x7e, 0xc9, 0x55, 0x78, 0xf4, 0xe2, 0xa3, 0xee, 0x39, 0xe8, 0x1c, 0xf4, 0xc2, 0x65, 0xc3, 0xdf,
+	0x51, 0xcb, 0xac, 0x63, 0x50, 0x99, 0x71, 0x3e, 0xbd, 0xb9, 0x4f, 0xbc, 0xa8, 0x1a, 0xe1, 0x33,
+	0xd4, 0x66, 0xe5, 0x8b, 0x29, 0xf1, 0x83, 0xda, 0xf8, 0xd3, 0xe4, 0x0f, 0x3d, 0x78, 0x06, 0xdd,
+	0x37, 0xa5, 0xdb, 0x94, 0xe9, 0xb1, 0x34, 0xba, 0x88, 0x76, 0x16, 0xf8, 0x37, 0xfa, 0xc2, 0x84,
+	0x50, 0x9b, 0x95, 0xd1, 0x4c, 0xa6, 0x09, 0xd3, 0x20, 0x0d, 0xa9, 0x05, 0xde, 0xb8, 0x1d, 0xf5,
+	0x1d, 0x71, 0xf1, 0x36, 0xc7, 0x3f, 0x10, 0xb2, 0x27, 0xad, 0x04, 0xe4, 0x20, 0x48, 0xdd, 0x86,
+	0x8b, 0x3a, 0x76, 0x72, 0x6a, 0x07, 0xf8, 0x1f, 0xaa, 0x5b, 0x40, 0x1a, 0x2e, 0x56, 0xb0, 0x1f,
+	0xab, 0xac, 0x90, 0x56, 0x15, 0xd2, 0xcb, 0x14, 0x74, 0xe4, 0xd4, 0xf8, 0x2b, 0x6a, 0x68, 0x60,
+	0x22, 0x26, 0x4d, 0x57, 0x4c, 0x09, 0x86, 0x53, 0xd4, 0x7b, 0x17, 0x19, 0xf7, 0x51, 0xed, 0x11,
+	0x8a, 0x6d, 0xb3, 0xf6, 0xd7, 0x2e, 0xe6, 0x4c, 0x64, 0xb0, 0x6d, 0xb4, 0x04, 0x47, 0xfe, 0x7f,
+	0x6f, 0xf4, 0x19, 0x75, 0x17, 0x62, 0x0d, 0xd2, 0x94, 0xc7, 0xcf, 0xa7, 0xe8, 0x1b, 0x57, 0xf1,
+	0xe1, 0x9a, 0x96, 0xde, 0x75, 0xdd, 0x7e, 0x9f, 0xfd, 0xc1, 0xd5, 0x24, 0x62, 0x05, 0x5d, 0x58,
+	0x7e, 0xe9, 0xf8, 0x13, 0x63, 0x92, 0x9b, 0xa6, 0x0b, 0xfd, 0xf7, 0x35, 0x00, 0x00, 0xff, 0xff,
+	0xc3, 0xdc, 0x08, 0x3d, 0x55, 0x02, 0x00, 0x00,
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"v2ray.com/core"
@@ -16,6 +17,7 @@ import (
 	"v2ray.com/core/common/errors"
 	"v2ray.com/core/common/log"
 	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
 	http_proto "v2ray.com/core/common/protocol/http"
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/common/signal"
@@ -26,27 +28,124 @@ import (
 	"v2ray.com/core/transport/pipe"
 )
 
+type userByName struct {
+	sync.RWMutex
+	cache map[string]*protocol.MemoryUser
+}
+
+func (v *userByName) Add(u *protocol.MemoryUser) error {
+	account, ok := u.Account.(*Account)
+	if !ok {
+		return newError("not an HTTP account")
+	}
+
+	v.Lock()
+	defer v.Unlock()
+
+	if _, found := v.cache[account.Username]; found {
+		return newError("user ", account.Username, " already exists")
+	}
+	v.cache[account.Username] = u
+	return nil
+}
+
+func (v *userByName) Remove(email string) error {
+	email = strings.ToLower(email)
+
+	v.Lock()
+	defer v.Unlock()
+
+	for name, u := range v.cache {
+		if strings.ToLower(u.Email) == email {
+			delete(v.cache, name)
+			return nil
+		}
+	}
+	return newError("user ", email, " not found")
+}
+
+func (v *userByName) Get(username, password string) (*protocol.MemoryUser, bool) {
+	v.RLock()
+	defer v.RUnlock()
+
+	u, found := v.cache[username]
+	if !found || u.Account.(*Account).Password != password {
+		return nil, false
+	}
+	return u, true
+}
+
 // Server is an HTTP proxy server.
 type Server struct {
 	config        *ServerConfig
 	policyManager policy.Manager
+	users         *userByName
+	// authRequired is decided by the config only. Adding or removing users at runtime doesn't change it.
+	authRequired bool
 }
 
 // NewServer creates a new HTTP inbound handler.
 func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
+	if err := config.Validate(); err != nil {
+		return nil, newError("invalid HTTP server settings").Base(err)
+	}
 	v := core.MustFromContext(ctx)
 	s := &Server{
 		config:        config,
 		policyManager: v.GetFeature(policy.ManagerType()).(policy.Manager),
+		users: &userByName{
+			cache: make(map[string]*protocol.MemoryUser),
+		},
+		authRequired: len(config.Accounts) > 0 || len(config.User) > 0,
+	}
+
+	for username, password := range config.Accounts {
+		if err := s.users.Add(&protocol.MemoryUser{
+			Account: &Account{
+				Username: username,
+				Password: password,
+			},
+			Level: config.UserLevel,
+			Email: username,
+		}); err != nil {
+			return nil, newError("failed to initiate account").Base(err)
+		}
+	}
+
+	for _, user := range config.User {
+		mUser, err := user.ToMemoryUser()
+		if err != nil {
+			return nil, newError("failed to get HTTP user").Base(err)
+		}
+		if err := s.users.Add(mUser); err != nil {
+			return nil, newError("failed to initiate user").Base(err)
+		}
 	}
 
 	return s, nil
 }
 
-func (s *Server) policy() policy.Session {
+// AddUser implements proxy.UserManager.
+func (s *Server) AddUser(ctx context.Context, u *protocol.MemoryUser) error {
+	return s.users.Add(u)
+}
+
+// RemoveUser implements proxy.UserManager.
+func (s *Server) RemoveUser(ctx context.Context, email string) error {
+	if len(email) == 0 {
+		return newError("Email must not be empty.")
+	}
+	return s.users.Remove(email)
+}
+
+func (s *Server) policy(ctx context.Context) policy.Session {
 	config := s.config
-	p := s.policyManager.ForLevel(config.UserLevel)
-	if config.Timeout > 0 && config.UserLevel == 0 {
+	level := config.UserLevel
+	if inbound := session.InboundFromContext(ctx); inbound != nil && inbound.User != nil {
+		level = inbound.User.Level
+	}
+	p := s.policyManager.ForLevel(level)
+	if config.Timeout > 0 && level == 0 {
 		p.Timeouts.ConnectionIdle = time.Duration(config.Timeout) * time.Second
 	}
 	return p
@@ -106,7 +205,7 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn internet
 	reader := bufio.NewReaderSize(readerOnly{conn}, buf.Size)
 
 Start:
-	if err := conn.SetReadDeadline(time.Now().Add(s.policy().Timeouts.Handshake)); err != nil {
+	if err := conn.SetReadDeadline(time.Now().Add(s.policy(ctx).Timeouts.Handshake)); err != nil {
 		newError("failed to set read deadline").Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
 
@@ -119,10 +218,14 @@ Start:
 		return trace
 	}
 
-	if len(s.config.Accounts) > 0 {
-		user, pass, ok := parseBasicAuth(request.Header.Get("Proxy-Authorization"))
-		if !ok || !s.config.HasAccount(user, pass) {
-			return common.Error2(conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\nProxy-Authenticate: Basic realm=\"proxy\"\r\n\r\n")))
+	if s.authRequired {
+		username, password, ok := parseBasicAuth(request.Header.Get("Proxy-Authorization"))
+		user, found := s.users.Get(username, password)
+		if !ok || !found {
+			return common.Error2(conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\nProxy-Authenticate: Basic realm=" + quoteString(s.config.GetRealmValue()) + "\r\n\r\n")))
+		}
+		if inbound := session.InboundFromContext(ctx); inbound != nil {
+			inbound.User = user
 		}
 	}
 
@@ -172,7 +275,7 @@ func (s *Server) handleConnect(ctx context.Context, request *http.Request, reade
 		return newError("failed to write back OK response").Base(err)
 	}
 
-	plcy := s.policy()
+	plcy := s.policy(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 	timer := signal.CancelAfterInactivity(ctx, cancel, plcy.Timeouts.ConnectionIdle)
 
@@ -1,9 +1,28 @@
 package mtproto
 
 import (
+	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol"
 )
 
+var (
+	defaultDataCenters = []net.Address{
+		net.ParseAddress("149.154.175.50"),
+		net.ParseAddress("149.154.167.51"),
+		net.ParseAddress("149.154.175.100"),
+		net.ParseAddress("149.154.167.91"),
+		net.ParseAddress("149.154.171.5"),
+	}
+
+	defaultDataCentersV6 = []net.Address{
+		net.ParseAddress("2001:b28:f23d:f001::a"),
+		net.ParseAddress("2001:67c:4e8:f002::a"),
+		net.ParseAddress("2001:b28:f23d:f003::a"),
+		net.ParseAddress("2001:67c:4e8:f004::a"),
+		net.ParseAddress("2001:b28:f23f:f005::a"),
+	}
+)
+
 func (a *Account) Equals(another protocol.Account) bool {
 	aa, ok := another.(*Account)
 	if !ok {
@@ -22,3 +41,69 @@ func (a *Account) Equals(another protocol.Account) bool {
 
 	return true
 }
+
+// SecretMode is the mode of a MTProto secret, determined by its prefix.
+type SecretMode byte
+
+const (
+	// SecretModeSimple accepts all obfuscated connection types.
+	SecretModeSimple SecretMode = iota
+	// SecretModeSecure accepts padded intermediate connections only. Secret is prefixed with 0xdd.
+	SecretModeSecure
+	// SecretModeFakeTLS accepts connections wrapped in a fake TLS handshake. Secret is prefixed with 0xee.
+	SecretModeFakeTLS
+)
+
+const secretKeySize = 16
+
+// Mode returns the mode of the secret in this account.
+func (a *Account) Mode() SecretMode {
+	switch {
+	case len(a.Secret) == secretKeySize+1 && a.Secret[0] == 0xdd:
+		return SecretModeSecure
+	case len(a.Secret) > secretKeySize+1 && a.Secret[0] == 0xee:
+		return SecretModeFakeTLS
+	default:
+		return SecretModeSimple
+	}
+}
+
+// Key returns the key part of the secret, without mode prefix and domain.
+func (a *Account) Key() []byte {
+	if a.Mode() == SecretModeSimple {
+		return a.Secret
+	}
+	return a.Secret[1 : secretKeySize+1]
+}
+
+// Domain returns the domain that fake-TLS clients mimic, or empty if the secret is not in fake-TLS mode.
+func (a *Account) Domain() string {
+	if a.Mode() != SecretModeFakeTLS {
+		return ""
+	}
+	return string(a.Secret[secretKeySize+1:])
+}
+
+func toAddressList(list []*net.IPOrDomain) []net.Address {
+	addrs := make([]net.Address, 0, len(list))
+	for _, addr := range list {
+		addrs = append(addrs, addr.AsAddress())
+	}
+	return addrs
+}
+
+// DataCenters returns the addresses of Telegram datacenters, indexed by datacenter ID starting from 0.
+func (c *ServerConfig) DataCenters() []net.Address {
+	if c.PreferIpv6 {
+		if len(c.DatacenterV6) > 0 {
+			return toAddressList(c.DatacenterV6)
+		}
+		if len(c.Datacenter) == 0 {
+			return defaultDataCentersV6
+		}
+	}
+	if len(c.Datacenter) > 0 {
+		return toAddressList(c.Datacenter)
+	}
+	return defaultDataCenters
+}
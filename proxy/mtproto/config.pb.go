@@ -4,6 +4,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
+	net "v2ray.com/core/common/net"
 	protocol "v2ray.com/core/common/protocol"
 )
 
@@ -19,6 +20,10 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Account struct {
+	// Secret is the 16-byte key shared with clients. A 17-byte secret prefixed
+	// with 0xdd enables secure (padded) mode only. A secret prefixed with 0xee,
+	// followed by the 16-byte key and a domain name, enables fake-TLS mode,
+	// in which clients must send the domain name in the ClientHello SNI.
 	Secret               []byte   `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -60,10 +65,18 @@ func (m *Account) GetSecret() []byte {
 type ServerConfig struct {
 	// User is a list of users that allowed to connect to this inbound.
 	// Although this is a repeated field, only the first user is effective for now.
-	User                 []*protocol.User `protobuf:"bytes,1,rep,name=user,proto3" json:"user,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	User []*protocol.User `protobuf:"bytes,1,rep,name=user,proto3" json:"user,omitempty"`
+	// Datacenter is the list of IPv4 addresses of Telegram datacenters, ordered
+	// by datacenter ID starting from 1. Production datacenters are used if empty.
+	Datacenter []*net.IPOrDomain `protobuf:"bytes,2,rep,name=datacenter,proto3" json:"datacenter,omitempty"`
+	// Datacenter_v6 is the list of IPv6 addresses of Telegram datacenters, in
+	// the same order as datacenter.
+	DatacenterV6 []*net.IPOrDomain `protobuf:"bytes,3,rep,name=datacenter_v6,json=datacenterV6,proto3" json:"datacenter_v6,omitempty"`
+	// Whether to connect to datacenters through IPv6 addresses.
+	PreferIpv6           bool     `protobuf:"varint,4,opt,name=prefer_ipv6,json=preferIpv6,proto3" json:"prefer_ipv6,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ServerConfig) Reset()         { *m = ServerConfig{} }
@@ -98,6 +111,27 @@ func (m *ServerConfig) GetUser() []*protocol.User {
 	return nil
 }
 
+func (m *ServerConfig) GetDatacenter() []*net.IPOrDomain {
+	if m != nil {
+		return m.Datacenter
+	}
+	return nil
+}
+
+func (m *ServerConfig) GetDatacenterV6() []*net.IPOrDomain {
+	if m != nil {
+		return m.DatacenterV6
+	}
+	return nil
+}
+
+func (m *ServerConfig) GetPreferIpv6() bool {
+	if m != nil {
+		return m.PreferIpv6
+	}
+	return false
+}
+
 type ClientConfig struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -140,19 +174,25 @@ func init() {
 }
 
 var fileDescriptor_64514e21c693811b = []byte{
-	// 221 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x8f, 0xc1, 0x4a, 0xc4, 0x30,
-	0x10, 0x86, 0x89, 0xca, 0x2e, 0xc4, 0xe2, 0xa1, 0x07, 0x09, 0xe2, 0xa1, 0xf6, 0xb4, 0x5e, 0x26,
-	0x50, 0x7d, 0x01, 0xed, 0x5e, 0x85, 0xa5, 0xa2, 0x07, 0x6f, 0xeb, 0x30, 0xca, 0xc2, 0x26, 0x53,
-	0xa6, 0x69, 0xb1, 0xaf, 0xe4, 0x53, 0x4a, 0x93, 0x16, 0x44, 0xf0, 0x94, 0xfc, 0xfc, 0x1f, 0xdf,
-	0x9f, 0xe8, 0xdb, 0xa1, 0x92, 0xfd, 0x08, 0xc8, 0xce, 0x22, 0x0b, 0xd9, 0x56, 0xf8, 0x6b, 0xb4,
-	0x2e, 0xb4, 0xc2, 0x81, 0x2d, 0xb2, 0xff, 0x38, 0x7c, 0x42, 0x0c, 0xb9, 0x59, 0x50, 0x21, 0x88,
-	0x18, 0xcc, 0xd8, 0xd5, 0x5f, 0x09, 0xb2, 0x73, 0xec, 0x6d, 0x2c, 0x91, 0x8f, 0xb6, 0xef, 0x48,
-	0x92, 0xa4, 0xbc, 0xd1, 0xeb, 0x07, 0x44, 0xee, 0x7d, 0xc8, 0x2f, 0xf5, 0xaa, 0x23, 0x14, 0x0a,
-	0x46, 0x15, 0x6a, 0x93, 0x35, 0x73, 0x2a, 0xb7, 0x3a, 0x7b, 0x26, 0x19, 0x48, 0xea, 0xb8, 0x9e,
-	0xdf, 0xeb, 0xb3, 0x49, 0x60, 0x54, 0x71, 0xba, 0x39, 0xaf, 0x0a, 0xf8, 0xf5, 0x8c, 0x34, 0x04,
-	0xcb, 0x10, 0xbc, 0x74, 0x24, 0x4d, 0xa4, 0xcb, 0x0b, 0x9d, 0xd5, 0xc7, 0x03, 0xf9, 0x90, 0x2c,
-	0x8f, 0x5b, 0x7d, 0x8d, 0xec, 0xe0, 0xbf, 0x3f, 0xec, 0xd4, 0xdb, 0x7a, 0xbe, 0x7e, 0x9f, 0x98,
-	0xd7, 0xaa, 0xd9, 0x8f, 0x50, 0x4f, 0xd4, 0x2e, 0x52, 0x4f, 0xa9, 0x7a, 0x5f, 0xc5, 0xe3, 0xee,
-	0x27, 0x00, 0x00, 0xff, 0xff, 0x54, 0x23, 0xa0, 0xae, 0x37, 0x01, 0x00, 0x00,
+	// 314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xc1, 0x6a, 0xfa, 0x40,
+	0x10, 0xc6, 0x89, 0x8a, 0xfe, 0x19, 0xf3, 0xef, 0x21, 0x87, 0xb2, 0x48, 0xa1, 0xd1, 0x4b, 0xf5,
+	0xb2, 0x0b, 0xb6, 0xe4, 0x6e, 0x95, 0x82, 0x87, 0x52, 0x49, 0xa9, 0x87, 0x5e, 0x24, 0x5d, 0xc7,
+	0x12, 0x30, 0x3b, 0x61, 0xb2, 0x86, 0xfa, 0x4a, 0x7d, 0xba, 0x3e, 0x42, 0x71, 0xa3, 0x28, 0xc5,
+	0x42, 0x4f, 0xbb, 0xdf, 0xce, 0xef, 0xfb, 0x66, 0x96, 0x81, 0x41, 0x39, 0xe4, 0x64, 0x2b, 0x35,
+	0x65, 0x4a, 0x13, 0xa3, 0xca, 0x99, 0x3e, 0xb6, 0x2a, 0xb3, 0x39, 0x93, 0x25, 0xa5, 0xc9, 0xac,
+	0xd2, 0x77, 0xe9, 0x44, 0x20, 0x0e, 0x28, 0xa3, 0x74, 0x98, 0xdc, 0x63, 0x9d, 0x9b, 0x1f, 0x21,
+	0x9a, 0xb2, 0x8c, 0x8c, 0x32, 0x68, 0x55, 0xb2, 0x5c, 0x32, 0x16, 0x45, 0x15, 0xd1, 0x19, 0x9c,
+	0x07, 0x5d, 0x51, 0xd3, 0x5a, 0x6d, 0x0a, 0xe4, 0x0a, 0xed, 0x75, 0xa1, 0x35, 0xd2, 0x9a, 0x36,
+	0xc6, 0x06, 0x97, 0xd0, 0x2c, 0x50, 0x33, 0x5a, 0xe1, 0x85, 0x5e, 0xdf, 0x8f, 0xf7, 0xaa, 0xf7,
+	0xe5, 0x81, 0xff, 0x8c, 0x5c, 0x22, 0x8f, 0xdd, 0x9c, 0xc1, 0x1d, 0x34, 0x76, 0x09, 0xc2, 0x0b,
+	0xeb, 0xfd, 0xf6, 0x30, 0x94, 0x27, 0x03, 0x57, 0x9d, 0xe4, 0xa1, 0x93, 0x7c, 0x29, 0x90, 0x63,
+	0x47, 0x07, 0x23, 0x80, 0x65, 0x62, 0x13, 0x8d, 0xc6, 0x22, 0x8b, 0x9a, 0xf3, 0x76, 0xcf, 0x78,
+	0x0d, 0x5a, 0x39, 0x9d, 0x3d, 0xf1, 0x84, 0xb2, 0x24, 0x35, 0xf1, 0x89, 0x29, 0x78, 0x80, 0xff,
+	0x47, 0xb5, 0x28, 0x23, 0x51, 0xff, 0x6b, 0x8a, 0x7f, 0xf4, 0xcd, 0xa3, 0xe0, 0x1a, 0xda, 0x39,
+	0xe3, 0x0a, 0x79, 0x91, 0xe6, 0x65, 0x24, 0x1a, 0xa1, 0xd7, 0xff, 0x17, 0x43, 0xf5, 0x34, 0xcd,
+	0xcb, 0xa8, 0x77, 0x01, 0xfe, 0x78, 0x9d, 0xa2, 0xb1, 0xd5, 0x8f, 0xef, 0x27, 0x70, 0xa5, 0x29,
+	0x93, 0xbf, 0x6d, 0x66, 0xe6, 0xbd, 0xb6, 0xf6, 0xd7, 0xcf, 0x9a, 0x98, 0x0f, 0xe3, 0x64, 0x2b,
+	0xc7, 0x3b, 0x6a, 0xe6, 0xa8, 0xc7, 0xaa, 0xf4, 0xd6, 0x74, 0xc7, 0xed, 0x77, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0x0d, 0xcf, 0xca, 0x80, 0x0d, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,279 @@
+package mtproto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/antireplay"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/dice"
+)
+
+const (
+	tlsRecordChangeCipherSpec = 0x14
+	tlsRecordHandshake        = 0x16
+	tlsRecordApplicationData  = 0x17
+
+	tlsRecordHeaderSize = 5
+	tlsMaxRecordSize    = 16384
+
+	tlsRandomOffset = 11
+	tlsRandomSize   = 32
+
+	tlsExtensionServerName = 0x0000
+
+	fakeTLSTimeSkew = 120 * time.Second
+)
+
+var (
+	fakeTLSReplayFilter = antireplay.NewReplayFilter(2*fakeTLSTimeSkew, 100000)
+)
+
+// FakeTLSClientHello is a ClientHello received in fake-TLS mode.
+type FakeTLSClientHello struct {
+	Random    [tlsRandomSize]byte
+	SessionID []byte
+	// ServerName is the host name in the server_name extension, or empty if there is none.
+	ServerName string
+}
+
+func fakeTLSDigest(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+	for _, d := range data {
+		common.Must2(h.Write(d))
+	}
+	return h.Sum(nil)
+}
+
+// ReadFakeTLSClientHello reads a TLS ClientHello record from the reader, and verifies that its random field is signed by the given key,
+// and that it is sent to the given domain.
+func ReadFakeTLSClientHello(reader io.Reader, key []byte, domain string) (*FakeTLSClientHello, error) {
+	var header [tlsRecordHeaderSize]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return nil, newError("failed to read TLS record header").Base(err)
+	}
+	if header[0] != tlsRecordHandshake || header[1] != 0x03 {
+		return nil, newError("not a TLS handshake record")
+	}
+	size := int(binary.BigEndian.Uint16(header[3:]))
+	if size > tlsMaxRecordSize || size < tlsRandomOffset+tlsRandomSize+1-tlsRecordHeaderSize {
+		return nil, newError("invalid TLS record size: ", size)
+	}
+
+	record := make([]byte, tlsRecordHeaderSize+size)
+	copy(record, header[:])
+	if _, err := io.ReadFull(reader, record[tlsRecordHeaderSize:]); err != nil {
+		return nil, newError("failed to read ClientHello").Base(err)
+	}
+	if record[tlsRecordHeaderSize] != 0x01 {
+		return nil, newError("not a ClientHello")
+	}
+
+	hello := new(FakeTLSClientHello)
+	random := record[tlsRandomOffset : tlsRandomOffset+tlsRandomSize]
+	copy(hello.Random[:], random)
+	for i := range random {
+		random[i] = 0
+	}
+
+	digest := fakeTLSDigest(key, record)
+	for i := range digest {
+		digest[i] ^= hello.Random[i]
+	}
+	for _, b := range digest[:tlsRandomSize-4] {
+		if b != 0 {
+			return nil, newError("invalid ClientHello digest")
+		}
+	}
+
+	timestamp := time.Unix(int64(binary.LittleEndian.Uint32(digest[tlsRandomSize-4:])), 0)
+	if d := time.Since(timestamp); d > fakeTLSTimeSkew || d < -fakeTLSTimeSkew {
+		return nil, newError("invalid ClientHello timestamp: ", timestamp)
+	}
+	if !fakeTLSReplayFilter.Check(hello.Random[:]) {
+		return nil, newError("replayed ClientHello")
+	}
+
+	sessionIDOffset := tlsRandomOffset + tlsRandomSize
+	sessionIDLen := int(record[sessionIDOffset])
+	if sessionIDOffset+1+sessionIDLen > len(record) {
+		return nil, newError("invalid session id length: ", sessionIDLen)
+	}
+	hello.SessionID = append([]byte(nil), record[sessionIDOffset+1:sessionIDOffset+1+sessionIDLen]...)
+
+	serverName, err := readServerName(record[sessionIDOffset+1+sessionIDLen:])
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(serverName, domain) {
+		return nil, newError("unexpected server name: ", serverName)
+	}
+	hello.ServerName = serverName
+
+	return hello, nil
+}
+
+// readServerName returns the host name in the server_name extension of a ClientHello, given the part after the
+// session ID.
+func readServerName(b []byte) (string, error) {
+	// Cipher suites and compression methods.
+	if len(b) < 2 {
+		return "", nil
+	}
+	n := 2 + int(binary.BigEndian.Uint16(b))
+	if n >= len(b) {
+		return "", nil
+	}
+	n += 1 + int(b[n])
+	if n+2 > len(b) {
+		return "", nil
+	}
+
+	size := int(binary.BigEndian.Uint16(b[n:]))
+	extensions := b[n+2:]
+	if size > len(extensions) {
+		return "", newError("invalid extensions length: ", size)
+	}
+	extensions = extensions[:size]
+	for len(extensions) >= 4 {
+		extType := binary.BigEndian.Uint16(extensions)
+		size := int(binary.BigEndian.Uint16(extensions[2:]))
+		if 4+size > len(extensions) {
+			return "", newError("invalid extension length: ", size)
+		}
+		data := extensions[4 : 4+size]
+		extensions = extensions[4+size:]
+		if extType != tlsExtensionServerName {
+			continue
+		}
+		// server_name_list with a host_name entry.
+		if len(data) < 5 || data[2] != 0 {
+			return "", newError("invalid server_name extension")
+		}
+		nameLen := int(binary.BigEndian.Uint16(data[3:]))
+		if 5+nameLen > len(data) {
+			return "", newError("invalid server name length: ", nameLen)
+		}
+		return string(data[5 : 5+nameLen]), nil
+	}
+	return "", nil
+}
+
+// WriteFakeTLSServerHello writes the TLS 1.3 ServerHello, ChangeCipherSpec and a random ApplicationData record in response to the ClientHello.
+func WriteFakeTLSServerHello(writer io.Writer, key []byte, hello *FakeTLSClientHello) error {
+	response := make([]byte, 0, 1024)
+
+	handshakeSize := 2 + tlsRandomSize + 1 + len(hello.SessionID) + 2 + 1 + 2 + 46
+	response = append(response, tlsRecordHandshake, 0x03, 0x03, byte((handshakeSize+4)>>8), byte(handshakeSize+4))
+	response = append(response, 0x02, 0x00, byte(handshakeSize>>8), byte(handshakeSize))
+	response = append(response, 0x03, 0x03)
+	response = append(response, make([]byte, tlsRandomSize)...)
+	response = append(response, byte(len(hello.SessionID)))
+	response = append(response, hello.SessionID...)
+	// Cipher suite TLS_AES_128_GCM_SHA256, no compression.
+	response = append(response, 0x13, 0x01, 0x00)
+	// Extensions: key_share with a random x25519 key, and supported_versions of TLS 1.3.
+	response = append(response, 0x00, 46)
+	response = append(response, 0x00, 0x33, 0x00, 0x24, 0x00, 0x1d, 0x00, 0x20)
+	keyShare := make([]byte, 32)
+	common.Must2(rand.Read(keyShare))
+	response = append(response, keyShare...)
+	response = append(response, 0x00, 0x2b, 0x00, 0x02, 0x03, 0x04)
+
+	response = append(response, tlsRecordChangeCipherSpec, 0x03, 0x03, 0x00, 0x01, 0x01)
+
+	payloadSize := 1024 + dice.Roll(3072)
+	response = append(response, tlsRecordApplicationData, 0x03, 0x03, byte(payloadSize>>8), byte(payloadSize))
+	payload := make([]byte, payloadSize)
+	common.Must2(rand.Read(payload))
+	response = append(response, payload...)
+
+	digest := fakeTLSDigest(key, hello.Random[:], response)
+	copy(response[tlsRandomOffset:], digest)
+
+	return buf.WriteAllBytes(writer, response)
+}
+
+// FakeTLSReader reads payload of ApplicationData records from the underlying reader.
+type FakeTLSReader struct {
+	reader io.Reader
+	left   int
+}
+
+// NewFakeTLSReader creates a new FakeTLSReader.
+func NewFakeTLSReader(reader io.Reader) *FakeTLSReader {
+	return &FakeTLSReader{
+		reader: reader,
+	}
+}
+
+// Read implements io.Reader.
+func (r *FakeTLSReader) Read(b []byte) (int, error) {
+	for r.left == 0 {
+		var header [tlsRecordHeaderSize]byte
+		if _, err := io.ReadFull(r.reader, header[:]); err != nil {
+			return 0, err
+		}
+		size := int(binary.BigEndian.Uint16(header[3:]))
+		switch header[0] {
+		case tlsRecordChangeCipherSpec:
+			if _, err := io.CopyN(ioutil.Discard, r.reader, int64(size)); err != nil {
+				return 0, err
+			}
+		case tlsRecordApplicationData:
+			r.left = size
+		default:
+			return 0, newError("unexpected TLS record type: ", header[0])
+		}
+	}
+
+	if len(b) > r.left {
+		b = b[:r.left]
+	}
+	n, err := r.reader.Read(b)
+	r.left -= n
+	return n, err
+}
+
+// FakeTLSWriter wraps all written data into ApplicationData records.
+type FakeTLSWriter struct {
+	writer io.Writer
+}
+
+// NewFakeTLSWriter creates a new FakeTLSWriter.
+func NewFakeTLSWriter(writer io.Writer) *FakeTLSWriter {
+	return &FakeTLSWriter{
+		writer: writer,
+	}
+}
+
+// Write implements io.Writer.
+func (w *FakeTLSWriter) Write(b []byte) (int, error) {
+	const maxPayload = buf.Size - tlsRecordHeaderSize
+
+	record := buf.New()
+	defer record.Release()
+
+	total := len(b)
+	for len(b) > 0 {
+		n := len(b)
+		if n > maxPayload {
+			n = maxPayload
+		}
+		record.Clear()
+		common.Must2(record.Write([]byte{tlsRecordApplicationData, 0x03, 0x03, byte(n >> 8), byte(n)}))
+		common.Must2(record.Write(b[:n]))
+		if err := buf.WriteAllBytes(w.writer, record.Bytes()); err != nil {
+			return total - len(b), err
+		}
+		b = b[n:]
+	}
+	return total, nil
+}
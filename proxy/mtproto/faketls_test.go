@@ -0,0 +1,169 @@
+package mtproto_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/compare"
+	. "v2ray.com/core/proxy/mtproto"
+)
+
+func createClientHello(key []byte, timestamp time.Time) []byte {
+	return createClientHelloWithServerName(key, timestamp, "www.example.com")
+}
+
+func createClientHelloWithServerName(key []byte, timestamp time.Time, serverName string) []byte {
+	sessionID := make([]byte, 32)
+	common.Must2(rand.Read(sessionID))
+
+	body := []byte{0x01, 0x00, 0x00, 0x00, 0x03, 0x03}
+	body = append(body, make([]byte, 32)...)
+	body = append(body, byte(len(sessionID)))
+	body = append(body, sessionID...)
+	body = append(body, 0x00, 0x02, 0x13, 0x01, 0x01, 0x00)
+	if len(serverName) > 0 {
+		n := len(serverName)
+		// Extensions with only server_name.
+		body = append(body, 0x00, byte(n+9), 0x00, 0x00, 0x00, byte(n+5), 0x00, byte(n+3), 0x00, 0x00, byte(n))
+		body = append(body, serverName...)
+	}
+	body[3] = byte(len(body) - 4)
+
+	record := []byte{0x16, 0x03, 0x01, byte(len(body) >> 8), byte(len(body))}
+	record = append(record, body...)
+
+	h := hmac.New(sha256.New, key)
+	common.Must2(h.Write(record))
+	digest := h.Sum(nil)
+	var ts [4]byte
+	binary.LittleEndian.PutUint32(ts[:], uint32(timestamp.Unix()))
+	for i := range ts {
+		digest[28+i] ^= ts[i]
+	}
+	copy(record[11:], digest)
+	return record
+}
+
+func TestFakeTLSHandshake(t *testing.T) {
+	key := make([]byte, 16)
+	common.Must2(rand.Read(key))
+
+	clientHello := createClientHello(key, time.Now())
+	hello, err := ReadFakeTLSClientHello(bytes.NewReader(clientHello), key, "www.example.com")
+	common.Must(err)
+	if err := compare.BytesEqualWithDetail(hello.SessionID, clientHello[44:76]); err != nil {
+		t.Error(err)
+	}
+	if hello.ServerName != "www.example.com" {
+		t.Error("unexpected server name: ", hello.ServerName)
+	}
+
+	var response bytes.Buffer
+	common.Must(WriteFakeTLSServerHello(&response, key, hello))
+
+	serverHello := response.Bytes()
+	random := append([]byte(nil), serverHello[11:43]...)
+	for i := 11; i < 43; i++ {
+		serverHello[i] = 0
+	}
+	h := hmac.New(sha256.New, key)
+	common.Must2(h.Write(hello.Random[:]))
+	common.Must2(h.Write(serverHello))
+	if err := compare.BytesEqualWithDetail(random, h.Sum(nil)); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := ReadFakeTLSClientHello(bytes.NewReader(clientHello), key, "www.example.com"); err == nil {
+		t.Error("replayed ClientHello is accepted")
+	}
+}
+
+func TestFakeTLSInvalidClientHello(t *testing.T) {
+	key := make([]byte, 16)
+	common.Must2(rand.Read(key))
+
+	otherKey := make([]byte, 16)
+	common.Must2(rand.Read(otherKey))
+	if _, err := ReadFakeTLSClientHello(bytes.NewReader(createClientHello(otherKey, time.Now())), key, "www.example.com"); err == nil {
+		t.Error("ClientHello with invalid digest is accepted")
+	}
+
+	if _, err := ReadFakeTLSClientHello(bytes.NewReader(createClientHello(key, time.Now().Add(-time.Hour))), key, "www.example.com"); err == nil {
+		t.Error("expired ClientHello is accepted")
+	}
+}
+
+func TestFakeTLSServerName(t *testing.T) {
+	key := make([]byte, 16)
+	common.Must2(rand.Read(key))
+
+	if _, err := ReadFakeTLSClientHello(bytes.NewReader(createClientHelloWithServerName(key, time.Now(), "WWW.Example.com")), key, "www.example.com"); err != nil {
+		t.Error("ClientHello to the domain is rejected: ", err)
+	}
+	if _, err := ReadFakeTLSClientHello(bytes.NewReader(createClientHelloWithServerName(key, time.Now(), "www.v2ray.com")), key, "www.example.com"); err == nil {
+		t.Error("ClientHello to another domain is accepted")
+	}
+	if _, err := ReadFakeTLSClientHello(bytes.NewReader(createClientHelloWithServerName(key, time.Now(), "")), key, "www.example.com"); err == nil {
+		t.Error("ClientHello without server name is accepted")
+	}
+}
+
+func TestFakeTLSReadWrite(t *testing.T) {
+	payload := make([]byte, 8192)
+	common.Must2(rand.Read(payload))
+
+	var stream bytes.Buffer
+	stream.Write([]byte{0x14, 0x03, 0x03, 0x00, 0x01, 0x01})
+	common.Must2(NewFakeTLSWriter(&stream).Write(payload))
+
+	received := make([]byte, len(payload))
+	common.Must2(io.ReadFull(NewFakeTLSReader(&stream), received))
+	if err := compare.BytesEqualWithDetail(received, payload); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAccountSecretMode(t *testing.T) {
+	key := make([]byte, 16)
+	common.Must2(rand.Read(key))
+
+	cases := []struct {
+		Secret []byte
+		Mode   SecretMode
+		Domain string
+	}{
+		{
+			Secret: key,
+			Mode:   SecretModeSimple,
+		},
+		{
+			Secret: append([]byte{0xdd}, key...),
+			Mode:   SecretModeSecure,
+		},
+		{
+			Secret: append(append([]byte{0xee}, key...), []byte("www.v2ray.com")...),
+			Mode:   SecretModeFakeTLS,
+			Domain: "www.v2ray.com",
+		},
+	}
+
+	for _, c := range cases {
+		account := &Account{Secret: c.Secret}
+		if account.Mode() != c.Mode {
+			t.Error("unexpected mode: ", account.Mode(), " want ", c.Mode)
+		}
+		if err := compare.BytesEqualWithDetail(account.Key(), key); err != nil {
+			t.Error(err)
+		}
+		if account.Domain() != c.Domain {
+			t.Error("unexpected domain: ", account.Domain())
+		}
+	}
+}
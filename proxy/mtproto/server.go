@@ -2,6 +2,7 @@ package mtproto
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"v2ray.com/core"
@@ -20,20 +21,11 @@ import (
 	"v2ray.com/core/transport/pipe"
 )
 
-var (
-	dcList = []net.Address{
-		net.ParseAddress("149.154.175.50"),
-		net.ParseAddress("149.154.167.51"),
-		net.ParseAddress("149.154.175.100"),
-		net.ParseAddress("149.154.167.91"),
-		net.ParseAddress("149.154.171.5"),
-	}
-)
-
 type Server struct {
-	user    *protocol.User
-	account *Account
-	policy  policy.Manager
+	user        *protocol.User
+	account     *Account
+	dataCenters []net.Address
+	policy      policy.Manager
 }
 
 func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
@@ -54,9 +46,10 @@ func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
 	v := core.MustFromContext(ctx)
 
 	return &Server{
-		user:    user,
-		account: account,
-		policy:  v.GetFeature(policy.ManagerType()).(policy.Manager),
+		user:        user,
+		account:     account,
+		dataCenters: config.DataCenters(),
+		policy:      v.GetFeature(policy.ManagerType()).(policy.Manager),
 	}, nil
 }
 
@@ -64,7 +57,13 @@ func (s *Server) Network() []net.Network {
 	return []net.Network{net.Network_TCP}
 }
 
-func isValidConnectionType(c [4]byte) bool {
+func isValidConnectionType(c [4]byte, mode SecretMode) bool {
+	if compare.BytesAll(c[:], 0xdd) {
+		return true
+	}
+	if mode == SecretModeSecure {
+		return false
+	}
 	if compare.BytesAll(c[:], 0xef) {
 		return true
 	}
@@ -80,7 +79,24 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn internet
 	if err := conn.SetDeadline(time.Now().Add(sPolicy.Timeouts.Handshake)); err != nil {
 		newError("failed to set deadline").Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
-	auth, err := ReadAuthentication(conn)
+
+	var reader io.Reader = conn
+	var writer io.Writer = conn
+	mode := s.account.Mode()
+	key := s.account.Key()
+	if mode == SecretModeFakeTLS {
+		hello, err := ReadFakeTLSClientHello(conn, key, s.account.Domain())
+		if err != nil {
+			return newError("failed to read fake TLS handshake").Base(err)
+		}
+		if err := WriteFakeTLSServerHello(conn, key, hello); err != nil {
+			return newError("failed to write fake TLS handshake").Base(err)
+		}
+		reader = NewFakeTLSReader(conn)
+		writer = NewFakeTLSWriter(conn)
+	}
+
+	auth, err := ReadAuthentication(reader)
 	if err != nil {
 		return newError("failed to read authentication header").Base(err)
 	}
@@ -90,24 +106,24 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn internet
 		newError("failed to clear deadline").Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
 
-	auth.ApplySecret(s.account.Secret)
+	auth.ApplySecret(key)
 
 	decryptor := crypto.NewAesCTRStream(auth.DecodingKey[:], auth.DecodingNonce[:])
 	decryptor.XORKeyStream(auth.Header[:], auth.Header[:])
 
 	ct := auth.ConnectionType()
-	if !isValidConnectionType(ct) {
+	if !isValidConnectionType(ct, mode) {
 		return newError("invalid connection type: ", ct)
 	}
 
 	dcID := auth.DataCenterID()
-	if dcID >= uint16(len(dcList)) {
+	if dcID >= uint16(len(s.dataCenters)) {
 		return newError("invalid datacenter id: ", dcID)
 	}
 
 	dest := net.Destination{
 		Network: net.Network_TCP,
-		Address: dcList[dcID],
+		Address: s.dataCenters[dcID],
 		Port:    net.Port(443),
 	}
 
@@ -129,7 +145,7 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn internet
 	request := func() error {
 		defer timer.SetTimeout(sPolicy.Timeouts.DownlinkOnly)
 
-		reader := buf.NewReader(crypto.NewCryptionReader(decryptor, conn))
+		reader := buf.NewReader(crypto.NewCryptionReader(decryptor, reader))
 		return buf.Copy(reader, link.Writer, buf.UpdateActivity(timer))
 	}
 
@@ -137,7 +153,7 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn internet
 		defer timer.SetTimeout(sPolicy.Timeouts.UplinkOnly)
 
 		encryptor := crypto.NewAesCTRStream(auth.EncodingKey[:], auth.EncodingNonce[:])
-		writer := buf.NewWriter(crypto.NewCryptionWriter(encryptor, conn))
+		writer := buf.NewWriter(crypto.NewCryptionWriter(encryptor, writer))
 		return buf.Copy(link.Reader, writer, buf.UpdateActivity(timer))
 	}
 
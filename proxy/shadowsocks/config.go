@@ -12,6 +12,7 @@ import (
 	"golang.org/x/crypto/hkdf"
 
 	"v2ray.com/core/common"
+	"v2ray.com/core/common/antireplay"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/crypto"
 	"v2ray.com/core/common/protocol"
@@ -22,6 +23,8 @@ type MemoryAccount struct {
 	Cipher      Cipher
 	Key         []byte
 	OneTimeAuth Account_OneTimeAuth
+	// ReplayFilter rejects TCP sessions with seen IVs, if not nil. Used for server side.
+	ReplayFilter *antireplay.ReplayFilter
 }
 
 // Equals implements protocol.Account.Equals().
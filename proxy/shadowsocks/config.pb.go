@@ -4,6 +4,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
+	antireplay "v2ray.com/core/common/antireplay"
 	net "v2ray.com/core/common/net"
 	protocol "v2ray.com/core/common/protocol"
 )
@@ -151,12 +152,14 @@ func (m *Account) GetOta() Account_OneTimeAuth {
 type ServerConfig struct {
 	// UdpEnabled specified whether or not to enable UDP for Shadowsocks.
 	// Deprecated. Use 'network' field.
-	UdpEnabled           bool           `protobuf:"varint,1,opt,name=udp_enabled,json=udpEnabled,proto3" json:"udp_enabled,omitempty"` // Deprecated: Do not use.
-	User                 *protocol.User `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Network              []net.Network  `protobuf:"varint,3,rep,packed,name=network,proto3,enum=v2ray.core.common.net.Network" json:"network,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	UdpEnabled bool           `protobuf:"varint,1,opt,name=udp_enabled,json=udpEnabled,proto3" json:"udp_enabled,omitempty"` // Deprecated: Do not use.
+	User       *protocol.User `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Network    []net.Network  `protobuf:"varint,3,rep,packed,name=network,proto3,enum=v2ray.core.common.net.Network" json:"network,omitempty"`
+	// Filter of replayed IVs in TCP sessions.
+	ReplayFilter         *antireplay.Config `protobuf:"bytes,4,opt,name=replay_filter,json=replayFilter,proto3" json:"replay_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ServerConfig) Reset()         { *m = ServerConfig{} }
@@ -206,6 +209,13 @@ func (m *ServerConfig) GetNetwork() []net.Network {
 	return nil
 }
 
+func (m *ServerConfig) GetReplayFilter() *antireplay.Config {
+	if m != nil {
+		return m.ReplayFilter
+	}
+	return nil
+}
+
 type ClientConfig struct {
 	Server               []*protocol.ServerEndpoint `protobuf:"bytes,1,rep,name=server,proto3" json:"server,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
@@ -258,38 +268,41 @@ func init() {
 }
 
 var fileDescriptor_8d089a30c2106007 = []byte{
-	// 522 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xc1, 0x6e, 0xd3, 0x4e,
-	0x10, 0xc6, 0xbb, 0x71, 0xff, 0x4d, 0xfe, 0xe3, 0x50, 0xdc, 0x95, 0x90, 0xac, 0xa8, 0x42, 0x56,
-	0x38, 0x10, 0x2a, 0xb1, 0x4e, 0x5c, 0x8a, 0x7a, 0x75, 0x4c, 0x4a, 0x2b, 0xc0, 0x89, 0x9c, 0x14,
-	0x04, 0x17, 0xcb, 0x5d, 0x2f, 0xc4, 0x6a, 0xe2, 0xb5, 0xd6, 0x76, 0x43, 0x9e, 0x86, 0x03, 0x37,
-	0xde, 0x8c, 0xb7, 0x40, 0x5e, 0x3b, 0xa9, 0x85, 0xaa, 0x70, 0x88, 0x94, 0x99, 0xfd, 0x7d, 0x9f,
-	0x66, 0xbe, 0x31, 0xbc, 0xbc, 0xb3, 0x44, 0xb0, 0x26, 0x94, 0x2f, 0x4d, 0xca, 0x05, 0x33, 0x13,
-	0xc1, 0xbf, 0xaf, 0xcd, 0x74, 0x1e, 0x84, 0x7c, 0x95, 0x72, 0x7a, 0x9b, 0x9a, 0x94, 0xc7, 0x5f,
-	0xa3, 0x6f, 0x24, 0x11, 0x3c, 0xe3, 0xf8, 0x78, 0x83, 0x0b, 0x46, 0x24, 0x4a, 0x6a, 0x68, 0xe7,
-	0xf9, 0x5f, 0x66, 0x94, 0x2f, 0x97, 0x3c, 0x36, 0x63, 0x96, 0x15, 0xbf, 0x15, 0x17, 0xb7, 0xa5,
-	0x4d, 0xe7, 0xc5, 0xc3, 0xa0, 0x7c, 0xa4, 0x7c, 0x61, 0xe6, 0x29, 0x13, 0x15, 0xda, 0xff, 0x07,
-	0x9a, 0x32, 0x71, 0xc7, 0x84, 0x9f, 0x26, 0x8c, 0x96, 0x8a, 0xee, 0x6f, 0x04, 0x4d, 0x9b, 0x52,
-	0x9e, 0xc7, 0x19, 0xee, 0x40, 0x2b, 0x09, 0xd2, 0x74, 0xc5, 0x45, 0xa8, 0x23, 0x03, 0xf5, 0xfe,
-	0xf7, 0xb6, 0x35, 0xbe, 0x02, 0x95, 0x46, 0xc9, 0x9c, 0x09, 0x3f, 0x5b, 0x27, 0x4c, 0x6f, 0x18,
-	0xa8, 0x77, 0x68, 0xf5, 0xc8, 0xae, 0x0d, 0x89, 0x23, 0x05, 0xb3, 0x75, 0xc2, 0x3c, 0xa0, 0xdb,
-	0xff, 0xd8, 0x01, 0x85, 0x67, 0x81, 0xae, 0x48, 0x8b, 0xc1, 0x6e, 0x8b, 0x6a, 0x34, 0x32, 0x8e,
-	0xd9, 0x2c, 0x5a, 0x32, 0x3b, 0xcf, 0xe6, 0x5e, 0xa1, 0xee, 0x5a, 0xa0, 0xd6, 0x7a, 0xb8, 0x05,
-	0xfb, 0x76, 0x9e, 0x71, 0x6d, 0x0f, 0xb7, 0xa1, 0xf5, 0x26, 0x4a, 0x83, 0x9b, 0x05, 0x0b, 0x35,
-	0x84, 0x55, 0x68, 0x8e, 0xe2, 0xb2, 0x68, 0x74, 0x7f, 0x22, 0x68, 0x4f, 0x65, 0x02, 0x8e, 0x3c,
-	0x13, 0x7e, 0x06, 0x6a, 0x1e, 0x26, 0x3e, 0x2b, 0x09, 0xb9, 0x73, 0x6b, 0xd8, 0xd0, 0x91, 0x07,
-	0x79, 0x98, 0x54, 0x3a, 0xfc, 0x0a, 0xf6, 0x8b, 0x84, 0xe5, 0xca, 0xaa, 0x65, 0xd4, 0xe7, 0x2d,
-	0xe3, 0x25, 0x9b, 0x78, 0xc9, 0x75, 0xca, 0x84, 0x27, 0x69, 0x7c, 0x0e, 0xcd, 0xea, 0x8a, 0xba,
-	0x62, 0x28, 0xbd, 0x43, 0xeb, 0xe9, 0x03, 0xc2, 0x98, 0x65, 0xc4, 0x2d, 0x29, 0x6f, 0x83, 0x77,
-	0x3d, 0x68, 0x3b, 0x8b, 0x88, 0xc5, 0x59, 0x35, 0xe4, 0x10, 0x0e, 0xca, 0xb3, 0xe9, 0xc8, 0x50,
-	0x7a, 0xaa, 0x75, 0xb2, 0x6b, 0x82, 0x72, 0xbd, 0x51, 0x1c, 0x26, 0x3c, 0x8a, 0x33, 0xaf, 0x52,
-	0x9e, 0xfc, 0x40, 0x00, 0xf7, 0xd7, 0x28, 0x52, 0xb9, 0x76, 0xdf, 0xb9, 0xe3, 0x4f, 0xae, 0xb6,
-	0x87, 0x1f, 0x83, 0x6a, 0x8f, 0xa6, 0xfe, 0xc0, 0x3a, 0xf7, 0x9d, 0x8b, 0xa1, 0x86, 0x36, 0x0d,
-	0xeb, 0xec, 0xb5, 0x6c, 0x34, 0x8a, 0x48, 0x9d, 0x4b, 0xdb, 0xb9, 0xb4, 0xad, 0xbe, 0xa6, 0xe0,
-	0x23, 0x78, 0xb4, 0xa9, 0xfc, 0xab, 0xd1, 0xec, 0x42, 0xdb, 0xaf, 0x5b, 0xbc, 0x75, 0x3e, 0x68,
-	0xff, 0xd5, 0x2d, 0x8a, 0xc6, 0x01, 0x7e, 0x02, 0x47, 0x5b, 0xd1, 0x64, 0xfc, 0xfe, 0xf3, 0xe0,
-	0xb4, 0x7f, 0xa6, 0x35, 0x8b, 0xb3, 0xb9, 0x63, 0x77, 0xa4, 0xb5, 0x86, 0x13, 0x30, 0x28, 0x5f,
-	0xee, 0xfc, 0x18, 0x26, 0xe8, 0x8b, 0x5a, 0x2b, 0x7f, 0x35, 0x8e, 0x3f, 0x5a, 0x5e, 0xb0, 0x26,
-	0x4e, 0x41, 0x4f, 0x24, 0x3d, 0xbd, 0x7f, 0xbe, 0x39, 0x90, 0xa1, 0x9c, 0xfe, 0x09, 0x00, 0x00,
-	0xff, 0xff, 0xdc, 0x7e, 0x6b, 0x61, 0xb5, 0x03, 0x00, 0x00,
+	// 563 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xc1, 0x6e, 0xd3, 0x4c,
+	0x10, 0xc7, 0xeb, 0x38, 0x5f, 0x93, 0x6f, 0x9c, 0x16, 0x77, 0x25, 0x24, 0xab, 0xaa, 0x90, 0x15,
+	0x90, 0x08, 0x95, 0x58, 0xb7, 0x2e, 0x45, 0xbd, 0x3a, 0x26, 0xa5, 0x15, 0xe0, 0x44, 0x6e, 0x0b,
+	0x82, 0x8b, 0xe5, 0xae, 0xb7, 0xc4, 0x6a, 0xe2, 0xb5, 0xd6, 0xeb, 0x86, 0x3c, 0x0d, 0x77, 0xde,
+	0x8c, 0x0b, 0xcf, 0x80, 0xbc, 0x76, 0x12, 0x83, 0xa2, 0x70, 0xb0, 0xe4, 0x99, 0xfd, 0xfd, 0x67,
+	0x77, 0xfe, 0x33, 0xf0, 0xf2, 0xc1, 0xe6, 0xe1, 0x1c, 0x13, 0x36, 0xb5, 0x08, 0xe3, 0xd4, 0x4a,
+	0x39, 0xfb, 0x36, 0xb7, 0xb2, 0x71, 0x18, 0xb1, 0x59, 0xc6, 0xc8, 0x7d, 0x66, 0x11, 0x96, 0xdc,
+	0xc5, 0x5f, 0x71, 0xca, 0x99, 0x60, 0xe8, 0x60, 0x81, 0x73, 0x8a, 0x25, 0x8a, 0x6b, 0xe8, 0xfe,
+	0xdf, 0xc5, 0x08, 0x9b, 0x4e, 0x59, 0x62, 0x85, 0x89, 0x88, 0x39, 0x4d, 0x27, 0xe1, 0xfc, 0x8f,
+	0x62, 0xfb, 0xcf, 0xd7, 0xe3, 0x09, 0x15, 0xc5, 0x37, 0x63, 0xfc, 0xbe, 0x02, 0x5f, 0xac, 0x07,
+	0xe5, 0x21, 0x61, 0x13, 0x2b, 0xcf, 0x28, 0xaf, 0xd0, 0xa3, 0x7f, 0xa0, 0x19, 0xe5, 0x0f, 0x94,
+	0x07, 0x59, 0x4a, 0x49, 0xa9, 0xe8, 0xfe, 0x54, 0xa0, 0xe5, 0x10, 0xc2, 0xf2, 0x44, 0xa0, 0x7d,
+	0x68, 0xa7, 0x61, 0x96, 0xcd, 0x18, 0x8f, 0x0c, 0xc5, 0x54, 0x7a, 0xff, 0xfb, 0xcb, 0x18, 0x5d,
+	0x82, 0x46, 0xe2, 0x74, 0x4c, 0x79, 0x20, 0xe6, 0x29, 0x35, 0x1a, 0xa6, 0xd2, 0xdb, 0xb5, 0x7b,
+	0x78, 0x93, 0x21, 0xd8, 0x95, 0x82, 0xeb, 0x79, 0x4a, 0x7d, 0x20, 0xcb, 0x7f, 0xe4, 0x82, 0xca,
+	0x44, 0x68, 0xa8, 0xb2, 0xc4, 0xf1, 0xe6, 0x12, 0xd5, 0xd3, 0xf0, 0x30, 0xa1, 0xd7, 0xf1, 0x94,
+	0x3a, 0xb9, 0x18, 0xfb, 0x85, 0xba, 0x6b, 0x83, 0x56, 0xcb, 0xa1, 0x36, 0x34, 0x9d, 0x5c, 0x30,
+	0x7d, 0x0b, 0x75, 0xa0, 0xfd, 0x26, 0xce, 0xc2, 0xdb, 0x09, 0x8d, 0x74, 0x05, 0x69, 0xd0, 0x1a,
+	0x24, 0x65, 0xd0, 0xe8, 0xfe, 0x52, 0xa0, 0x73, 0x25, 0x1d, 0x70, 0xe5, 0x20, 0xd0, 0x53, 0xd0,
+	0xf2, 0x28, 0x0d, 0x68, 0x49, 0xc8, 0x9e, 0xdb, 0xfd, 0x86, 0xa1, 0xf8, 0x90, 0x47, 0x69, 0xa5,
+	0x43, 0xaf, 0xa0, 0x59, 0x38, 0x2c, 0x5b, 0xd6, 0x6c, 0xb3, 0xfe, 0xde, 0xd2, 0x5e, 0xbc, 0xb0,
+	0x17, 0xdf, 0x64, 0x94, 0xfb, 0x92, 0x46, 0x67, 0xd0, 0xaa, 0xa6, 0x68, 0xa8, 0xa6, 0xda, 0xdb,
+	0xb5, 0x9f, 0xac, 0x11, 0x26, 0x54, 0x60, 0xaf, 0xa4, 0xfc, 0x05, 0x8e, 0x2e, 0x61, 0xa7, 0x5c,
+	0x97, 0xe0, 0x2e, 0x9e, 0x08, 0xca, 0x8d, 0xa6, 0xbc, 0xf8, 0xd9, 0x1a, 0xfd, 0x6a, 0xb5, 0x70,
+	0xd9, 0x91, 0xdf, 0x29, 0xc3, 0x73, 0xa9, 0xec, 0xfa, 0xd0, 0x71, 0x27, 0x31, 0x4d, 0x44, 0xd5,
+	0x6f, 0x1f, 0xb6, 0xcb, 0x0d, 0x30, 0x14, 0x53, 0xed, 0x69, 0xf6, 0xe1, 0xa6, 0x66, 0x4a, 0xa7,
+	0x06, 0x49, 0x94, 0xb2, 0x38, 0x11, 0x7e, 0xa5, 0x3c, 0xfc, 0xae, 0x00, 0xac, 0x06, 0x5b, 0x18,
+	0x7c, 0xe3, 0xbd, 0xf3, 0x86, 0x9f, 0x3c, 0x7d, 0x0b, 0x3d, 0x02, 0xcd, 0x19, 0x5c, 0x05, 0xc7,
+	0xf6, 0x59, 0xe0, 0x9e, 0xf7, 0x75, 0x65, 0x91, 0xb0, 0x4f, 0x5f, 0xcb, 0x44, 0xa3, 0x98, 0x8e,
+	0x7b, 0xe1, 0xb8, 0x17, 0x8e, 0x7d, 0xa4, 0xab, 0x68, 0x0f, 0x76, 0x16, 0x51, 0x70, 0x39, 0xb8,
+	0x3e, 0xd7, 0x9b, 0xf5, 0x12, 0x6f, 0xdd, 0x0f, 0xfa, 0x7f, 0xf5, 0x12, 0x45, 0x62, 0x1b, 0x3d,
+	0x86, 0xbd, 0xa5, 0x68, 0x34, 0x7c, 0xff, 0xf9, 0xf8, 0xe4, 0xe8, 0x54, 0x6f, 0x15, 0x1b, 0xe0,
+	0x0d, 0xbd, 0x81, 0xde, 0xee, 0x8f, 0xc0, 0x24, 0x6c, 0xba, 0x71, 0xaf, 0x46, 0xca, 0x17, 0xad,
+	0x16, 0xfe, 0x68, 0x1c, 0x7c, 0xb4, 0x7d, 0xe9, 0x22, 0xa7, 0x78, 0x24, 0xe9, 0xab, 0xd5, 0xf1,
+	0xed, 0xb6, 0x34, 0xe5, 0xe4, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0xfc, 0x67, 0x28, 0xeb, 0x2f,
+	0x04, 0x00, 0x00,
 }
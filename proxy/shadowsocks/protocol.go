@@ -99,6 +99,12 @@ func ReadTCPSession(user *protocol.MemoryUser, reader io.Reader) (*protocol.Requ
 		return nil, nil, newError("invalid remote address.")
 	}
 
+	// The IV is checked after the header is decrypted, and authenticated for AEAD ciphers. Otherwise clients without the
+	// key would be able to flush the filter with random IVs.
+	if len(iv) > 0 && account.ReplayFilter != nil && !account.ReplayFilter.Check(iv) {
+		return nil, nil, newError("duplicated IV, possibly under replay attack")
+	}
+
 	var chunkReader buf.Reader
 	if request.Option.Has(RequestOptionOneTimeAuth) {
 		chunkReader = NewChunkReader(br, NewAuthenticator(ChunkKeyGenerator(iv)))
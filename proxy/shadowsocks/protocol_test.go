@@ -1,9 +1,13 @@
 package shadowsocks_test
 
 import (
+	"bytes"
+	"crypto/rand"
 	"testing"
+	"time"
 
 	"v2ray.com/core/common"
+	"v2ray.com/core/common/antireplay"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol"
@@ -137,6 +141,92 @@ func TestTCPRequest(t *testing.T) {
 
 }
 
+func TestTCPRequestReplay(t *testing.T) {
+	assert := With(t)
+
+	account := toAccount(&Account{
+		Password:   "tcp-password",
+		CipherType: CipherType_AES_128_GCM,
+	})
+	account.(*MemoryAccount).ReplayFilter = antireplay.NewReplayFilter(time.Minute, 1024)
+
+	request := &protocol.RequestHeader{
+		Version: Version,
+		Command: protocol.RequestCommandTCP,
+		Address: net.LocalHostIP,
+		Port:    1234,
+		User: &protocol.MemoryUser{
+			Email:   "love@v2ray.com",
+			Account: account,
+		},
+	}
+
+	cache := buf.New()
+	defer cache.Release()
+
+	writer, err := WriteTCPRequest(request, cache)
+	assert(err, IsNil)
+
+	data := buf.New()
+	common.Must2(data.WriteString("test string"))
+	assert(writer.WriteMultiBuffer(buf.MultiBuffer{data}), IsNil)
+
+	replay := buf.New()
+	defer replay.Release()
+	common.Must2(replay.Write(cache.Bytes()))
+
+	_, _, err = ReadTCPSession(request.User, cache)
+	assert(err, IsNil)
+
+	_, _, err = ReadTCPSession(request.User, replay)
+	assert(err, IsNotNil)
+}
+
+func TestTCPRequestReplayAfterFlood(t *testing.T) {
+	account := toAccount(&Account{
+		Password:   "tcp-password",
+		CipherType: CipherType_AES_128_GCM,
+	})
+	account.(*MemoryAccount).ReplayFilter = antireplay.NewReplayFilter(time.Minute, 8)
+
+	request := &protocol.RequestHeader{
+		Version: Version,
+		Command: protocol.RequestCommandTCP,
+		Address: net.LocalHostIP,
+		Port:    1234,
+		User: &protocol.MemoryUser{
+			Email:   "love@v2ray.com",
+			Account: account,
+		},
+	}
+
+	session := buf.New()
+	defer session.Release()
+	writer, err := WriteTCPRequest(request, session)
+	common.Must(err)
+	data := buf.New()
+	common.Must2(data.WriteString("test string"))
+	common.Must(writer.WriteMultiBuffer(buf.MultiBuffer{data}))
+	captured := append([]byte(nil), session.Bytes()...)
+
+	if _, _, err := ReadTCPSession(request.User, bytes.NewReader(captured)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sessions with random IVs fail authentication, so they must not rotate the filter.
+	for i := 0; i < 100; i++ {
+		garbage := make([]byte, len(captured))
+		common.Must2(rand.Read(garbage))
+		if _, _, err := ReadTCPSession(request.User, bytes.NewReader(garbage)); err == nil {
+			t.Fatal("expected error for random data, but got nil")
+		}
+	}
+
+	if _, _, err := ReadTCPSession(request.User, bytes.NewReader(captured)); err == nil {
+		t.Error("expected error for replayed session, but got nil")
+	}
+}
+
 func TestUDPReaderWriter(t *testing.T) {
 	assert := With(t)
 
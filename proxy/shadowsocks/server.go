@@ -37,6 +37,8 @@ func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
 		return nil, newError("failed to parse user account").Base(err)
 	}
 
+	mUser.Account.(*MemoryAccount).ReplayFilter = config.ReplayFilter.NewFilter()
+
 	v := core.MustFromContext(ctx)
 	s := &Server{
 		config:        *config,
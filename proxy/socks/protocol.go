@@ -3,6 +3,7 @@ package socks
 import (
 	"encoding/binary"
 	"io"
+	"strings"
 
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/buf"
@@ -43,12 +44,44 @@ type ServerSession struct {
 	port   net.Port
 }
 
-func (s *ServerSession) handshake4(cmd byte, reader io.Reader, writer io.Writer) (*protocol.RequestHeader, error) {
-	if s.config.AuthType == AuthType_PASSWORD {
-		writeSocks4Response(writer, socks4RequestRejected, net.AnyIP, net.Port(0)) // nolint: errcheck
-		return nil, newError("socks 4 is not allowed when auth is required.")
+// NewServerSession creates a new ServerSession for handshaking with a client connected to the given port.
+func NewServerSession(config *ServerConfig, port net.Port) *ServerSession {
+	return &ServerSession{
+		config: config,
+		port:   port,
 	}
+}
 
+func (s *ServerSession) newUser(username, password string) *protocol.MemoryUser {
+	return &protocol.MemoryUser{
+		Account: &Account{
+			Username: username,
+			Password: password,
+		},
+		Email: username,
+		Level: s.config.UserLevel,
+	}
+}
+
+// auth4 authenticates a Socks 4 client by its user id. When password auth is required,
+// the user id must be in the form of "username:password" of a configured account.
+func (s *ServerSession) auth4(userID string) (*protocol.MemoryUser, error) {
+	if s.config.AuthType != AuthType_PASSWORD {
+		return nil, nil
+	}
+
+	idx := strings.IndexByte(userID, ':')
+	if idx == -1 {
+		return nil, newError("socks 4 user id is not in the form of username:password")
+	}
+	username, password := userID[:idx], userID[idx+1:]
+	if !s.config.HasAccount(username, password) {
+		return nil, newError("invalid username or password")
+	}
+	return s.newUser(username, password), nil
+}
+
+func (s *ServerSession) handshake4(cmd byte, reader io.Reader, writer io.Writer) (*protocol.RequestHeader, error) {
 	var port net.Port
 	var address net.Address
 
@@ -63,9 +96,16 @@ func (s *ServerSession) handshake4(cmd byte, reader io.Reader, writer io.Writer)
 		buffer.Release()
 	}
 
-	if _, err := ReadUntilNull(reader); /* user id */ err != nil {
+	userID, err := ReadUntilNull(reader)
+	if err != nil {
+		return nil, newError("failed to read user id").Base(err)
+	}
+	user, err := s.auth4(userID)
+	if err != nil {
+		writeSocks4Response(writer, socks4RequestRejected, net.AnyIP, net.Port(0)) // nolint: errcheck
 		return nil, err
 	}
+
 	if address.IP()[0] == 0x00 {
 		domain, err := ReadUntilNull(reader)
 		if err != nil {
@@ -81,6 +121,7 @@ func (s *ServerSession) handshake4(cmd byte, reader io.Reader, writer io.Writer)
 			Address: address,
 			Port:    port,
 			Version: socks4Version,
+			User:    user,
 		}
 		if err := writeSocks4Response(writer, socks4RequestGranted, net.AnyIP, net.Port(0)); err != nil {
 			return nil, err
@@ -92,12 +133,12 @@ func (s *ServerSession) handshake4(cmd byte, reader io.Reader, writer io.Writer)
 	}
 }
 
-func (s *ServerSession) auth5(nMethod byte, reader io.Reader, writer io.Writer) error {
+func (s *ServerSession) auth5(nMethod byte, reader io.Reader, writer io.Writer) (*protocol.MemoryUser, error) {
 	buffer := buf.StackNew()
 	defer buffer.Release()
 
 	if _, err := buffer.ReadFullFrom(reader, int32(nMethod)); err != nil {
-		return newError("failed to read auth methods").Base(err)
+		return nil, newError("failed to read auth methods").Base(err)
 	}
 
 	var expectedAuth byte = authNotRequired
@@ -107,34 +148,36 @@ func (s *ServerSession) auth5(nMethod byte, reader io.Reader, writer io.Writer)
 
 	if !hasAuthMethod(expectedAuth, buffer.BytesRange(0, int32(nMethod))) {
 		writeSocks5AuthenticationResponse(writer, socks5Version, authNoMatchingMethod) // nolint: errcheck
-		return newError("no matching auth method")
+		return nil, newError("no matching auth method")
 	}
 
 	if err := writeSocks5AuthenticationResponse(writer, socks5Version, expectedAuth); err != nil {
-		return newError("failed to write auth response").Base(err)
+		return nil, newError("failed to write auth response").Base(err)
 	}
 
 	if expectedAuth == authPassword {
 		username, password, err := ReadUsernamePassword(reader)
 		if err != nil {
-			return newError("failed to read username and password for authentication").Base(err)
+			return nil, newError("failed to read username and password for authentication").Base(err)
 		}
 
 		if !s.config.HasAccount(username, password) {
 			writeSocks5AuthenticationResponse(writer, 0x01, 0xFF) // nolint: errcheck
-			return newError("invalid username or password")
+			return nil, newError("invalid username or password")
 		}
 
 		if err := writeSocks5AuthenticationResponse(writer, 0x01, 0x00); err != nil {
-			return newError("failed to write auth response").Base(err)
+			return nil, newError("failed to write auth response").Base(err)
 		}
+		return s.newUser(username, password), nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (s *ServerSession) handshake5(nMethod byte, reader io.Reader, writer io.Writer) (*protocol.RequestHeader, error) {
-	if err := s.auth5(nMethod, reader, writer); err != nil {
+	user, err := s.auth5(nMethod, reader, writer)
+	if err != nil {
 		return nil, err
 	}
 
@@ -169,6 +212,7 @@ func (s *ServerSession) handshake5(nMethod byte, reader io.Reader, writer io.Wri
 	}
 
 	request.Version = socks5Version
+	request.User = user
 
 	addr, port, err := addrParser.ReadAddressPort(nil, reader)
 	if err != nil {
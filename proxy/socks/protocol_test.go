@@ -109,6 +109,66 @@ func TestReadUntilNull(t *testing.T) {
 	}
 }
 
+func TestSocks4Handshake(t *testing.T) {
+	config := &ServerConfig{
+		AuthType: AuthType_PASSWORD,
+		Accounts: map[string]string{
+			"user": "pass",
+		},
+	}
+
+	testCases := []struct {
+		Input   []byte
+		Address net.Address
+		Email   string
+		Error   bool
+	}{
+		{
+			Input:   []byte{0x04, 0x01, 0x00, 0x50, 1, 2, 3, 4, 'u', 's', 'e', 'r', ':', 'p', 'a', 's', 's', 0x00},
+			Address: net.IPAddress([]byte{1, 2, 3, 4}),
+			Email:   "user",
+		},
+		{
+			Input:   []byte{0x04, 0x01, 0x00, 0x50, 0, 0, 0, 1, 'u', 's', 'e', 'r', ':', 'p', 'a', 's', 's', 0x00, 'v', '2', 'r', 'a', 'y', '.', 'c', 'o', 'm', 0x00},
+			Address: net.DomainAddress("v2ray.com"),
+			Email:   "user",
+		},
+		{
+			Input: []byte{0x04, 0x01, 0x00, 0x50, 1, 2, 3, 4, 'u', 's', 'e', 'r', 0x00},
+			Error: true,
+		},
+		{
+			Input: []byte{0x04, 0x01, 0x00, 0x50, 1, 2, 3, 4, 'u', 's', 'e', 'r', ':', 'b', 'a', 'd', 0x00},
+			Error: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		response := buf.New()
+		request, err := NewServerSession(config, net.Port(1080)).Handshake(bytes.NewReader(testCase.Input), response)
+		if testCase.Error {
+			if err == nil {
+				t.Error("for input: ", testCase.Input, " expect error, but actually nil")
+			}
+			if response.Len() != 8 || response.Byte(1) != 91 {
+				t.Error("for input: ", testCase.Input, " expect rejection, but actually ", response.Bytes())
+			}
+		} else {
+			if err != nil {
+				t.Error("for input: ", testCase.Input, " expect no error, but actually ", err.Error())
+				continue
+			}
+			if request.Destination() != net.TCPDestination(testCase.Address, net.Port(80)) {
+				t.Error("for input: ", testCase.Input, " expect destination ", testCase.Address, " but actually ", request.Destination())
+			}
+			if request.User == nil || request.User.Email != testCase.Email {
+				t.Error("for input: ", testCase.Input, " expect user ", testCase.Email, " but actually ", request.User)
+			}
+		}
+		response.Release()
+	}
+}
+
 func BenchmarkReadUsernamePassword(b *testing.B) {
 	input := []byte{0x05, 0x01, 'a', 0x02, 'b', 'c'}
 	buffer := buf.New()
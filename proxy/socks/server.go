@@ -82,10 +82,7 @@ func (s *Server) processTCP(ctx context.Context, conn internet.Connection, dispa
 		return newError("inbound gateway not specified")
 	}
 
-	svrSession := &ServerSession{
-		config: s.config,
-		port:   inbound.Gateway.Port,
-	}
+	svrSession := NewServerSession(s.config, inbound.Gateway.Port)
 
 	reader := &buf.BufferedReader{Reader: buf.NewReader(conn)}
 	request, err := svrSession.Handshake(reader, conn)
@@ -105,6 +102,10 @@ func (s *Server) processTCP(ctx context.Context, conn internet.Connection, dispa
 		newError("failed to clear deadline").Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
 
+	if request.User != nil {
+		inbound.User = request.User
+	}
+
 	if request.Command == protocol.RequestCommandTCP {
 		dest := request.Destination()
 		newError("TCP Connect request to ", dest).WriteToLog(session.ExportIDToError(ctx))
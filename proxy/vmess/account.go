@@ -14,6 +14,8 @@ type MemoryAccount struct {
 	AlterIDs []*protocol.ID
 	// Security type of the account. Used for client connections.
 	Security protocol.SecurityType
+	// AEADHeader indicates whether the request header is sent in AEAD format. Used for client connections.
+	AEADHeader bool
 }
 
 // AnyValidID returns an ID that is either the main ID or one of the alternative IDs if any.
@@ -42,8 +44,9 @@ func (a *Account) AsAccount() (protocol.Account, error) {
 	}
 	protoID := protocol.NewID(id)
 	return &MemoryAccount{
-		ID:       protoID,
-		AlterIDs: protocol.NewAlterIDs(protoID, uint16(a.AlterId)),
-		Security: a.SecuritySettings.GetSecurityType(),
+		ID:         protoID,
+		AlterIDs:   protocol.NewAlterIDs(protoID, uint16(a.AlterId)),
+		Security:   a.SecuritySettings.GetSecurityType(),
+		AEADHeader: a.AeadHeader,
 	}, nil
 }
@@ -0,0 +1,8 @@
+// Package aead implements the AEAD based request header of VMess.
+//
+// A request header in this format starts with a 16-byte authenticated ID, which is an AES encrypted
+// block of timestamp, random bytes and checksum, derived from the command key of the user. It is
+// followed by the sealed length of the header, a connection nonce, and finally the sealed header itself.
+package aead
+
+//go:generate errorgen
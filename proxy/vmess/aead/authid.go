@@ -0,0 +1,140 @@
+package aead
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/antireplay"
+)
+
+const (
+	// AuthIDLen is the length of an authenticated ID.
+	AuthIDLen = 16
+
+	authIDTimeDelta = 120
+
+	// MinReplayWindow is the minimum window of a filter for replayed authenticated IDs, which are accepted within
+	// authIDTimeDelta before and after their timestamps.
+	MinReplayWindow = time.Second * authIDTimeDelta * 2
+)
+
+var (
+	ErrNotFound = newError("user do not exist")
+	ErrReplay   = newError("replayed request")
+)
+
+// CreateAuthID creates an authenticated ID from the command key of a user and the given unix time.
+func CreateAuthID(cmdKey []byte, time int64) [AuthIDLen]byte {
+	var plain [AuthIDLen]byte
+	binary.BigEndian.PutUint64(plain[:8], uint64(time))
+	common.Must2(io.ReadFull(rand.Reader, plain[8:12]))
+	binary.BigEndian.PutUint32(plain[12:], crc32.ChecksumIEEE(plain[:12]))
+
+	var result [AuthIDLen]byte
+	newCipherFromKey(cmdKey).Encrypt(result[:], plain[:])
+	return result
+}
+
+func newCipherFromKey(cmdKey []byte) cipher.Block {
+	aesBlock, err := aes.NewCipher(KDF16(cmdKey, kdfSaltConstAuthIDEncryptionKey))
+	common.Must(err)
+	return aesBlock
+}
+
+// AuthIDDecoder decrypts authenticated IDs of a single user.
+type AuthIDDecoder struct {
+	s cipher.Block
+}
+
+// NewAuthIDDecoder creates a new AuthIDDecoder for the given command key.
+func NewAuthIDDecoder(cmdKey []byte) *AuthIDDecoder {
+	return &AuthIDDecoder{newCipherFromKey(cmdKey)}
+}
+
+// Decode decrypts the given authenticated ID, and returns its timestamp and whether its checksum is valid.
+func (aidd *AuthIDDecoder) Decode(authID [AuthIDLen]byte) (int64, bool) {
+	var plain [AuthIDLen]byte
+	aidd.s.Decrypt(plain[:], authID[:])
+	t := int64(binary.BigEndian.Uint64(plain[:8]))
+	return t, binary.BigEndian.Uint32(plain[12:]) == crc32.ChecksumIEEE(plain[:12])
+}
+
+type authIDDecoderItem struct {
+	dec    *AuthIDDecoder
+	ticket interface{}
+}
+
+// AuthIDDecoderHolder matches authenticated IDs against all registered users,
+// and rejects authenticated IDs that were seen before.
+type AuthIDDecoderHolder struct {
+	sync.RWMutex
+	decoders map[string]*authIDDecoderItem
+	filter   *antireplay.ReplayFilter
+}
+
+// NewAuthIDDecoderHolder creates a new AuthIDDecoderHolder.
+func NewAuthIDDecoderHolder() *AuthIDDecoderHolder {
+	return &AuthIDDecoderHolder{
+		decoders: make(map[string]*authIDDecoderItem),
+		filter:   antireplay.NewReplayFilter(MinReplayWindow, 0),
+	}
+}
+
+// SetReplayFilter replaces the filter that rejects replayed authenticated IDs.
+func (a *AuthIDDecoderHolder) SetReplayFilter(filter *antireplay.ReplayFilter) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.filter = filter
+}
+
+// AddUser registers the command key of a user. The ticket is returned by Match when an authenticated ID of the user is found.
+func (a *AuthIDDecoderHolder) AddUser(key [16]byte, ticket interface{}) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.decoders[string(key[:])] = &authIDDecoderItem{
+		dec:    NewAuthIDDecoder(key[:]),
+		ticket: ticket,
+	}
+}
+
+// RemoveUser unregisters the command key of a user.
+func (a *AuthIDDecoderHolder) RemoveUser(key [16]byte) {
+	a.Lock()
+	defer a.Unlock()
+
+	delete(a.decoders, string(key[:]))
+}
+
+// Match returns the ticket of the user who created the given authenticated ID.
+func (a *AuthIDDecoderHolder) Match(authID [AuthIDLen]byte) (interface{}, error) {
+	a.RLock()
+	defer a.RUnlock()
+
+	now := time.Now().Unix()
+	for _, v := range a.decoders {
+		t, ok := v.dec.Decode(authID)
+		if !ok {
+			continue
+		}
+
+		if t < now-authIDTimeDelta || t > now+authIDTimeDelta {
+			continue
+		}
+
+		if !a.filter.Check(authID[:]) {
+			return nil, ErrReplay
+		}
+
+		return v.ticket, nil
+	}
+	return nil, ErrNotFound
+}
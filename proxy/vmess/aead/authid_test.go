@@ -0,0 +1,61 @@
+package aead_test
+
+import (
+	"testing"
+	"time"
+
+	. "v2ray.com/core/proxy/vmess/aead"
+)
+
+func TestCreateAuthID(t *testing.T) {
+	key := KDF16([]byte("Demo Key for Auth ID Test"), "Demo Path for Auth ID Test")
+	authID := CreateAuthID(key, time.Now().Unix())
+
+	var fixedKey [16]byte
+	copy(fixedKey[:], key)
+
+	holder := NewAuthIDDecoderHolder()
+	holder.AddUser(fixedKey, "Demo User")
+
+	ticket, err := holder.Match(authID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.(string) != "Demo User" {
+		t.Error("unexpected ticket: ", ticket)
+	}
+
+	if _, err := holder.Match(authID); err != ErrReplay {
+		t.Error("expect replay error, but got ", err)
+	}
+}
+
+func TestCreateAuthIDExpired(t *testing.T) {
+	key := KDF16([]byte("Demo Key for Auth ID Test"), "Demo Path for Auth ID Test")
+	authID := CreateAuthID(key, time.Now().Unix()-1200)
+
+	var fixedKey [16]byte
+	copy(fixedKey[:], key)
+
+	holder := NewAuthIDDecoderHolder()
+	holder.AddUser(fixedKey, "Demo User")
+
+	if _, err := holder.Match(authID); err != ErrNotFound {
+		t.Error("expect not found error, but got ", err)
+	}
+}
+
+func TestCreateAuthIDWrongUser(t *testing.T) {
+	key := KDF16([]byte("Demo Key for Auth ID Test"), "Demo Path for Auth ID Test")
+	authID := CreateAuthID(key, time.Now().Unix())
+
+	var fixedKey [16]byte
+	copy(fixedKey[:], KDF16([]byte("Demo Key for Auth ID Test2"), "Demo Path for Auth ID Test"))
+
+	holder := NewAuthIDDecoderHolder()
+	holder.AddUser(fixedKey, "Demo User")
+
+	if _, err := holder.Match(authID); err != ErrNotFound {
+		t.Error("expect not found error, but got ", err)
+	}
+}
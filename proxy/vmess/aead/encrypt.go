@@ -0,0 +1,88 @@
+package aead
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"v2ray.com/core/common"
+)
+
+const (
+	connectionNonceLen = 8
+	lengthLen          = 2
+	gcmOverhead        = 16
+)
+
+func newAesGcm(key []byte) cipher.AEAD {
+	block, err := aes.NewCipher(key)
+	common.Must(err)
+	aead, err := cipher.NewGCM(block)
+	common.Must(err)
+	return aead
+}
+
+// SealVMessAEADHeader seals the given request header with the command key of a user.
+// The result consists of authenticated ID, sealed header length, connection nonce and sealed header.
+func SealVMessAEADHeader(key [16]byte, data []byte) []byte {
+	generatedAuthID := CreateAuthID(key[:], time.Now().Unix())
+
+	var connectionNonce [connectionNonceLen]byte
+	common.Must2(io.ReadFull(rand.Reader, connectionNonce[:]))
+
+	var headerLength [lengthLen]byte
+	binary.BigEndian.PutUint16(headerLength[:], uint16(len(data)))
+
+	lengthKey := KDF16(key[:], kdfSaltConstVMessHeaderPayloadLengthAEADKey, string(generatedAuthID[:]), string(connectionNonce[:]))
+	lengthNonce := KDF(key[:], kdfSaltConstVMessHeaderPayloadLengthAEADIV, string(generatedAuthID[:]), string(connectionNonce[:]))[:12]
+	sealedLength := newAesGcm(lengthKey).Seal(nil, lengthNonce, headerLength[:], generatedAuthID[:])
+
+	payloadKey := KDF16(key[:], kdfSaltConstVMessHeaderPayloadAEADKey, string(generatedAuthID[:]), string(connectionNonce[:]))
+	payloadNonce := KDF(key[:], kdfSaltConstVMessHeaderPayloadAEADIV, string(generatedAuthID[:]), string(connectionNonce[:]))[:12]
+	sealedPayload := newAesGcm(payloadKey).Seal(nil, payloadNonce, data, generatedAuthID[:])
+
+	output := bytes.NewBuffer(make([]byte, 0, AuthIDLen+len(sealedLength)+connectionNonceLen+len(sealedPayload)))
+	common.Must2(output.Write(generatedAuthID[:]))
+	common.Must2(output.Write(sealedLength))
+	common.Must2(output.Write(connectionNonce[:]))
+	common.Must2(output.Write(sealedPayload))
+	return output.Bytes()
+}
+
+// OpenVMessAEADHeader reads and opens a sealed request header from the given reader, whose authenticated ID
+// has already been read and matched against the command key.
+func OpenVMessAEADHeader(key [16]byte, authID [AuthIDLen]byte, reader io.Reader) ([]byte, error) {
+	var sealedLength [lengthLen + gcmOverhead]byte
+	var connectionNonce [connectionNonceLen]byte
+
+	if _, err := io.ReadFull(reader, sealedLength[:]); err != nil {
+		return nil, newError("failed to read header length").Base(err)
+	}
+	if _, err := io.ReadFull(reader, connectionNonce[:]); err != nil {
+		return nil, newError("failed to read connection nonce").Base(err)
+	}
+
+	lengthKey := KDF16(key[:], kdfSaltConstVMessHeaderPayloadLengthAEADKey, string(authID[:]), string(connectionNonce[:]))
+	lengthNonce := KDF(key[:], kdfSaltConstVMessHeaderPayloadLengthAEADIV, string(authID[:]), string(connectionNonce[:]))[:12]
+	headerLength, err := newAesGcm(lengthKey).Open(nil, lengthNonce, sealedLength[:], authID[:])
+	if err != nil {
+		return nil, newError("failed to open header length").Base(err)
+	}
+
+	sealedPayload := make([]byte, int(binary.BigEndian.Uint16(headerLength))+gcmOverhead)
+	if _, err := io.ReadFull(reader, sealedPayload); err != nil {
+		return nil, newError("failed to read header").Base(err)
+	}
+
+	payloadKey := KDF16(key[:], kdfSaltConstVMessHeaderPayloadAEADKey, string(authID[:]), string(connectionNonce[:]))
+	payloadNonce := KDF(key[:], kdfSaltConstVMessHeaderPayloadAEADIV, string(authID[:]), string(connectionNonce[:]))[:12]
+	payload, err := newAesGcm(payloadKey).Open(nil, payloadNonce, sealedPayload, authID[:])
+	if err != nil {
+		return nil, newError("failed to open header").Base(err)
+	}
+	return payload, nil
+}
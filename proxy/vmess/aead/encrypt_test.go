@@ -0,0 +1,48 @@
+package aead_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "v2ray.com/core/proxy/vmess/aead"
+)
+
+func TestOpenVMessAEADHeader(t *testing.T) {
+	TestHeader := []byte("Test Header")
+	key := KDF16([]byte("Demo Key for VMess AEAD Header"), "Demo Path for VMess AEAD Header")
+	var keyArray [16]byte
+	copy(keyArray[:], key)
+
+	sealed := SealVMessAEADHeader(keyArray, TestHeader)
+
+	var authID [16]byte
+	copy(authID[:], sealed)
+	reader := bytes.NewReader(sealed[16:])
+
+	header, err := OpenVMessAEADHeader(keyArray, authID, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(header, TestHeader) {
+		t.Error("unexpected header: ", string(header))
+	}
+	if reader.Len() != 0 {
+		t.Error("unread bytes: ", reader.Len())
+	}
+}
+
+func TestOpenVMessAEADHeaderTampered(t *testing.T) {
+	key := KDF16([]byte("Demo Key for VMess AEAD Header"), "Demo Path for VMess AEAD Header")
+	var keyArray [16]byte
+	copy(keyArray[:], key)
+
+	sealed := SealVMessAEADHeader(keyArray, []byte("Test Header"))
+	sealed[len(sealed)-1] ^= 0xff
+
+	var authID [16]byte
+	copy(authID[:], sealed)
+
+	if _, err := OpenVMessAEADHeader(keyArray, authID, bytes.NewReader(sealed[16:])); err == nil {
+		t.Error("expect error, but got nil")
+	}
+}
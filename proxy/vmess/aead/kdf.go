@@ -0,0 +1,45 @@
+package aead
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+)
+
+const (
+	kdfSaltConstAuthIDEncryptionKey             = "AES Auth ID Encryption"
+	kdfSaltConstVMessHeaderPayloadAEADKey       = "VMess Header AEAD Key"
+	kdfSaltConstVMessHeaderPayloadAEADIV        = "VMess Header AEAD Nonce"
+	kdfSaltConstVMessHeaderPayloadLengthAEADKey = "VMess Header AEAD Key_Length"
+	kdfSaltConstVMessHeaderPayloadLengthAEADIV  = "VMess Header AEAD Nonce_Length"
+)
+
+const kdfRootKey = "VMess AEAD KDF"
+
+type hmacCreator struct {
+	parent *hmacCreator
+	value  []byte
+}
+
+func (h *hmacCreator) Create() hash.Hash {
+	if h.parent == nil {
+		return hmac.New(sha256.New, h.value)
+	}
+	return hmac.New(h.parent.Create, h.value)
+}
+
+// KDF derives a 32-byte key from the given key and path, using nested HMAC-SHA256.
+func KDF(key []byte, path ...string) []byte {
+	creator := &hmacCreator{value: []byte(kdfRootKey)}
+	for _, v := range path {
+		creator = &hmacCreator{parent: creator, value: []byte(v)}
+	}
+	h := creator.Create()
+	h.Write(key) // nolint: errcheck
+	return h.Sum(nil)
+}
+
+// KDF16 derives a 16-byte key from the given key and path.
+func KDF16(key []byte, path ...string) []byte {
+	return KDF(key, path...)[:16]
+}
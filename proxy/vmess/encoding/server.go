@@ -1,6 +1,7 @@
 package encoding
 
 import (
+	"bytes"
 	"crypto/md5"
 	"encoding/binary"
 	"hash/fnv"
@@ -18,6 +19,7 @@ import (
 	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/common/task"
 	"v2ray.com/core/proxy/vmess"
+	"v2ray.com/core/proxy/vmess/aead"
 )
 
 type sessionId struct {
@@ -129,17 +131,34 @@ func (s *ServerSession) DecodeRequestHeader(reader io.Reader) (*protocol.Request
 		return nil, newError("failed to read request header").Base(err)
 	}
 
+	var decryptor io.Reader
 	user, timestamp, valid := s.userValidator.Get(buffer.Bytes())
-	if !valid {
-		return nil, newError("invalid user")
+	if valid {
+		iv := hashTimestamp(md5.New(), timestamp)
+		vmessAccount := user.Account.(*vmess.MemoryAccount)
+
+		aesStream := crypto.NewAesDecryptionStream(vmessAccount.ID.CmdKey(), iv[:])
+		decryptor = crypto.NewCryptionReader(aesStream, reader)
+	} else {
+		// Not a legacy request header. Try AEAD header instead.
+		aeadUser, err := s.userValidator.GetAEAD(buffer.Bytes())
+		if err != nil {
+			return nil, newError("invalid user").Base(err)
+		}
+		user = aeadUser
+
+		var cmdKey [16]byte
+		var authID [aead.AuthIDLen]byte
+		copy(cmdKey[:], user.Account.(*vmess.MemoryAccount).ID.CmdKey())
+		copy(authID[:], buffer.Bytes())
+		header, err := aead.OpenVMessAEADHeader(cmdKey, authID, reader)
+		if err != nil {
+			return nil, newError("invalid AEAD header").Base(err)
+		}
+		decryptor = bytes.NewReader(header)
 	}
-
-	iv := hashTimestamp(md5.New(), timestamp)
 	vmessAccount := user.Account.(*vmess.MemoryAccount)
 
-	aesStream := crypto.NewAesDecryptionStream(vmessAccount.ID.CmdKey(), iv[:])
-	decryptor := crypto.NewCryptionReader(aesStream, reader)
-
 	buffer.Clear()
 	if _, err := buffer.ReadFullFrom(decryptor, 38); err != nil {
 		return nil, newError("failed to read request header").Base(err)
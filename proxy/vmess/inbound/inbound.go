@@ -24,6 +24,7 @@ import (
 	"v2ray.com/core/features/policy"
 	"v2ray.com/core/features/routing"
 	"v2ray.com/core/proxy/vmess"
+	"v2ray.com/core/proxy/vmess/aead"
 	"v2ray.com/core/proxy/vmess/encoding"
 	"v2ray.com/core/transport/internet"
 	"v2ray.com/core/transport/pipe"
@@ -123,6 +124,13 @@ func New(ctx context.Context, config *Config) (*Handler, error) {
 		secure:                config.SecureEncryptionOnly,
 	}
 
+	if config.ReplayFilter != nil {
+		if window := config.ReplayFilter.GetWindowValue(); window < aead.MinReplayWindow {
+			return nil, newError("replay filter window ", window, " is shorter than ", aead.MinReplayWindow)
+		}
+		handler.clients.SetReplayFilter(config.ReplayFilter.NewFilter())
+	}
+
 	for _, user := range config.User {
 		mUser, err := user.ToMemoryUser()
 		if err != nil {
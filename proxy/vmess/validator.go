@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"v2ray.com/core/common"
+	"v2ray.com/core/common/antireplay"
 	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/common/serial"
 	"v2ray.com/core/common/task"
+	"v2ray.com/core/proxy/vmess/aead"
 )
 
 const (
@@ -29,6 +31,9 @@ type TimedUserValidator struct {
 	hasher   protocol.IDHash
 	baseTime protocol.Timestamp
 	task     *task.Periodic
+	filter   *antireplay.ReplayFilter
+
+	aeadDecoderHolder *aead.AuthIDDecoderHolder
 }
 
 type indexTimePair struct {
@@ -43,7 +48,11 @@ func NewTimedUserValidator(hasher protocol.IDHash) *TimedUserValidator {
 		userHash: make(map[[16]byte]indexTimePair, 1024),
 		hasher:   hasher,
 		baseTime: protocol.Timestamp(time.Now().Unix() - cacheDurationSec*2),
+		filter:   antireplay.NewReplayFilter(aead.MinReplayWindow, 0),
+
+		aeadDecoderHolder: aead.NewAuthIDDecoderHolder(),
 	}
+	tuv.aeadDecoderHolder.SetReplayFilter(tuv.filter)
 	tuv.task = &task.Periodic{
 		Interval: updateInterval,
 		Execute: func() error {
@@ -122,6 +131,11 @@ func (v *TimedUserValidator) Add(u *protocol.MemoryUser) error {
 	v.users = append(v.users, uu)
 	v.generateNewHashes(protocol.Timestamp(nowSec), uu)
 
+	account := uu.user.Account.(*MemoryAccount)
+	var cmdKey [16]byte
+	copy(cmdKey[:], account.ID.CmdKey())
+	v.aeadDecoderHolder.AddUser(cmdKey, uu)
+
 	return nil
 }
 
@@ -132,12 +146,44 @@ func (v *TimedUserValidator) Get(userHash []byte) (*protocol.MemoryUser, protoco
 	var fixedSizeHash [16]byte
 	copy(fixedSizeHash[:], userHash)
 	pair, found := v.userHash[fixedSizeHash]
-	if found {
-		var user protocol.MemoryUser
-		user = pair.user.user
-		return &user, protocol.Timestamp(pair.timeInc) + v.baseTime, true
+	if !found {
+		return nil, 0, false
+	}
+	// The hash is accepted only within cacheDurationSec of its timestamp, so that a replay filter of
+	// aead.MinReplayWindow remembers it for as long as it is valid.
+	timestamp := protocol.Timestamp(pair.timeInc) + v.baseTime
+	nowSec := protocol.Timestamp(time.Now().Unix())
+	if timestamp+cacheDurationSec < nowSec || timestamp > nowSec+cacheDurationSec {
+		return nil, 0, false
 	}
-	return nil, 0, false
+	if !v.filter.Check(fixedSizeHash[:]) {
+		return nil, 0, false
+	}
+	var user protocol.MemoryUser
+	user = pair.user.user
+	return &user, timestamp, true
+}
+
+// SetReplayFilter sets the filter that rejects replayed user hashes of legacy request headers and
+// authenticated IDs of AEAD request headers.
+func (v *TimedUserValidator) SetReplayFilter(filter *antireplay.ReplayFilter) {
+	v.Lock()
+	v.filter = filter
+	v.Unlock()
+	v.aeadDecoderHolder.SetReplayFilter(filter)
+}
+
+// GetAEAD returns the user who created the given authenticated ID of an AEAD request header.
+func (v *TimedUserValidator) GetAEAD(authID []byte) (*protocol.MemoryUser, error) {
+	var fixedSizeAuthID [aead.AuthIDLen]byte
+	copy(fixedSizeAuthID[:], authID)
+
+	ticket, err := v.aeadDecoderHolder.Match(fixedSizeAuthID)
+	if err != nil {
+		return nil, err
+	}
+	user := ticket.(*user).user
+	return &user, nil
 }
 
 func (v *TimedUserValidator) Remove(email string) bool {
@@ -157,6 +203,11 @@ func (v *TimedUserValidator) Remove(email string) bool {
 	}
 	ulen := len(v.users)
 
+	account := v.users[idx].user.Account.(*MemoryAccount)
+	var cmdKey [16]byte
+	copy(cmdKey[:], account.ID.CmdKey())
+	v.aeadDecoderHolder.RemoveUser(cmdKey)
+
 	v.users[idx] = v.users[ulen-1]
 	v.users[ulen-1] = nil
 	v.users = v.users[:ulen-1]
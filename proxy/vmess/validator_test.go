@@ -80,3 +80,32 @@ func TestUserValidator(t *testing.T) {
 	assert(v.Remove(user.Email), IsTrue)
 	assert(v.Remove(user.Email), IsFalse)
 }
+
+func TestUserValidatorReplay(t *testing.T) {
+	hasher := protocol.DefaultIDHash
+	v := NewTimedUserValidator(hasher)
+	defer common.Close(v)
+
+	id := uuid.New()
+	account, err := (&Account{
+		Id:      id.String(),
+		AlterId: 0,
+	}).AsAccount()
+	common.Must(err)
+	common.Must(v.Add(&protocol.MemoryUser{
+		Email:   "test",
+		Account: account,
+	}))
+
+	ts := protocol.Timestamp(time.Now().Unix())
+	idHash := hasher(id.Bytes())
+	common.Must2(serial.WriteUint64(idHash, uint64(ts)))
+	userHash := idHash.Sum(nil)
+
+	if _, _, found := v.Get(userHash); !found {
+		t.Fatal("user not found")
+	}
+	if _, _, found := v.Get(userHash); found {
+		t.Error("replayed user hash is accepted")
+	}
+}
@@ -1,6 +1,10 @@
 package internet
 
-import "v2ray.com/core/common/serial"
+import (
+	"time"
+
+	"v2ray.com/core/common/serial"
+)
 
 type ConfigCreator func() interface{}
 
@@ -134,3 +138,13 @@ func (c *ProxyConfig) HasTag() bool {
 func (m SocketConfig_TProxyMode) IsEnabled() bool {
 	return m != SocketConfig_Off
 }
+
+// DefaultHappyEyeballsDelay is the delay between connection attempts recommended by RFC 8305.
+const DefaultHappyEyeballsDelay = 250 * time.Millisecond
+
+func (c *SocketConfig) getHappyEyeballsDelay() time.Duration {
+	if c == nil || c.HappyEyeballsDelay == 0 {
+		return DefaultHappyEyeballsDelay
+	}
+	return time.Duration(c.HappyEyeballsDelay) * time.Millisecond
+}
@@ -4,6 +4,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
+	net "v2ray.com/core/common/net"
 	serial "v2ray.com/core/common/serial"
 )
 
@@ -261,6 +262,10 @@ func (m *StreamConfig) GetSocketSettings() *SocketConfig {
 }
 
 type ProxyConfig struct {
+	// Tag of the outbound handler that raw connections are opened through. The
+	// stream settings of the dialing handler still apply on top of them. The
+	// handler may be chained to another one in the same way. QUIC sends its
+	// packets through the handler as UDP, so the handler must support UDP.
 	Tag                  string   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -309,12 +314,40 @@ type SocketConfig struct {
 	Tproxy SocketConfig_TProxyMode `protobuf:"varint,3,opt,name=tproxy,proto3,enum=v2ray.core.transport.internet.SocketConfig_TProxyMode" json:"tproxy,omitempty"`
 	// ReceiveOriginalDestAddress is for enabling IP_RECVORIGDSTADDR socket option.
 	// This option is for UDP only.
-	ReceiveOriginalDestAddress bool     `protobuf:"varint,4,opt,name=receive_original_dest_address,json=receiveOriginalDestAddress,proto3" json:"receive_original_dest_address,omitempty"`
-	BindAddress                []byte   `protobuf:"bytes,5,opt,name=bind_address,json=bindAddress,proto3" json:"bind_address,omitempty"`
-	BindPort                   uint32   `protobuf:"varint,6,opt,name=bind_port,json=bindPort,proto3" json:"bind_port,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
-	XXX_unrecognized           []byte   `json:"-"`
-	XXX_sizecache              int32    `json:"-"`
+	ReceiveOriginalDestAddress bool   `protobuf:"varint,4,opt,name=receive_original_dest_address,json=receiveOriginalDestAddress,proto3" json:"receive_original_dest_address,omitempty"`
+	BindAddress                []byte `protobuf:"bytes,5,opt,name=bind_address,json=bindAddress,proto3" json:"bind_address,omitempty"`
+	BindPort                   uint32 `protobuf:"varint,6,opt,name=bind_port,json=bindPort,proto3" json:"bind_port,omitempty"`
+	// Server names of TLS connections to accept, when the listening address is
+	// shared with other inbounds. Connections are routed by the server name in
+	// ClientHello, without terminating TLS. Names like "*.v2ray.com" match all
+	// subdomains. Other listening options, such as tfo and
+	// accept_proxy_protocol, must be the same on all inbounds sharing the
+	// address. This option is for listening TCP only.
+	SniServerName []string `protobuf:"bytes,7,rep,name=sni_server_name,json=sniServerName,proto3" json:"sni_server_name,omitempty"`
+	// Destination to forward connections on the shared address whose server
+	// name matches no inbound, such as a local web server. Such connections are
+	// closed if not set.
+	SniFallback *net.Endpoint `protobuf:"bytes,8,opt,name=sni_fallback,json=sniFallback,proto3" json:"sni_fallback,omitempty"`
+	// Whether accepted connections start with a PROXY protocol header, which
+	// carries the address of the original client. Connections without a valid
+	// header are rejected. This option is for TCP transport only.
+	AcceptProxyProtocol bool `protobuf:"varint,9,opt,name=accept_proxy_protocol,json=acceptProxyProtocol,proto3" json:"accept_proxy_protocol,omitempty"`
+	// Version of PROXY protocol header, 1 or 2, to send on dialed connections.
+	// The header carries the source address of the inbound connection. No
+	// header is sent if zero. This option is for TCP transport only.
+	ProxyProtocol uint32 `protobuf:"varint,10,opt,name=proxy_protocol,json=proxyProtocol,proto3" json:"proxy_protocol,omitempty"`
+	// Delay in milliseconds before the next address is tried while connecting
+	// to a domain, as in Happy Eyeballs (RFC 8305). IPv6 and IPv4 addresses are
+	// tried alternately. Default value 250 ms is used if zero. This option is
+	// for TCP only.
+	HappyEyeballsDelay uint32 `protobuf:"varint,11,opt,name=happy_eyeballs_delay,json=happyEyeballsDelay,proto3" json:"happy_eyeballs_delay,omitempty"`
+	// Name of the network interface to bind the socket to, with
+	// SO_BINDTODEVICE. Dialing or listening fails if the socket can't be
+	// bound. This option is for Linux only.
+	Interface            string   `protobuf:"bytes,12,opt,name=interface,proto3" json:"interface,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SocketConfig) Reset()         { *m = SocketConfig{} }
@@ -384,6 +417,48 @@ func (m *SocketConfig) GetBindPort() uint32 {
 	return 0
 }
 
+func (m *SocketConfig) GetSniServerName() []string {
+	if m != nil {
+		return m.SniServerName
+	}
+	return nil
+}
+
+func (m *SocketConfig) GetSniFallback() *net.Endpoint {
+	if m != nil {
+		return m.SniFallback
+	}
+	return nil
+}
+
+func (m *SocketConfig) GetAcceptProxyProtocol() bool {
+	if m != nil {
+		return m.AcceptProxyProtocol
+	}
+	return false
+}
+
+func (m *SocketConfig) GetProxyProtocol() uint32 {
+	if m != nil {
+		return m.ProxyProtocol
+	}
+	return 0
+}
+
+func (m *SocketConfig) GetHappyEyeballsDelay() uint32 {
+	if m != nil {
+		return m.HappyEyeballsDelay
+	}
+	return 0
+}
+
+func (m *SocketConfig) GetInterface() string {
+	if m != nil {
+		return m.Interface
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("v2ray.core.transport.internet.TransportProtocol", TransportProtocol_name, TransportProtocol_value)
 	proto.RegisterEnum("v2ray.core.transport.internet.SocketConfig_TCPFastOpenState", SocketConfig_TCPFastOpenState_name, SocketConfig_TCPFastOpenState_value)
@@ -399,45 +474,55 @@ func init() {
 }
 
 var fileDescriptor_91dbc815c3d97a05 = []byte{
-	// 636 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xdd, 0x6a, 0xdb, 0x4c,
-	0x10, 0x8d, 0x2c, 0xc7, 0x91, 0xc7, 0x8e, 0xa3, 0xec, 0x95, 0xc9, 0x47, 0xf8, 0x12, 0x17, 0x8a,
-	0x69, 0x41, 0x0a, 0x2a, 0xed, 0x55, 0x6f, 0x12, 0xbb, 0xa5, 0xa1, 0x4d, 0x2c, 0x64, 0xb5, 0x85,
-	0x40, 0x11, 0x6b, 0x69, 0x6c, 0x44, 0x2c, 0xad, 0xd9, 0xdd, 0x86, 0xfa, 0x95, 0x0a, 0xbd, 0xeb,
-	0x43, 0xf4, 0xb1, 0xca, 0xae, 0x7e, 0x6a, 0xd2, 0x92, 0x26, 0xf4, 0x6e, 0x34, 0x73, 0xe6, 0xcc,
-	0x9c, 0x39, 0x5a, 0x70, 0x6e, 0x3c, 0x4e, 0xd7, 0x4e, 0xcc, 0x32, 0x37, 0x66, 0x1c, 0x5d, 0xc9,
-	0x69, 0x2e, 0x56, 0x8c, 0x4b, 0x37, 0xcd, 0x25, 0xf2, 0x1c, 0xa5, 0x1b, 0xb3, 0x7c, 0x9e, 0x2e,
-	0x9c, 0x15, 0x67, 0x92, 0x91, 0xc3, 0x0a, 0xcf, 0xd1, 0xa9, 0xb1, 0x4e, 0x85, 0x3d, 0x38, 0xb9,
-	0x45, 0x17, 0xb3, 0x2c, 0x63, 0xb9, 0x2b, 0x90, 0xa7, 0x74, 0xe9, 0xca, 0xf5, 0x0a, 0x93, 0x28,
-	0x43, 0x21, 0xe8, 0x02, 0x0b, 0xc2, 0xc1, 0x0f, 0x03, 0xf6, 0xc2, 0x8a, 0x68, 0xa4, 0x47, 0x91,
-	0x77, 0x60, 0xe9, 0x62, 0xcc, 0x96, 0x7d, 0xe3, 0xc8, 0x18, 0xf6, 0xbc, 0x13, 0xe7, 0xce, 0xb9,
-	0x4e, 0xcd, 0xe0, 0x97, 0x7d, 0x41, 0xcd, 0x40, 0x1e, 0xc1, 0x6e, 0x15, 0x47, 0x39, 0xcd, 0xb0,
-	0x6f, 0x1e, 0x19, 0xc3, 0x76, 0xd0, 0xad, 0x92, 0x97, 0x34, 0x43, 0x72, 0x06, 0x96, 0x40, 0x29,
-	0xd3, 0x7c, 0x21, 0xfa, 0x8d, 0x23, 0x63, 0xd8, 0xf1, 0x1e, 0x6f, 0x8e, 0x2c, 0x74, 0x38, 0x85,
-	0x0e, 0x27, 0x54, 0x3a, 0x2e, 0x0a, 0x19, 0x41, 0xdd, 0x37, 0xf8, 0x6e, 0x42, 0x77, 0x2a, 0x39,
-	0xd2, 0xac, 0xd4, 0xe1, 0xff, 0xbb, 0x8e, 0xb3, 0x46, 0xdf, 0xb8, 0x4b, 0xcb, 0xf6, 0x1f, 0xb4,
-	0x7c, 0x02, 0x52, 0x53, 0x47, 0x1b, 0xaa, 0xcc, 0x61, 0xc7, 0x73, 0xee, 0xbb, 0x40, 0x21, 0x21,
-	0xd8, 0xaf, 0x31, 0xd3, 0x92, 0x48, 0xed, 0x20, 0x30, 0xfe, 0xcc, 0x53, 0xb9, 0x8e, 0x94, 0xa3,
-	0xd5, 0x3d, 0xab, 0xa4, 0xba, 0x0e, 0x99, 0xc2, 0x7e, 0x0d, 0xaa, 0x57, 0x68, 0xea, 0x15, 0xee,
-	0x7b, 0x58, 0xbb, 0x22, 0xa8, 0x27, 0x87, 0xb0, 0x27, 0x58, 0x7c, 0x8d, 0x1b, 0xaa, 0x5a, 0xda,
-	0xab, 0xa7, 0x7f, 0x51, 0x35, 0xd5, 0x5d, 0xa5, 0xa4, 0x5e, 0xc1, 0x51, 0xb1, 0x0e, 0xfe, 0x87,
-	0x8e, 0xcf, 0xd9, 0x97, 0x75, 0x69, 0x9a, 0x0d, 0xa6, 0xa4, 0x0b, 0xed, 0x57, 0x3b, 0x50, 0xe1,
-	0xe0, 0x9b, 0xf2, 0x75, 0x83, 0x81, 0x10, 0x68, 0x66, 0x94, 0x5f, 0x6b, 0xcc, 0x76, 0xa0, 0x63,
-	0x72, 0x09, 0xa6, 0x9c, 0x33, 0xfd, 0xef, 0xf4, 0xbc, 0x97, 0x0f, 0xd8, 0xc7, 0x09, 0x47, 0xfe,
-	0x6b, 0x2a, 0xe4, 0x64, 0x85, 0xf9, 0x54, 0x52, 0x89, 0x81, 0x22, 0x22, 0x97, 0xd0, 0x92, 0x2b,
-	0xb5, 0x96, 0x3e, 0x6f, 0xcf, 0x7b, 0xf1, 0x20, 0x4a, 0x2d, 0xe8, 0x82, 0x25, 0x18, 0x94, 0x2c,
-	0xe4, 0x14, 0x0e, 0x39, 0xc6, 0x98, 0xde, 0x60, 0xc4, 0x78, 0xba, 0x48, 0x73, 0xba, 0x8c, 0x12,
-	0x14, 0x32, 0xa2, 0x49, 0xc2, 0x51, 0x28, 0x73, 0x8c, 0xa1, 0x15, 0x1c, 0x94, 0xa0, 0x49, 0x89,
-	0x19, 0xa3, 0x90, 0xa7, 0x05, 0x82, 0x1c, 0x43, 0x77, 0x96, 0xe6, 0x49, 0xdd, 0xa1, 0xfe, 0xbd,
-	0x6e, 0xd0, 0x51, 0xb9, 0x0a, 0xf2, 0x1f, 0xb4, 0x35, 0x44, 0xed, 0xa6, 0xbd, 0xd9, 0x0d, 0x2c,
-	0x95, 0xf0, 0x19, 0x97, 0x83, 0xe7, 0x60, 0xdf, 0xd6, 0x4a, 0x2c, 0x68, 0x9e, 0x8a, 0x73, 0x61,
-	0x6f, 0x11, 0x80, 0xd6, 0xab, 0x9c, 0xce, 0x96, 0x68, 0x1b, 0xa4, 0x03, 0x3b, 0xe3, 0x54, 0xe8,
-	0x8f, 0xc6, 0xc0, 0x05, 0xf8, 0xa5, 0x87, 0xec, 0x80, 0x39, 0x99, 0xcf, 0x0b, 0x7c, 0x91, 0xb6,
-	0x0d, 0xd2, 0x05, 0x2b, 0xc0, 0x24, 0xe5, 0x18, 0x4b, 0xbb, 0xf1, 0xe4, 0x0a, 0xf6, 0x7f, 0x7b,
-	0x47, 0xaa, 0x2f, 0x1c, 0xf9, 0xf6, 0x96, 0x0a, 0xde, 0x8f, 0x7d, 0xdb, 0x50, 0xa3, 0x2f, 0xde,
-	0x8e, 0x7c, 0xbb, 0x41, 0x76, 0xa1, 0xfd, 0x11, 0x67, 0xc5, 0x05, 0x6d, 0x53, 0x15, 0xde, 0x84,
-	0xa1, 0x6f, 0x37, 0x89, 0x0d, 0xdd, 0x31, 0xcb, 0x68, 0x9a, 0x97, 0xb5, 0xed, 0xb3, 0x09, 0x1c,
-	0xc7, 0x2c, 0xbb, 0xdb, 0x0b, 0xdf, 0xb8, 0xb2, 0xaa, 0xf8, 0x6b, 0xe3, 0xf0, 0x83, 0x17, 0xd0,
-	0xb5, 0x33, 0x52, 0xd8, 0x7a, 0x2d, 0xe7, 0xbc, 0xac, 0xcf, 0x5a, 0xfa, 0xe9, 0x3e, 0xfb, 0x19,
-	0x00, 0x00, 0xff, 0xff, 0xce, 0xe9, 0xc8, 0x20, 0x89, 0x05, 0x00, 0x00,
+	// 789 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x5d, 0x6f, 0xe3, 0x44,
+	0x14, 0x5d, 0xc7, 0xfd, 0x48, 0x6e, 0x9c, 0xd4, 0x1d, 0x40, 0x8a, 0x0a, 0xd5, 0x66, 0x83, 0x58,
+	0x45, 0xac, 0xe4, 0x54, 0x41, 0xf0, 0xc4, 0x4b, 0x9b, 0x74, 0xc5, 0x0a, 0xda, 0x5a, 0x4e, 0x00,
+	0x69, 0x25, 0x64, 0x4d, 0xec, 0x9b, 0x30, 0xaa, 0x3d, 0x63, 0xcd, 0x0c, 0x15, 0xf9, 0x4b, 0x3c,
+	0xf3, 0x13, 0x78, 0xe0, 0x67, 0xa1, 0x19, 0x7f, 0x34, 0x94, 0xaa, 0x50, 0xf1, 0x36, 0xbe, 0xf7,
+	0xdc, 0x33, 0xf7, 0xdc, 0x73, 0xc7, 0x10, 0xdc, 0x4d, 0x25, 0xdd, 0x06, 0x89, 0xc8, 0x27, 0x89,
+	0x90, 0x38, 0xd1, 0x92, 0x72, 0x55, 0x08, 0xa9, 0x27, 0x8c, 0x6b, 0x94, 0x1c, 0xf5, 0x24, 0x11,
+	0x7c, 0xcd, 0x36, 0x41, 0x21, 0x85, 0x16, 0xe4, 0xb4, 0xc6, 0x4b, 0x0c, 0x1a, 0x6c, 0x50, 0x63,
+	0x4f, 0xce, 0x1e, 0xd0, 0x25, 0x22, 0xcf, 0x05, 0x9f, 0x28, 0x94, 0x8c, 0x66, 0x13, 0xbd, 0x2d,
+	0x30, 0x8d, 0x73, 0x54, 0x8a, 0x6e, 0xb0, 0x24, 0x3c, 0x79, 0xf3, 0x78, 0x85, 0xb9, 0x38, 0x45,
+	0xa5, 0x19, 0xa7, 0x9a, 0x09, 0x5e, 0x82, 0x47, 0x7f, 0x3a, 0x70, 0xb4, 0xac, 0x6f, 0x9d, 0xd9,
+	0xbe, 0xc8, 0x77, 0xd0, 0xb6, 0xc9, 0x44, 0x64, 0x03, 0x67, 0xe8, 0x8c, 0xfb, 0xd3, 0xb3, 0xe0,
+	0xc9, 0x26, 0x83, 0x86, 0x21, 0xac, 0xea, 0xa2, 0x86, 0x81, 0x7c, 0x0a, 0xbd, 0xfa, 0x1c, 0x73,
+	0x9a, 0xe3, 0xc0, 0x1d, 0x3a, 0xe3, 0x4e, 0xe4, 0xd5, 0xc1, 0x6b, 0x9a, 0x23, 0xb9, 0x80, 0xb6,
+	0x42, 0xad, 0x19, 0xdf, 0xa8, 0x41, 0x6b, 0xe8, 0x8c, 0xbb, 0xd3, 0xd7, 0xbb, 0x57, 0x96, 0x12,
+	0x82, 0x52, 0x74, 0xb0, 0x34, 0xa2, 0xaf, 0x4a, 0xcd, 0x51, 0x53, 0x37, 0xfa, 0xdd, 0x05, 0x6f,
+	0xa1, 0x25, 0xd2, 0xbc, 0xd2, 0x11, 0xfe, 0x7f, 0x1d, 0x17, 0xad, 0x81, 0xf3, 0x94, 0x96, 0xfd,
+	0x47, 0xb4, 0xfc, 0x04, 0xa4, 0xa1, 0x8e, 0x77, 0x54, 0xb9, 0xe3, 0xee, 0x34, 0xf8, 0xaf, 0x0d,
+	0x94, 0x12, 0xa2, 0xe3, 0x06, 0xb3, 0xa8, 0x88, 0x4c, 0x0f, 0x0a, 0x93, 0x5f, 0x24, 0xd3, 0xdb,
+	0xd8, 0xd8, 0x5f, 0xcf, 0xb3, 0x0e, 0x9a, 0xe9, 0x90, 0x05, 0x1c, 0x37, 0xa0, 0xa6, 0x85, 0xbd,
+	0xa1, 0xfb, 0x8c, 0xc1, 0xfa, 0x35, 0x41, 0x73, 0xf3, 0x12, 0x8e, 0x94, 0x48, 0x6e, 0x71, 0x47,
+	0xd5, 0x81, 0xf5, 0xea, 0xcd, 0xbf, 0xa8, 0x5a, 0xd8, 0xaa, 0x4a, 0x52, 0xbf, 0xe4, 0xa8, 0x59,
+	0x47, 0x2f, 0xa1, 0x1b, 0x4a, 0xf1, 0xeb, 0xb6, 0x32, 0xcd, 0x07, 0x57, 0xd3, 0x8d, 0xf5, 0xab,
+	0x13, 0x99, 0xe3, 0xe8, 0x8f, 0x7d, 0xf0, 0x76, 0x19, 0x08, 0x81, 0xbd, 0x9c, 0xca, 0x5b, 0x8b,
+	0xd9, 0x8f, 0xec, 0x99, 0x5c, 0x83, 0xab, 0xd7, 0xc2, 0xee, 0x4e, 0x7f, 0xfa, 0xf5, 0x33, 0xfa,
+	0x09, 0x96, 0xb3, 0xf0, 0x2d, 0x55, 0xfa, 0xa6, 0x40, 0xbe, 0xd0, 0x54, 0x63, 0x64, 0x88, 0xc8,
+	0x35, 0x1c, 0xe8, 0xc2, 0xb4, 0x65, 0xc7, 0xdb, 0x9f, 0x7e, 0xf5, 0x2c, 0x4a, 0x2b, 0xe8, 0x4a,
+	0xa4, 0x18, 0x55, 0x2c, 0xe4, 0x1c, 0x4e, 0x25, 0x26, 0xc8, 0xee, 0x30, 0x16, 0x92, 0x6d, 0x18,
+	0xa7, 0x59, 0x6c, 0x5e, 0x63, 0x4c, 0xd3, 0x54, 0xa2, 0x32, 0xe6, 0x38, 0xe3, 0x76, 0x74, 0x52,
+	0x81, 0x6e, 0x2a, 0xcc, 0x1c, 0x95, 0x3e, 0x2f, 0x11, 0xe4, 0x15, 0x78, 0x2b, 0xc6, 0xd3, 0xa6,
+	0xc2, 0xec, 0x9e, 0x17, 0x75, 0x4d, 0xac, 0x86, 0x7c, 0x0c, 0x1d, 0x0b, 0x31, 0xbd, 0x59, 0x6f,
+	0x7a, 0x51, 0xdb, 0x04, 0x42, 0x21, 0x35, 0x79, 0x0d, 0x47, 0x8a, 0xb3, 0x58, 0xa1, 0xbc, 0x43,
+	0x59, 0xae, 0xef, 0xe1, 0xd0, 0x1d, 0x77, 0xa2, 0x9e, 0xe2, 0x6c, 0x61, 0xa3, 0xd5, 0x5b, 0xf4,
+	0x0c, 0x6e, 0x4d, 0xb3, 0x6c, 0x45, 0x93, 0xdb, 0x41, 0xdb, 0x7a, 0xfc, 0xf2, 0x91, 0xb5, 0x31,
+	0xc2, 0x2f, 0x79, 0x5a, 0x08, 0xc6, 0x75, 0xd4, 0x55, 0x9c, 0xbd, 0xad, 0x6a, 0xc8, 0x14, 0x3e,
+	0xa2, 0x49, 0x82, 0x85, 0x8e, 0xad, 0xfc, 0xb8, 0x79, 0x87, 0x1d, 0x2b, 0xf3, 0x83, 0x32, 0x69,
+	0xc7, 0x54, 0x3f, 0x35, 0xf2, 0x19, 0xf4, 0x1f, 0x80, 0xc1, 0x2a, 0xe8, 0x15, 0x7f, 0x83, 0x9d,
+	0xc1, 0x87, 0x3f, 0xd3, 0xa2, 0xd8, 0xc6, 0xb8, 0xc5, 0x15, 0xcd, 0x32, 0x15, 0xa7, 0x98, 0xd1,
+	0xed, 0xa0, 0x6b, 0xc1, 0xc4, 0xe6, 0x2e, 0xab, 0xd4, 0xdc, 0x64, 0xc8, 0x27, 0xd0, 0xb1, 0x3e,
+	0xad, 0x69, 0x82, 0x03, 0xcf, 0x2e, 0xd6, 0x7d, 0x60, 0xf4, 0x25, 0xf8, 0x0f, 0x57, 0x80, 0xb4,
+	0x61, 0xef, 0x5c, 0xbd, 0x53, 0xfe, 0x0b, 0x02, 0x70, 0x70, 0xc9, 0xe9, 0x2a, 0x43, 0xdf, 0x21,
+	0x5d, 0x38, 0x9c, 0x33, 0x65, 0x3f, 0x5a, 0xa3, 0x09, 0xc0, 0xbd, 0xcd, 0xe4, 0x10, 0xdc, 0x9b,
+	0xf5, 0xba, 0xc4, 0x97, 0x61, 0xdf, 0x21, 0x1e, 0xb4, 0x23, 0x4c, 0x99, 0xc4, 0x44, 0xfb, 0xad,
+	0xcf, 0xdf, 0xc3, 0xf1, 0x3f, 0x7e, 0x2f, 0xa6, 0x6e, 0x39, 0x0b, 0xfd, 0x17, 0xe6, 0xf0, 0xfd,
+	0x3c, 0xf4, 0x1d, 0x73, 0xf5, 0xd5, 0xb7, 0xb3, 0xd0, 0x6f, 0x91, 0x1e, 0x74, 0x7e, 0xc4, 0x55,
+	0xb9, 0x58, 0xbe, 0x6b, 0x12, 0xdf, 0x2c, 0x97, 0xa1, 0xbf, 0x47, 0x7c, 0xf0, 0xe6, 0x22, 0xa7,
+	0x8c, 0x57, 0xb9, 0xfd, 0x8b, 0x1b, 0x78, 0x95, 0x88, 0xfc, 0xe9, 0x15, 0x0d, 0x9d, 0xf7, 0xed,
+	0xfa, 0xfc, 0x5b, 0xeb, 0xf4, 0x87, 0x69, 0x44, 0xb7, 0xc1, 0xcc, 0x60, 0x9b, 0xb6, 0x82, 0x77,
+	0x55, 0x7e, 0x75, 0x60, 0x3d, 0xf8, 0xe2, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf6, 0xc3, 0x0f,
+	0x9c, 0xcd, 0x06, 0x00, 0x00,
 }
@@ -62,11 +62,51 @@ func Dial(ctx context.Context, dest net.Destination, streamSettings *MemoryStrea
 	return nil, newError("unknown network ", dest.Network)
 }
 
+type systemDialerKey int
+
+const systemDialerKeyValue systemDialerKey = 0
+
+// ContextWithSystemDialer returns a context in which DialSystem opens raw connections with the given SystemDialer,
+// instead of the global one. The transports, such as TLS and WebSocket, then run on top of these connections.
+func ContextWithSystemDialer(ctx context.Context, dialer SystemDialer) context.Context {
+	return context.WithValue(ctx, systemDialerKeyValue, dialer)
+}
+
+// SystemDialerFromContext returns the SystemDialer set by ContextWithSystemDialer, or nil if not set.
+func SystemDialerFromContext(ctx context.Context) SystemDialer {
+	if dialer, ok := ctx.Value(systemDialerKeyValue).(SystemDialer); ok {
+		return dialer
+	}
+	return nil
+}
+
 // DialSystem calls system dialer to create a network connection.
 func DialSystem(ctx context.Context, dest net.Destination, sockopt *SocketConfig) (net.Conn, error) {
+	if dialer := SystemDialerFromContext(ctx); dialer != nil {
+		// The dialer may dial again, e.g., through another outbound. It uses the global dialer unless it says otherwise.
+		return dialer.Dial(ContextWithSystemDialer(ctx, nil), nil, dest, sockopt)
+	}
+
 	var src net.Address
 	if outbound := session.OutboundFromContext(ctx); outbound != nil {
 		src = outbound.Gateway
 	}
 	return effectiveSystemDialer.Dial(ctx, src, dest, sockopt)
 }
+
+// DialSystemPacket opens a packet connection for sending packets to the given destination. If the context carries a
+// SystemDialer, the connection is opened through it, and an error is returned if it can't carry packets.
+func DialSystemPacket(ctx context.Context, dest net.Destination, sockopt *SocketConfig) (net.PacketConn, error) {
+	if dialer := SystemDialerFromContext(ctx); dialer != nil {
+		packetDialer, ok := dialer.(PacketDialer)
+		if !ok {
+			return nil, newError("dialer in context doesn't support packet connections to ", dest)
+		}
+		return packetDialer.DialPacket(ContextWithSystemDialer(ctx, nil), dest, sockopt)
+	}
+
+	return ListenSystemPacket(ctx, &net.UDPAddr{
+		IP:   []byte{0, 0, 0, 0},
+		Port: 0,
+	}, sockopt)
+}
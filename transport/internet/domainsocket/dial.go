@@ -25,7 +25,11 @@ func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.Me
 	}
 
 	if config := tls.ConfigFromStreamSettings(streamSettings); config != nil {
-		return tls.Client(conn, config.GetTLSConfig(tls.WithDestination(dest))), nil
+		tlsConfig := config.GetTLSConfig(tls.WithDestination(dest))
+		if fingerprint := tls.GetFingerprint(config.Fingerprint); fingerprint != nil {
+			return tls.UClient(conn, tlsConfig, fingerprint), nil
+		}
+		return tls.Client(conn, tlsConfig), nil
 	}
 
 	return conn, nil
@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"v2ray.com/core/common"
+	"v2ray.com/core/transport/internet"
+)
+
+const protocolName = "grpc"
+
+func (c *Config) getServiceName() string {
+	if len(c.ServiceName) == 0 {
+		return "v2ray.transport.Tunnel"
+	}
+	return c.ServiceName
+}
+
+func (c *Config) getPath() string {
+	return "/" + c.getServiceName() + "/Tun"
+}
+
+func init() {
+	common.Must(internet.RegisterProtocolConfigCreatorByName(protocolName, func() interface{} {
+		return new(Config)
+	}))
+}
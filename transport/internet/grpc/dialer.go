@@ -0,0 +1,340 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/task"
+	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/tls"
+)
+
+const handshakeTimeout = time.Second * 8
+
+type handshaker interface {
+	Handshake() error
+}
+
+func handshake(conn net.Conn) error {
+	h, ok := conn.(handshaker)
+	if !ok {
+		return nil
+	}
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+	if err := h.Handshake(); err != nil {
+		return err
+	}
+	return conn.SetDeadline(time.Time{})
+}
+
+// clientConns is a pool of HTTP/2 connections. gRPC streams to the same destination are multiplexed on one
+// connection, until it can't take more streams.
+type clientConns struct {
+	access    sync.Mutex
+	transport *http2.Transport
+	conns     map[connKey][]*http2.ClientConn
+	dialing   map[connKey]*pendingConn
+	cleanup   *task.Periodic
+}
+
+// pendingConn is a connection being dialed. Streams to the same destination wait for it, instead of dialing their own.
+type pendingConn struct {
+	done chan struct{}
+	err  error
+}
+
+// connKey identifies pooled connections. Connections opened with different dialers are not shared.
+type connKey struct {
+	dest   net.Destination
+	dialer internet.SystemDialer
+}
+
+func isActive(cc *http2.ClientConn) bool {
+	state := cc.State()
+	return !state.Closed && !state.Closing
+}
+
+func removeInactiveConns(conns []*http2.ClientConn) []*http2.ClientConn {
+	activeConns := make([]*http2.ClientConn, 0, len(conns))
+	for _, cc := range conns {
+		if isActive(cc) {
+			activeConns = append(activeConns, cc)
+			continue
+		}
+		if err := cc.Close(); err != nil {
+			newError("failed to close connection").Base(err).WriteToLog()
+		}
+	}
+
+	if len(activeConns) < len(conns) {
+		return activeConns
+	}
+
+	return conns
+}
+
+func (c *clientConns) cleanConns() error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if len(c.conns) == 0 {
+		return nil
+	}
+
+	newConnMap := make(map[connKey][]*http2.ClientConn)
+
+	for key, conns := range c.conns {
+		conns = removeInactiveConns(conns)
+		if len(conns) > 0 {
+			newConnMap[key] = conns
+		}
+	}
+
+	c.conns = newConnMap
+	return nil
+}
+
+func dialConn(key connKey, tlsSettings *tls.Config, sockopt *internet.SocketConfig) (net.Conn, error) {
+	dest := key.dest
+	// Connections are shared by streams, so they don't live within the context of any stream.
+	ctx := internet.ContextWithSystemDialer(context.Background(), key.dialer)
+	conn, err := internet.DialSystem(ctx, dest, sockopt)
+	if err != nil {
+		return nil, err
+	}
+	if tlsSettings == nil {
+		return conn, nil
+	}
+
+	tlsConfig := tlsSettings.GetTLSConfig(tls.WithDestination(dest), tls.WithNextProto("h2"))
+	if fingerprint := tls.GetFingerprint(tlsSettings.Fingerprint); fingerprint != nil {
+		conn = tls.UClient(conn, tlsConfig, fingerprint)
+	} else {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	if err := handshake(conn); err != nil {
+		conn.Close() // nolint: errcheck
+		return nil, newError("failed to complete TLS handshake").Base(err)
+	}
+	return conn, nil
+}
+
+func (c *clientConns) newConn(key connKey, tlsSettings *tls.Config, sockopt *internet.SocketConfig) (*http2.ClientConn, error) {
+	conn, err := dialConn(key, tlsSettings, sockopt)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := c.transport.NewClientConn(conn)
+	if err != nil {
+		conn.Close() // nolint: errcheck
+		return nil, err
+	}
+	return cc, nil
+}
+
+// getConn returns a pooled connection that can take a new stream, or dials a new one. The pool is not locked while
+// dialing, so that a slow destination doesn't hold up streams to others.
+func (c *clientConns) getConn(ctx context.Context, key connKey, tlsSettings *tls.Config, sockopt *internet.SocketConfig) (*http2.ClientConn, error) {
+	for {
+		c.access.Lock()
+		conns := removeInactiveConns(c.conns[key])
+		if len(conns) > 0 {
+			c.conns[key] = conns
+		} else {
+			delete(c.conns, key)
+		}
+		for _, cc := range conns {
+			if cc.CanTakeNewRequest() {
+				c.access.Unlock()
+				return cc, nil
+			}
+		}
+
+		if pending, found := c.dialing[key]; found {
+			c.access.Unlock()
+			select {
+			case <-pending.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if pending.err != nil {
+				return nil, pending.err
+			}
+			// The new connection may be taken by other streams already.
+			continue
+		}
+
+		pending := &pendingConn{done: make(chan struct{})}
+		c.dialing[key] = pending
+		c.access.Unlock()
+
+		cc, err := c.newConn(key, tlsSettings, sockopt)
+
+		c.access.Lock()
+		delete(c.dialing, key)
+		if err == nil {
+			c.conns[key] = append(c.conns[key], cc)
+		}
+		pending.err = err
+		close(pending.done)
+		c.access.Unlock()
+
+		return cc, err
+	}
+}
+
+var client clientConns
+
+func init() {
+	client.transport = &http2.Transport{
+		ReadIdleTimeout: time.Second * 30,
+	}
+	client.conns = make(map[connKey][]*http2.ClientConn)
+	client.dialing = make(map[connKey]*pendingConn)
+	client.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  client.cleanConns,
+	}
+	common.Must(client.cleanup.Start())
+}
+
+// Dial opens a gRPC stream to the given destination.
+func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig) (internet.Connection, error) {
+	newError("creating gRPC stream to ", dest).WriteToLog(session.ExportIDToError(ctx))
+
+	config := streamSettings.ProtocolSettings.(*Config)
+	tlsSettings := tls.ConfigFromStreamSettings(streamSettings)
+
+	key := connKey{dest: dest, dialer: internet.SystemDialerFromContext(ctx)}
+	cc, err := client.getConn(ctx, key, tlsSettings, streamSettings.SocketSettings)
+	if err != nil {
+		return nil, newError("failed to dial to ", dest).Base(err)
+	}
+
+	scheme := "http"
+	if tlsSettings != nil {
+		scheme = "https"
+	}
+	host := config.Host
+	if len(host) == 0 {
+		host = dest.NetAddr()
+	}
+
+	reader, writer := io.Pipe()
+	request := &http.Request{
+		Method: "POST",
+		Host:   host,
+		URL: &url.URL{
+			Scheme: scheme,
+			Host:   host,
+			Path:   config.getPath(),
+		},
+		Proto:      "HTTP/2",
+		ProtoMajor: 2,
+		ProtoMinor: 0,
+		Header: http.Header{
+			"Content-Type": {"application/grpc"},
+			"Te":           {"trailers"},
+			"User-Agent":   {"grpc-go/1.36.0"},
+		},
+		Body: reader,
+	}
+
+	response, err := roundTrip(ctx, cc, request)
+	if err != nil {
+		writer.Close() // nolint: errcheck
+		return nil, newError("failed to open gRPC stream to ", dest).Base(err)
+	}
+	if err := checkResponse(response); err != nil {
+		writer.Close()        // nolint: errcheck
+		response.Body.Close() // nolint: errcheck
+		return nil, err
+	}
+
+	return net.NewConnection(
+		net.ConnectionOutputMulti(newHunkReader(response.Body, func() error {
+			return checkStatus(response.Trailer)
+		})),
+		net.ConnectionInputMulti(newHunkWriter(writer, writer)),
+		net.ConnectionOnClose(response.Body),
+		net.ConnectionRemoteAddr(remoteAddr(dest)),
+	), nil
+}
+
+// roundTrip sends the request and waits for the response headers, until ctx is done or handshakeTimeout passes. The
+// stream lives on its own context afterwards, because HTTP/2 aborts the stream when the context of its request ends.
+func roundTrip(ctx context.Context, cc *http2.ClientConn, request *http.Request) (*http.Response, error) {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	timer := time.NewTimer(handshakeTimeout)
+	defer timer.Stop()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-timer.C:
+			cancel()
+		case <-done:
+		}
+	}()
+
+	response, err := cc.RoundTrip(request.WithContext(streamCtx))
+	if err == nil && streamCtx.Err() != nil {
+		// The stream is aborted just after the response headers arrive.
+		response.Body.Close() // nolint: errcheck
+		err = streamCtx.Err()
+	}
+	if err != nil {
+		cancel()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func remoteAddr(dest net.Destination) net.Addr {
+	addr := &net.TCPAddr{
+		IP:   []byte{0, 0, 0, 0},
+		Port: int(dest.Port),
+	}
+	if dest.Address.Family().IsIP() {
+		addr.IP = dest.Address.IP()
+	}
+	return addr
+}
+
+func checkStatus(header http.Header) error {
+	if status := header.Get("Grpc-Status"); len(status) > 0 && status != "0" {
+		return newError("gRPC stream ends with status ", status, ": ", header.Get("Grpc-Message"))
+	}
+	return nil
+}
+
+func checkResponse(response *http.Response) error {
+	if response.StatusCode != http.StatusOK {
+		return newError("unexpected status ", response.Status)
+	}
+	if !strings.HasPrefix(response.Header.Get("Content-Type"), "application/grpc") {
+		return newError("unexpected content type ", response.Header.Get("Content-Type"))
+	}
+	return checkStatus(response.Header)
+}
+
+func init() {
+	common.Must(internet.RegisterTransportDialer(protocolName, Dial))
+}
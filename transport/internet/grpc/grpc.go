@@ -0,0 +1,5 @@
+// Package grpc implements a transport that carries each connection as a bidirectional streaming gRPC call over
+// HTTP/2, so that it passes through gRPC-aware proxies and CDNs.
+package grpc
+
+//go:generate errorgen
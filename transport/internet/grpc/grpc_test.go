@@ -0,0 +1,230 @@
+package grpc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	gonet "net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/http2"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol/tls/cert"
+	"v2ray.com/core/testing/servers/tcp"
+	"v2ray.com/core/transport/internet"
+	. "v2ray.com/core/transport/internet/grpc"
+	"v2ray.com/core/transport/internet/tls"
+)
+
+func echo(conn internet.Connection) {
+	go func() {
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+}
+
+func testStreams(t *testing.T, streamSettings *internet.MemoryStreamConfig) {
+	port := tcp.PickPort()
+	listener, err := Listen(context.Background(), net.LocalHostIP, port, streamSettings, echo)
+	common.Must(err)
+	defer listener.Close()
+
+	dest := net.TCPDestination(net.LocalHostIP, port)
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			errs <- func() error {
+				conn, err := Dial(context.Background(), dest, streamSettings)
+				if err != nil {
+					return err
+				}
+				defer conn.Close()
+
+				payload := make([]byte, 64*1024)
+				common.Must2(rand.Read(payload))
+				go conn.Write(payload)
+
+				response := make([]byte, len(payload))
+				if _, err := io.ReadFull(conn, response); err != nil {
+					return err
+				}
+				if r := cmp.Diff(response, payload); r != "" {
+					return errors.New(r)
+				}
+				return nil
+			}()
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case err := <-errs:
+			if err != nil {
+				t.Error(err)
+			}
+		case <-time.After(time.Second * 10):
+			t.Fatal("timeout")
+		}
+	}
+}
+
+func TestCleartext(t *testing.T) {
+	testStreams(t, &internet.MemoryStreamConfig{
+		ProtocolName:     "grpc",
+		ProtocolSettings: &Config{ServiceName: "test.Service"},
+	})
+}
+
+func TestTLS(t *testing.T) {
+	testStreams(t, &internet.MemoryStreamConfig{
+		ProtocolName:     "grpc",
+		ProtocolSettings: &Config{},
+		SecurityType:     "tls",
+		SecuritySettings: &tls.Config{
+			ServerName:    "www.v2ray.com",
+			AllowInsecure: true,
+			Certificate:   []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.CommonName("www.v2ray.com")))},
+		},
+	})
+}
+
+func TestUnknownService(t *testing.T) {
+	port := tcp.PickPort()
+	listener, err := Listen(context.Background(), net.LocalHostIP, port, &internet.MemoryStreamConfig{
+		ProtocolName:     "grpc",
+		ProtocolSettings: &Config{ServiceName: "server.Service"},
+	}, echo)
+	common.Must(err)
+	defer listener.Close()
+
+	if _, err := Dial(context.Background(), net.TCPDestination(net.LocalHostIP, port), &internet.MemoryStreamConfig{
+		ProtocolName:     "grpc",
+		ProtocolSettings: &Config{ServiceName: "client.Service"},
+	}); err == nil {
+		t.Error("expect error for unknown service")
+	}
+}
+
+func TestSlowDestination(t *testing.T) {
+	// The slow destination accepts connections, but never completes TLS handshakes.
+	slowListener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer slowListener.Close()
+	go func() {
+		for {
+			conn, err := slowListener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	tlsSettings := &internet.MemoryStreamConfig{
+		ProtocolName:     "grpc",
+		ProtocolSettings: &Config{},
+		SecurityType:     "tls",
+		SecuritySettings: &tls.Config{
+			ServerName:    "www.v2ray.com",
+			AllowInsecure: true,
+			Certificate:   []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.CommonName("www.v2ray.com")))},
+		},
+	}
+	go Dial(context.Background(), net.DestinationFromAddr(slowListener.Addr()), tlsSettings) // nolint: errcheck
+	time.Sleep(time.Millisecond * 100)
+
+	port := tcp.PickPort()
+	listener, err := Listen(context.Background(), net.LocalHostIP, port, tlsSettings, echo)
+	common.Must(err)
+	defer listener.Close()
+
+	dialed := make(chan error, 1)
+	go func() {
+		conn, err := Dial(context.Background(), net.TCPDestination(net.LocalHostIP, port), tlsSettings)
+		if err == nil {
+			conn.Close()
+		}
+		dialed <- err
+	}()
+	select {
+	case err := <-dialed:
+		common.Must(err)
+	case <-time.After(time.Second * 4):
+		t.Fatal("dialing is blocked by a slow destination")
+	}
+}
+
+func TestDialCancel(t *testing.T) {
+	// The server accepts HTTP/2 streams, but never responds.
+	silentListener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer silentListener.Close()
+
+	block := make(chan struct{})
+	defer close(block)
+	go func() {
+		for {
+			conn, err := silentListener.Accept()
+			if err != nil {
+				return
+			}
+			go (&http2.Server{}).ServeConn(conn, &http2.ServeConnOpts{
+				Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					<-block
+				}),
+			})
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+	errs := make(chan error, 1)
+	go func() {
+		_, err := Dial(ctx, net.DestinationFromAddr(silentListener.Addr()), &internet.MemoryStreamConfig{
+			ProtocolName:     "grpc",
+			ProtocolSettings: &Config{ServiceName: "test.Service"},
+		})
+		errs <- err
+	}()
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("expect error when the context ends before the response")
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("dialing is not cancelled with the context")
+	}
+}
+
+func TestStreamOutlivesDialContext(t *testing.T) {
+	streamSettings := &internet.MemoryStreamConfig{
+		ProtocolName:     "grpc",
+		ProtocolSettings: &Config{ServiceName: "test.Service"},
+	}
+	port := tcp.PickPort()
+	listener, err := Listen(context.Background(), net.LocalHostIP, port, streamSettings, echo)
+	common.Must(err)
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := Dial(ctx, net.TCPDestination(net.LocalHostIP, port), streamSettings)
+	common.Must(err)
+	defer conn.Close()
+	cancel()
+
+	common.Must2(conn.Write([]byte("test")))
+	response := make([]byte, 4)
+	common.Must(conn.SetReadDeadline(time.Now().Add(time.Second * 5)))
+	if _, err := io.ReadFull(conn, response); err != nil {
+		t.Fatal("stream is broken after the dial context ends: ", err)
+	}
+	if string(response) != "test" {
+		t.Error("unexpected response: ", string(response))
+	}
+}
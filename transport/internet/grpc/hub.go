@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal/done"
+	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/tls"
+)
+
+// Listener is an internet.Listener that accepts gRPC streams.
+type Listener struct {
+	listener  net.Listener
+	tlsConfig *gotls.Config
+	server    *http2.Server
+	config    *Config
+	handler   internet.ConnHandler
+	done      *done.Instance
+}
+
+// Addr implements net.Listener.
+func (l *Listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Close implements net.Listener.
+func (l *Listener) Close() error {
+	common.Must(l.done.Close())
+	return l.listener.Close()
+}
+
+// writeStatus responds to the request with a gRPC error in trailers-only form.
+func writeStatus(writer http.ResponseWriter, code int, message string) {
+	writer.Header().Set("Content-Type", "application/grpc")
+	writer.Header().Set("Grpc-Status", strconv.Itoa(code))
+	writer.Header().Set("Grpc-Message", message)
+	writer.WriteHeader(http.StatusOK)
+}
+
+// ServeHTTP implements http.Handler.
+func (l *Listener) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if !strings.HasPrefix(request.Header.Get("Content-Type"), "application/grpc") {
+		writer.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	if request.Method != "POST" || request.URL.Path != l.config.getPath() {
+		writeStatus(writer, 12, "unknown method") // UNIMPLEMENTED
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/grpc")
+	writer.WriteHeader(http.StatusOK)
+	if f, ok := writer.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	var remoteAddr net.Addr = &net.TCPAddr{
+		IP:   []byte{0, 0, 0, 0},
+		Port: 0,
+	}
+	if dest, err := net.ParseDestination("tcp:" + request.RemoteAddr); err == nil && dest.Address.Family().IsIP() {
+		remoteAddr = &net.TCPAddr{
+			IP:   dest.Address.IP(),
+			Port: int(dest.Port),
+		}
+	}
+
+	done := done.New()
+	hunkWriter := newHunkWriter(writer, done)
+	conn := net.NewConnection(
+		net.ConnectionOutputMulti(newHunkReader(request.Body, nil)),
+		net.ConnectionInputMulti(hunkWriter),
+		net.ConnectionOnClose(done),
+		net.ConnectionLocalAddr(l.Addr()),
+		net.ConnectionRemoteAddr(remoteAddr),
+	)
+	l.handler(tls.WithVerifiedUser(conn, request.TLS))
+
+	select {
+	case <-done.Wait():
+	case <-request.Context().Done():
+		conn.Close() // nolint: errcheck
+	}
+
+	// The writer is closed, so trailers are written only by this goroutine.
+	common.Must(hunkWriter.Close())
+	writer.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+}
+
+func (l *Listener) serveConn(conn net.Conn) {
+	if l.tlsConfig != nil {
+		tlsConn := tls.Server(conn, l.tlsConfig)
+		if err := handshake(tlsConn); err != nil {
+			newError("failed to complete TLS handshake with ", conn.RemoteAddr()).Base(err).AtInfo().WriteToLog()
+			conn.Close() // nolint: errcheck
+			return
+		}
+		conn = tlsConn
+	}
+
+	l.server.ServeConn(conn, &http2.ServeConnOpts{
+		Handler: l,
+	})
+}
+
+func (l *Listener) keepAccepting() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			if l.done.Done() || strings.Contains(err.Error(), "closed") {
+				break
+			}
+			newError("failed to accept raw connections").Base(err).AtWarning().WriteToLog()
+			time.Sleep(time.Millisecond * 500)
+			continue
+		}
+		go l.serveConn(conn)
+	}
+}
+
+// Listen creates a Listener for gRPC streams. Without TLS, HTTP/2 is served in cleartext with prior knowledge.
+func Listen(ctx context.Context, address net.Address, port net.Port, streamSettings *internet.MemoryStreamConfig, handler internet.ConnHandler) (internet.Listener, error) {
+	listener, err := internet.ListenSystem(ctx, &net.TCPAddr{
+		IP:   address.IP(),
+		Port: int(port),
+	}, streamSettings.SocketSettings)
+	if err != nil {
+		return nil, newError("failed to listen on ", address, ":", port).Base(err)
+	}
+	newError("listening gRPC on ", address, ":", port).WriteToLog(session.ExportIDToError(ctx))
+
+	l := &Listener{
+		listener: listener,
+		server: &http2.Server{
+			IdleTimeout: time.Minute * 5,
+		},
+		config:  streamSettings.ProtocolSettings.(*Config),
+		handler: handler,
+		done:    done.New(),
+	}
+	if config := tls.ConfigFromStreamSettings(streamSettings); config != nil {
+		l.tlsConfig = config.GetTLSConfig(tls.WithNextProto("h2"))
+	}
+
+	go l.keepAccepting()
+	return l, nil
+}
+
+func init() {
+	common.Must(internet.RegisterTransportListener(protocolName, Listen))
+}
@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"sync"
+
+	"v2ray.com/core/common/buf"
+)
+
+// Each chunk of payload is sent as a gRPC message of protobuf "message Hunk { bytes data = 1; }", which is prefixed by
+// 1 byte of compression flag and 4 bytes of message length.
+const (
+	messageHeaderSize = 5
+	maxMessageSize    = 4 * 1024 * 1024
+	hunkDataTag       = 0x0A // field 1, length delimited
+)
+
+// hunkReader reads payload from gRPC messages.
+type hunkReader struct {
+	reader *bufio.Reader
+	// status checks gRPC status in trailers when the stream ends. May be nil.
+	status func() error
+	// remaining is the size of data not yet read in current message.
+	remaining int32
+}
+
+func newHunkReader(reader io.Reader, status func() error) *hunkReader {
+	return &hunkReader{
+		reader: bufio.NewReaderSize(reader, buf.Size),
+		status: status,
+	}
+}
+
+func (r *hunkReader) readMessageHeader() error {
+	var header [messageHeaderSize]byte
+	if _, err := io.ReadFull(r.reader, header[:]); err != nil {
+		if err == io.EOF && r.status != nil {
+			if err := r.status(); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+	if header[0] != 0 {
+		return newError("compressed gRPC message is not supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxMessageSize {
+		return newError("gRPC message too large: ", size)
+	}
+	if size == 0 {
+		return nil
+	}
+
+	tag, err := r.reader.ReadByte()
+	if err != nil {
+		return newError("failed to read gRPC message").Base(err)
+	}
+	if tag != hunkDataTag {
+		return newError("unexpected field in gRPC message: ", tag)
+	}
+	dataSize, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return newError("failed to read gRPC message").Base(err)
+	}
+	if dataSize+1+uint64(uvarintSize(dataSize)) != uint64(size) {
+		return newError("invalid size of data in gRPC message: ", dataSize)
+	}
+	r.remaining = int32(dataSize)
+	return nil
+}
+
+// ReadMultiBuffer implements buf.Reader.
+func (r *hunkReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	for r.remaining == 0 {
+		if err := r.readMessageHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	var mb buf.MultiBuffer
+	for r.remaining > 0 {
+		size := r.remaining
+		if size > buf.Size {
+			size = buf.Size
+		}
+		b := buf.New()
+		if _, err := b.ReadFullFrom(r.reader, size); err != nil {
+			b.Release()
+			buf.ReleaseMulti(mb)
+			return nil, newError("failed to read gRPC message").Base(err)
+		}
+		r.remaining -= size
+		mb = append(mb, b)
+		if r.reader.Buffered() == 0 {
+			break
+		}
+	}
+	return mb, nil
+}
+
+func uvarintSize(v uint64) int {
+	var b [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(b[:], v)
+}
+
+// hunkWriter writes payload as gRPC messages.
+type hunkWriter struct {
+	access sync.Mutex
+	writer io.Writer
+	closer io.Closer
+	closed bool
+}
+
+func newHunkWriter(writer io.Writer, closer io.Closer) *hunkWriter {
+	return &hunkWriter{
+		writer: writer,
+		closer: closer,
+	}
+}
+
+func (w *hunkWriter) writeMessage(data []byte) error {
+	var header [messageHeaderSize + 1 + binary.MaxVarintLen64]byte
+	n := messageHeaderSize
+	header[n] = hunkDataTag
+	n++
+	n += binary.PutUvarint(header[n:], uint64(len(data)))
+	binary.BigEndian.PutUint32(header[1:], uint32(n-messageHeaderSize+len(data)))
+
+	if err := buf.WriteAllBytes(w.writer, header[:n]); err != nil {
+		return err
+	}
+	return buf.WriteAllBytes(w.writer, data)
+}
+
+// WriteMultiBuffer implements buf.Writer. Each buffer is sent in one gRPC message.
+func (w *hunkWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	defer buf.ReleaseMulti(mb)
+
+	w.access.Lock()
+	defer w.access.Unlock()
+
+	if w.closed {
+		return io.ErrClosedPipe
+	}
+
+	for _, b := range mb {
+		if b.IsEmpty() {
+			continue
+		}
+		if err := w.writeMessage(b.Bytes()); err != nil {
+			return err
+		}
+	}
+	if f, ok := w.writer.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Close implements io.Closer. The writer is unusable after Close.
+func (w *hunkWriter) Close() error {
+	w.access.Lock()
+	defer w.access.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.closer != nil {
+		return w.closer.Close()
+	}
+	return nil
+}
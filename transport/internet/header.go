@@ -3,6 +3,8 @@ package internet
 import (
 	"context"
 	"net"
+	"sync"
+	"time"
 
 	"v2ray.com/core/common"
 )
@@ -23,6 +25,159 @@ func CreatePacketHeader(config interface{}) (PacketHeader, error) {
 	return nil, newError("not a packet header")
 }
 
+// StatefulPacketHeader is implemented by PacketHeaders that look different in packets sent by servers, keep state
+// for each peer, or depend on the packets received from the peer and the size of the payload.
+type StatefulPacketHeader interface {
+	PacketHeader
+	// ServerHeader returns the PacketHeader for packets sent by servers. It must have the same size.
+	ServerHeader() PacketHeader
+	// NewSession returns a PacketHeader for the packets exchanged with one peer.
+	NewSession() PacketHeader
+	// Receive is called with each packet received from the peer, which starts with the header of the peer.
+	Receive(packet []byte)
+	// SerializePacket writes the header for a payload of the given size, and returns the size of padding to append
+	// after the payload.
+	SerializePacket(b []byte, payloadSize int) int
+	// PayloadSize returns the size of the payload in the given packet, which starts with the header. It returns -1
+	// if the packet is invalid.
+	PayloadSize(packet []byte) int
+}
+
+// CreateServerPacketHeader creates a PacketHeader for packets sent by servers.
+func CreateServerPacketHeader(config interface{}) (PacketHeader, error) {
+	header, err := CreatePacketHeader(config)
+	if err != nil {
+		return nil, err
+	}
+	if h, ok := header.(StatefulPacketHeader); ok {
+		return h.ServerHeader(), nil
+	}
+	return header, nil
+}
+
+// ReceivePacketHeader passes a packet received from the peer to the given header.
+func ReceivePacketHeader(header PacketHeader, packet []byte) {
+	if h, ok := header.(StatefulPacketHeader); ok {
+		h.Receive(packet)
+	}
+}
+
+// SerializePacketHeader writes the header for a payload of the given size, and returns the size of padding to append
+// after the payload.
+func SerializePacketHeader(header PacketHeader, b []byte, payloadSize int) int {
+	if h, ok := header.(StatefulPacketHeader); ok {
+		return h.SerializePacket(b, payloadSize)
+	}
+	header.Serialize(b)
+	return 0
+}
+
+// PacketPayload returns the payload in a packet that starts with the given header, or nil if the packet is invalid.
+func PacketPayload(header PacketHeader, packet []byte) []byte {
+	size := int(header.Size())
+	if len(packet) <= size {
+		return nil
+	}
+	if h, ok := header.(StatefulPacketHeader); ok {
+		n := h.PayloadSize(packet)
+		if n <= 0 || size+n > len(packet) {
+			return nil
+		}
+		return packet[size : size+n]
+	}
+	return packet[size:]
+}
+
+const (
+	packetHeaderSessionTimeout = time.Minute * 5
+	// packetHeaderMaxSessions limits the memory taken by peers that send a few packets, possibly from spoofed
+	// addresses. The least recently used session is removed to make room for a new one.
+	packetHeaderMaxSessions = 1024
+)
+
+type packetHeaderSession struct {
+	header   PacketHeader
+	lastUsed time.Time
+}
+
+// PacketHeaderSessions keeps a PacketHeader for each peer, if the header implements StatefulPacketHeader. Sessions
+// without traffic for a while are removed, and there are at most packetHeaderMaxSessions of them.
+type PacketHeaderSessions struct {
+	access    sync.Mutex
+	header    PacketHeader
+	sessions  map[string]*packetHeaderSession
+	lastPurge time.Time
+}
+
+// NewPacketHeaderSessions creates a new PacketHeaderSessions for the given header, which may be nil.
+func NewPacketHeaderSessions(header PacketHeader) *PacketHeaderSessions {
+	s := &PacketHeaderSessions{
+		header:    header,
+		lastPurge: time.Now(),
+	}
+	if _, ok := header.(StatefulPacketHeader); ok {
+		s.sessions = make(map[string]*packetHeaderSession)
+	}
+	return s
+}
+
+// Get returns the PacketHeader for the packets exchanged with the given peer.
+func (s *PacketHeaderSessions) Get(peer string) PacketHeader {
+	if s.sessions == nil {
+		return s.header
+	}
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastPurge) > packetHeaderSessionTimeout {
+		s.purge(now)
+	}
+
+	session, found := s.sessions[peer]
+	if !found {
+		if len(s.sessions) >= packetHeaderMaxSessions {
+			s.purge(now)
+		}
+		if len(s.sessions) >= packetHeaderMaxSessions {
+			s.removeOldest()
+		}
+		session = &packetHeaderSession{
+			header: s.header.(StatefulPacketHeader).NewSession(),
+		}
+		s.sessions[peer] = session
+	}
+	session.lastUsed = now
+	return session.header
+}
+
+func (s *PacketHeaderSessions) purge(now time.Time) {
+	for key, session := range s.sessions {
+		if now.Sub(session.lastUsed) > packetHeaderSessionTimeout {
+			delete(s.sessions, key)
+		}
+	}
+	s.lastPurge = now
+}
+
+func (s *PacketHeaderSessions) removeOldest() {
+	var oldest string
+	var lastUsed time.Time
+	for key, session := range s.sessions {
+		if len(oldest) == 0 || session.lastUsed.Before(lastUsed) {
+			oldest = key
+			lastUsed = session.lastUsed
+		}
+	}
+	delete(s.sessions, oldest)
+}
+
+// Receive passes a packet received from the given peer to its PacketHeader.
+func (s *PacketHeaderSessions) Receive(peer string, packet []byte) {
+	ReceivePacketHeader(s.Get(peer), packet)
+}
+
 type ConnectionAuthenticator interface {
 	Client(net.Conn) net.Conn
 	Server(net.Conn) net.Conn
@@ -1,10 +1,12 @@
 package internet_test
 
 import (
+	"fmt"
 	"testing"
 
 	. "v2ray.com/core/transport/internet"
 	"v2ray.com/core/transport/internet/headers/noop"
+	"v2ray.com/core/transport/internet/headers/quic"
 	"v2ray.com/core/transport/internet/headers/srtp"
 	"v2ray.com/core/transport/internet/headers/utp"
 	. "v2ray.com/ext/assert"
@@ -25,3 +27,24 @@ func TestAllHeadersLoadable(t *testing.T) {
 	assert(err, IsNil)
 	assert(utp.Size(), Equals, int32(4))
 }
+
+func TestPacketHeaderSessionsLimit(t *testing.T) {
+	header, err := CreatePacketHeader(&quic.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := NewPacketHeaderSessions(header)
+
+	first := sessions.Get("10.0.0.1:1000")
+	if sessions.Get("10.0.0.1:1000") != first {
+		t.Error("session is not kept")
+	}
+
+	// Many other peers push the least recently used session out.
+	for i := 0; i < 2048; i++ {
+		sessions.Get(fmt.Sprint("10.0.", i/256, ".", i%256, ":2000"))
+	}
+	if sessions.Get("10.0.0.1:1000") == first {
+		t.Error("session is not removed")
+	}
+}
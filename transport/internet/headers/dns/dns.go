@@ -0,0 +1,152 @@
+package dns
+
+//go:generate errorgen
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/dice"
+	"v2ray.com/core/transport/internet"
+)
+
+const (
+	flagsQuery    = 0x0100 // recursion desired
+	flagsResponse = 0x8180 // response, recursion desired and available
+	typeA         = 1
+	classIN       = 1
+
+	maxPendingQueries = 16
+)
+
+// DNS is a PacketHeader that makes packets look like DNS queries, or responses when sent by servers. Responses
+// carry the IDs of the queries received from the peer, and no answers.
+type DNS struct {
+	question []byte
+	response bool
+
+	access  sync.Mutex
+	queries []uint16
+	lastID  uint16
+}
+
+// Size implements PacketHeader.
+func (d *DNS) Size() int32 {
+	return int32(12 + len(d.question))
+}
+
+// Serialize implements PacketHeader.
+func (d *DNS) Serialize(b []byte) {
+	if d.response {
+		binary.BigEndian.PutUint16(b, d.nextID())
+		binary.BigEndian.PutUint16(b[2:], flagsResponse)
+	} else {
+		binary.BigEndian.PutUint16(b, dice.RollUint16())
+		binary.BigEndian.PutUint16(b[2:], flagsQuery)
+	}
+	binary.BigEndian.PutUint16(b[4:], 1)  // QDCOUNT
+	binary.BigEndian.PutUint16(b[6:], 0)  // ANCOUNT
+	binary.BigEndian.PutUint16(b[8:], 0)  // NSCOUNT
+	binary.BigEndian.PutUint16(b[10:], 0) // ARCOUNT
+	copy(b[12:], d.question)
+}
+
+// SerializePacket implements internet.StatefulPacketHeader.
+func (d *DNS) SerializePacket(b []byte, payloadSize int) int {
+	d.Serialize(b)
+	return 0
+}
+
+// PayloadSize implements internet.StatefulPacketHeader.
+func (d *DNS) PayloadSize(packet []byte) int {
+	return len(packet) - int(d.Size())
+}
+
+// nextID returns the ID of the oldest query not yet responded to, or the ID of the last response.
+func (d *DNS) nextID() uint16 {
+	d.access.Lock()
+	defer d.access.Unlock()
+
+	if len(d.queries) > 0 {
+		d.lastID = d.queries[0]
+		d.queries = d.queries[1:]
+	}
+	return d.lastID
+}
+
+// Receive implements internet.StatefulPacketHeader.
+func (d *DNS) Receive(packet []byte) {
+	if !d.response || len(packet) < 12 || packet[2]&0x80 != 0 {
+		return
+	}
+
+	d.access.Lock()
+	defer d.access.Unlock()
+
+	if len(d.queries) >= maxPendingQueries {
+		d.queries = d.queries[1:]
+	}
+	d.queries = append(d.queries, binary.BigEndian.Uint16(packet))
+}
+
+// NewSession implements internet.StatefulPacketHeader.
+func (d *DNS) NewSession() internet.PacketHeader {
+	return &DNS{
+		question: d.question,
+		response: d.response,
+		lastID:   dice.RollUint16(),
+	}
+}
+
+// ServerHeader implements internet.StatefulPacketHeader.
+func (d *DNS) ServerHeader() internet.PacketHeader {
+	return &DNS{
+		question: d.question,
+		response: true,
+		lastID:   dice.RollUint16(),
+	}
+}
+
+// encodeQuestion encodes the question section for an A record of the given domain.
+func encodeQuestion(domain string) ([]byte, error) {
+	domain = strings.TrimSuffix(domain, ".")
+	if len(domain) == 0 || len(domain) > 253 {
+		return nil, newError("invalid domain: ", domain)
+	}
+
+	question := make([]byte, 0, len(domain)+6)
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, newError("invalid domain: ", domain)
+		}
+		question = append(question, byte(len(label)))
+		question = append(question, label...)
+	}
+	question = append(question, 0)
+	question = append(question, 0, typeA, 0, classIN)
+	return question, nil
+}
+
+// New returns a new DNS instance based on the given config.
+func New(ctx context.Context, config *Config) (*DNS, error) {
+	domain := config.Domain
+	if len(domain) == 0 {
+		domain = "www.example.com"
+	}
+	question, err := encodeQuestion(domain)
+	if err != nil {
+		return nil, err
+	}
+	return &DNS{
+		question: question,
+	}, nil
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
@@ -0,0 +1,90 @@
+package dns_test
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"v2ray.com/core/common"
+	"v2ray.com/core/transport/internet"
+	. "v2ray.com/core/transport/internet/headers/dns"
+)
+
+func TestDNSHeader(t *testing.T) {
+	client, err := internet.CreatePacketHeader(&Config{Domain: "www.v2ray.com"})
+	common.Must(err)
+	server, err := internet.CreateServerPacketHeader(&Config{Domain: "www.v2ray.com"})
+	common.Must(err)
+
+	if client.Size() != server.Size() {
+		t.Fatal("client header size ", client.Size(), " doesn't match server header size ", server.Size())
+	}
+
+	for _, tc := range []struct {
+		header   internet.PacketHeader
+		response bool
+	}{
+		{header: client, response: false},
+		{header: server, response: true},
+	} {
+		b := make([]byte, tc.header.Size())
+		tc.header.Serialize(b)
+
+		var parser dnsmessage.Parser
+		h, err := parser.Start(b)
+		common.Must(err)
+		if h.Response != tc.response {
+			t.Error("expected response flag ", tc.response, ", but got ", h.Response)
+		}
+		q, err := parser.Question()
+		common.Must(err)
+		if q.Name.String() != "www.v2ray.com." || q.Type != dnsmessage.TypeA || q.Class != dnsmessage.ClassINET {
+			t.Error("unexpected question: ", q)
+		}
+		common.Must(parser.SkipAllQuestions())
+		answers, err := parser.AllAnswers()
+		common.Must(err)
+		if len(answers) != 0 {
+			t.Error("unexpected answers: ", answers)
+		}
+		common.Must(parser.SkipAllAuthorities())
+		common.Must(parser.SkipAllAdditionals())
+	}
+}
+
+func TestDNSResponseID(t *testing.T) {
+	client, err := internet.CreatePacketHeader(&Config{})
+	common.Must(err)
+	server, err := internet.CreateServerPacketHeader(&Config{})
+	common.Must(err)
+	session := server.(internet.StatefulPacketHeader).NewSession()
+
+	for i := 0; i < 3; i++ {
+		query := make([]byte, client.Size())
+		client.Serialize(query)
+		internet.ReceivePacketHeader(session, query)
+
+		response := make([]byte, session.Size())
+		session.Serialize(response)
+		if !bytes.Equal(response[:2], query[:2]) {
+			t.Error("response ID ", response[:2], " doesn't match query ID ", query[:2])
+		}
+	}
+
+	// Without a new query, the ID of the last response is used again.
+	first := make([]byte, session.Size())
+	session.Serialize(first)
+	second := make([]byte, session.Size())
+	session.Serialize(second)
+	if !bytes.Equal(first[:2], second[:2]) {
+		t.Error("response ID changed without a query")
+	}
+}
+
+func TestInvalidDomain(t *testing.T) {
+	for _, domain := range []string{"a..b", ".", "www." + string(make([]byte, 64)) + ".com"} {
+		if _, err := internet.CreatePacketHeader(&Config{Domain: domain}); err == nil {
+			t.Error("expected error for domain ", domain)
+		}
+	}
+}
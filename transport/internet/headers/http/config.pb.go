@@ -316,21 +316,77 @@ func (m *ResponseConfig) GetHeader() []*Header {
 	return nil
 }
 
+type MimicryConfig struct {
+	// Maximum number of payload bytes in one request or response. Default
+	// 65536.
+	MaxMessageSize uint32 `protobuf:"varint,1,opt,name=max_message_size,json=maxMessageSize,proto3" json:"max_message_size,omitempty"`
+	// Page served to requests that don't match the request settings. An empty
+	// 404 response is sent if not set.
+	ProbePage            []byte   `protobuf:"bytes,2,opt,name=probe_page,json=probePage,proto3" json:"probe_page,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MimicryConfig) Reset()         { *m = MimicryConfig{} }
+func (m *MimicryConfig) String() string { return proto.CompactTextString(m) }
+func (*MimicryConfig) ProtoMessage()    {}
+func (*MimicryConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e2685d0b4b039e80, []int{6}
+}
+
+func (m *MimicryConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MimicryConfig.Unmarshal(m, b)
+}
+func (m *MimicryConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MimicryConfig.Marshal(b, m, deterministic)
+}
+func (m *MimicryConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MimicryConfig.Merge(m, src)
+}
+func (m *MimicryConfig) XXX_Size() int {
+	return xxx_messageInfo_MimicryConfig.Size(m)
+}
+func (m *MimicryConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_MimicryConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MimicryConfig proto.InternalMessageInfo
+
+func (m *MimicryConfig) GetMaxMessageSize() uint32 {
+	if m != nil {
+		return m.MaxMessageSize
+	}
+	return 0
+}
+
+func (m *MimicryConfig) GetProbePage() []byte {
+	if m != nil {
+		return m.ProbePage
+	}
+	return nil
+}
+
 type Config struct {
 	// Settings for authenticating requests. If not set, client side will not send authenication header, and server side will bypass authentication.
 	Request *RequestConfig `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
 	// Settings for authenticating responses. If not set, client side will bypass authentication, and server side will not send authentication header.
-	Response             *ResponseConfig `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Response *ResponseConfig `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	// If set, all data is carried in a series of HTTP requests and responses
+	// with chunked transfer encoding, instead of following a single header.
+	// Each request is paired with a response. A request method that allows a
+	// body, such as "POST", is recommended.
+	Mimicry              *MimicryConfig `protobuf:"bytes,3,opt,name=mimicry,proto3" json:"mimicry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *Config) Reset()         { *m = Config{} }
 func (m *Config) String() string { return proto.CompactTextString(m) }
 func (*Config) ProtoMessage()    {}
 func (*Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e2685d0b4b039e80, []int{6}
+	return fileDescriptor_e2685d0b4b039e80, []int{7}
 }
 
 func (m *Config) XXX_Unmarshal(b []byte) error {
@@ -365,6 +421,13 @@ func (m *Config) GetResponse() *ResponseConfig {
 	return nil
 }
 
+func (m *Config) GetMimicry() *MimicryConfig {
+	if m != nil {
+		return m.Mimicry
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Header)(nil), "v2ray.core.transport.internet.headers.http.Header")
 	proto.RegisterType((*Version)(nil), "v2ray.core.transport.internet.headers.http.Version")
@@ -372,6 +435,7 @@ func init() {
 	proto.RegisterType((*RequestConfig)(nil), "v2ray.core.transport.internet.headers.http.RequestConfig")
 	proto.RegisterType((*Status)(nil), "v2ray.core.transport.internet.headers.http.Status")
 	proto.RegisterType((*ResponseConfig)(nil), "v2ray.core.transport.internet.headers.http.ResponseConfig")
+	proto.RegisterType((*MimicryConfig)(nil), "v2ray.core.transport.internet.headers.http.MimicryConfig")
 	proto.RegisterType((*Config)(nil), "v2ray.core.transport.internet.headers.http.Config")
 }
 
@@ -380,30 +444,35 @@ func init() {
 }
 
 var fileDescriptor_e2685d0b4b039e80 = []byte{
-	// 394 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xbf, 0x8a, 0xdb, 0x40,
-	0x10, 0xc6, 0x91, 0xe4, 0xc8, 0xf1, 0x84, 0x84, 0xb0, 0x84, 0xa0, 0x2a, 0x31, 0xaa, 0x8c, 0x8b,
-	0x15, 0xc8, 0x69, 0x92, 0x74, 0x71, 0xe3, 0x04, 0x0c, 0x61, 0x1d, 0x5c, 0xa4, 0xdb, 0xc8, 0x93,
-	0x58, 0x10, 0xef, 0x2a, 0xbb, 0x2b, 0x83, 0xdf, 0x20, 0xcf, 0x72, 0xfd, 0x3d, 0xdb, 0xb5, 0xc7,
-	0xfe, 0x91, 0xce, 0x57, 0x1c, 0x9c, 0xee, 0xb8, 0x4a, 0x33, 0x68, 0xbe, 0x1f, 0xf3, 0x7d, 0x5a,
-	0x2d, 0x7c, 0x3e, 0x96, 0x8a, 0x9f, 0x68, 0x25, 0x0f, 0x45, 0x25, 0x15, 0x16, 0x46, 0x71, 0xa1,
-	0x1b, 0xa9, 0x4c, 0x51, 0x0b, 0x83, 0x4a, 0xa0, 0x29, 0xf6, 0xc8, 0x77, 0xa8, 0x74, 0xb1, 0x37,
-	0xa6, 0x29, 0x2a, 0x29, 0x7e, 0xd7, 0x7f, 0x68, 0xa3, 0xa4, 0x91, 0x64, 0xde, 0x89, 0x15, 0xd2,
-	0x5e, 0x48, 0x3b, 0x21, 0x0d, 0x42, 0x6a, 0x85, 0x79, 0x09, 0xe9, 0xca, 0xf5, 0x84, 0xc0, 0x48,
-	0xf0, 0x03, 0x66, 0xd1, 0x34, 0x9a, 0x4d, 0x98, 0xab, 0xc9, 0x1b, 0x78, 0x76, 0xe4, 0x7f, 0x5b,
-	0xcc, 0xe2, 0x69, 0x32, 0x9b, 0x30, 0xdf, 0xe4, 0xef, 0x61, 0xbc, 0x45, 0xa5, 0x6b, 0x29, 0x6e,
-	0x06, 0xbc, 0x2a, 0x0c, 0xbc, 0x83, 0x74, 0x8d, 0x66, 0x2f, 0x77, 0x77, 0xbc, 0xff, 0x1f, 0xc3,
-	0x4b, 0x86, 0xff, 0x5a, 0xd4, 0x66, 0xe9, 0x16, 0x27, 0x6b, 0x18, 0x1f, 0x3d, 0xd2, 0x4d, 0xbe,
-	0x28, 0x17, 0xf4, 0xfe, 0x26, 0x68, 0xd8, 0x86, 0x75, 0x0c, 0xf2, 0x0d, 0xd2, 0x83, 0x5b, 0x20,
-	0x8b, 0x1d, 0xad, 0x1c, 0x42, 0xf3, 0xab, 0xb3, 0x40, 0x20, 0xaf, 0x21, 0x69, 0x55, 0x9d, 0x25,
-	0x2e, 0x01, 0x5b, 0x5a, 0xba, 0x17, 0x64, 0xa3, 0x69, 0x32, 0x94, 0xee, 0xd3, 0x66, 0x81, 0x90,
-	0x7f, 0x80, 0x74, 0x63, 0xb8, 0x69, 0xb5, 0xcd, 0xbf, 0x92, 0xbb, 0x3e, 0x7f, 0x5b, 0x93, 0xb7,
-	0x90, 0x2a, 0xe4, 0x5a, 0x0a, 0xe7, 0x63, 0xc2, 0x42, 0x97, 0x5f, 0x45, 0xf0, 0x8a, 0xa1, 0x6e,
-	0xa4, 0xd0, 0xf8, 0x64, 0x09, 0x6a, 0xb7, 0xd7, 0x43, 0x12, 0xf4, 0x8e, 0x58, 0x20, 0x9c, 0xe5,
-	0x95, 0x3c, 0x3a, 0xaf, 0xcb, 0x08, 0xd2, 0xe0, 0x78, 0x03, 0x63, 0xe5, 0x0f, 0x51, 0x70, 0xfc,
-	0x71, 0x08, 0xf7, 0xd6, 0xf9, 0x63, 0x1d, 0x89, 0x6c, 0xe1, 0xb9, 0x0a, 0xc1, 0x06, 0xe7, 0x9f,
-	0x86, 0x51, 0xcf, 0x3f, 0x0a, 0xeb, 0x59, 0x5f, 0x10, 0xec, 0xcf, 0x3c, 0x00, 0xf5, 0x3d, 0xfa,
-	0x39, 0xb2, 0xcf, 0x8b, 0x78, 0xbe, 0x2d, 0x19, 0x3f, 0xd1, 0xa5, 0x15, 0xfd, 0xe8, 0x45, 0x5f,
-	0x3b, 0xd1, 0x2a, 0x88, 0x56, 0xc6, 0x34, 0xbf, 0x52, 0x77, 0x03, 0x2c, 0xae, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0x10, 0xea, 0x22, 0x27, 0x40, 0x04, 0x00, 0x00,
+	// 465 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0x4f, 0x8b, 0xd3, 0x40,
+	0x18, 0xc6, 0x69, 0x52, 0x53, 0xfb, 0x6a, 0x97, 0x65, 0x10, 0xc9, 0x45, 0x2d, 0x39, 0x95, 0x3d,
+	0x4c, 0x20, 0xeb, 0x45, 0xbd, 0xb9, 0x97, 0x2a, 0x14, 0x96, 0xa9, 0x14, 0xf1, 0x52, 0x66, 0xd3,
+	0xd7, 0x36, 0x60, 0x66, 0xe2, 0xcc, 0xb4, 0x6c, 0xf7, 0x13, 0x88, 0x1f, 0xc5, 0x2f, 0xe8, 0x55,
+	0xe6, 0x4f, 0xe2, 0xee, 0x41, 0x30, 0xca, 0x9e, 0x32, 0xef, 0xe4, 0x7d, 0x7e, 0x3c, 0xef, 0x33,
+	0xc3, 0xc0, 0x9b, 0x43, 0xa1, 0xf8, 0x91, 0x96, 0xb2, 0xce, 0x4b, 0xa9, 0x30, 0x37, 0x8a, 0x0b,
+	0xdd, 0x48, 0x65, 0xf2, 0x4a, 0x18, 0x54, 0x02, 0x4d, 0xbe, 0x43, 0xbe, 0x41, 0xa5, 0xf3, 0x9d,
+	0x31, 0x4d, 0x5e, 0x4a, 0xf1, 0xb9, 0xda, 0xd2, 0x46, 0x49, 0x23, 0xc9, 0x59, 0x2b, 0x56, 0x48,
+	0x3b, 0x21, 0x6d, 0x85, 0x34, 0x08, 0xa9, 0x15, 0x66, 0x05, 0x24, 0x73, 0x57, 0x13, 0x02, 0x43,
+	0xc1, 0x6b, 0x4c, 0x07, 0xd3, 0xc1, 0x6c, 0xcc, 0xdc, 0x9a, 0x3c, 0x81, 0x07, 0x07, 0xfe, 0x65,
+	0x8f, 0x69, 0x34, 0x8d, 0x67, 0x63, 0xe6, 0x8b, 0xec, 0x05, 0x8c, 0x56, 0xa8, 0x74, 0x25, 0xc5,
+	0xef, 0x06, 0xaf, 0x0a, 0x0d, 0xcf, 0x21, 0x59, 0xa0, 0xd9, 0xc9, 0xcd, 0x1f, 0xfe, 0x7f, 0x8b,
+	0x60, 0xc2, 0xf0, 0xeb, 0x1e, 0xb5, 0xb9, 0x70, 0xc6, 0xc9, 0x02, 0x46, 0x07, 0x8f, 0x74, 0x9d,
+	0x8f, 0x8a, 0x73, 0xfa, 0xf7, 0x43, 0xd0, 0xe0, 0x86, 0xb5, 0x0c, 0xf2, 0x1e, 0x92, 0xda, 0x19,
+	0x48, 0x23, 0x47, 0x2b, 0xfa, 0xd0, 0xbc, 0x75, 0x16, 0x08, 0xe4, 0x14, 0xe2, 0xbd, 0xaa, 0xd2,
+	0xd8, 0x25, 0x60, 0x97, 0x96, 0xee, 0x05, 0xe9, 0x70, 0x1a, 0xf7, 0xa5, 0xfb, 0xb4, 0x59, 0x20,
+	0x64, 0x2f, 0x21, 0x59, 0x1a, 0x6e, 0xf6, 0xda, 0xe6, 0x5f, 0xca, 0x4d, 0x97, 0xbf, 0x5d, 0x93,
+	0xa7, 0x90, 0x28, 0xe4, 0x5a, 0x0a, 0x37, 0xc7, 0x98, 0x85, 0x2a, 0xfb, 0x39, 0x80, 0x13, 0x86,
+	0xba, 0x91, 0x42, 0xe3, 0xbd, 0x25, 0xa8, 0x9d, 0xaf, 0x7f, 0x49, 0xd0, 0x4f, 0xc4, 0x02, 0xe1,
+	0x56, 0x5e, 0xf1, 0x7f, 0xe7, 0xf5, 0x11, 0x26, 0x8b, 0xaa, 0xae, 0x4a, 0x75, 0x0c, 0x73, 0xcf,
+	0xe0, 0xb4, 0xe6, 0xd7, 0xeb, 0x1a, 0xb5, 0xe6, 0x5b, 0x5c, 0xeb, 0xea, 0xc6, 0x47, 0x38, 0x61,
+	0x27, 0x35, 0xbf, 0x5e, 0xf8, 0xed, 0x65, 0x75, 0x83, 0xe4, 0x19, 0x40, 0xa3, 0xe4, 0x15, 0xae,
+	0x1b, 0xbe, 0x45, 0x37, 0xd6, 0x63, 0x36, 0x76, 0x3b, 0x97, 0x7c, 0x8b, 0xd9, 0xf7, 0x08, 0x92,
+	0xc0, 0x5c, 0xc2, 0x48, 0xf9, 0xeb, 0x19, 0xb2, 0x7c, 0xd5, 0xc7, 0xf1, 0x9d, 0x9b, 0xcd, 0x5a,
+	0x12, 0x59, 0xc1, 0x43, 0x15, 0x8e, 0x2c, 0x64, 0xfa, 0xba, 0x1f, 0xf5, 0xf6, 0x71, 0xb3, 0x8e,
+	0x65, 0xcd, 0xd6, 0x3e, 0x91, 0x34, 0xee, 0x6f, 0xf6, 0x4e, 0x98, 0xac, 0x25, 0xbd, 0x45, 0xb0,
+	0x6f, 0x4f, 0x0f, 0xd0, 0xe5, 0xe0, 0xd3, 0xd0, 0x7e, 0x7f, 0x44, 0x67, 0xab, 0x82, 0xf1, 0x23,
+	0xbd, 0xb0, 0xa2, 0x0f, 0x9d, 0xe8, 0x5d, 0x2b, 0x9a, 0x07, 0xd1, 0xdc, 0x98, 0xe6, 0x2a, 0x71,
+	0x0f, 0xd6, 0xf9, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe5, 0x51, 0xa2, 0x9d, 0xef, 0x04, 0x00,
+	0x00,
 }
@@ -218,6 +218,9 @@ func (a HttpAuthenticator) GetServerWriter() *HeaderWriter {
 }
 
 func (a HttpAuthenticator) Client(conn net.Conn) net.Conn {
+	if a.config.Mimicry != nil {
+		return NewMimicryConn(conn, a.config, false)
+	}
 	if a.config.Request == nil && a.config.Response == nil {
 		return conn
 	}
@@ -234,6 +237,9 @@ func (a HttpAuthenticator) Client(conn net.Conn) net.Conn {
 }
 
 func (a HttpAuthenticator) Server(conn net.Conn) net.Conn {
+	if a.config.Mimicry != nil {
+		return NewMimicryConn(conn, a.config, true)
+	}
 	if a.config.Request == nil && a.config.Response == nil {
 		return conn
 	}
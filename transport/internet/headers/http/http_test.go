@@ -102,7 +102,9 @@ func TestConnection(t *testing.T) {
 
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	assert(err, IsNil)
+	defer listener.Close()
 
+	done := make(chan struct{})
 	go func() {
 		conn, err := listener.Accept()
 		assert(err, IsNil)
@@ -110,6 +112,12 @@ func TestConnection(t *testing.T) {
 		b := make([]byte, 256)
 		for {
 			n, err := authConn.Read(b)
+			select {
+			case <-done:
+				// The client has closed the connection.
+				return
+			default:
+			}
 			assert(err, IsNil)
 			_, err = authConn.Write(b[:n])
 			assert(err, IsNil)
@@ -118,6 +126,12 @@ func TestConnection(t *testing.T) {
 
 	conn, err := net.DialTCP("tcp", nil, listener.Addr().(*net.TCPAddr))
 	assert(err, IsNil)
+	// Otherwise the connection is closed by garbage collection while later tests run, and the server goroutine
+	// fails after this test has completed.
+	defer func() {
+		close(done)
+		conn.Close()
+	}()
 
 	authConn := auth.Client(conn)
 	authConn.Write([]byte("Test payload"))
@@ -0,0 +1,425 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mimicryFlushTimeout is how long Close waits for queued bytes to be written.
+const mimicryFlushTimeout = 2 * time.Second
+
+func (c *MimicryConfig) getMaxMessageSize() int {
+	if c.MaxMessageSize == 0 {
+		return 64 * 1024
+	}
+	return int(c.MaxMessageSize)
+}
+
+// MimicryConn carries data in a series of HTTP/1.1 requests and responses with chunked transfer encoding.
+//
+// Each request is paired with a response, and the client decides where messages end. The client always keeps
+// one request open, and ends it once either the request or its response has carried enough payload, then
+// starts the next one right away. The server starts a response after the header of its request arrives, and
+// ends it along with the request.
+//
+// Outgoing bytes are queued and written to the underlying connection by a separate goroutine. So the reading side
+// never waits for a blocking write when it ends or starts a message, and keeps draining the connection.
+type MimicryConn struct {
+	net.Conn
+
+	config   *Config
+	isServer bool
+	maxSize  int
+
+	reader    *bufio.Reader
+	body      io.Reader
+	bodyBytes int
+
+	access   sync.Mutex
+	cond     *sync.Cond
+	writing  bool
+	written  int
+	started  int
+	received int
+	closed   bool
+	eof      bool
+
+	pending  [][]byte
+	queued   uint64
+	flushed  uint64
+	writeErr error
+	done     chan struct{}
+}
+
+// NewMimicryConn creates a MimicryConn on the given connection. isServer indicates whether the connection is accepted by a server.
+func NewMimicryConn(conn net.Conn, config *Config, isServer bool) *MimicryConn {
+	c := &MimicryConn{
+		Conn:     conn,
+		config:   config,
+		isServer: isServer,
+		maxSize:  config.Mimicry.getMaxMessageSize(),
+		reader:   bufio.NewReader(conn),
+		done:     make(chan struct{}),
+	}
+	c.cond = sync.NewCond(&c.access)
+	go c.writeLoop()
+	return c
+}
+
+// enqueue queues b for writing, and returns its sequence number. It must be called with c.access held.
+func (c *MimicryConn) enqueue(b []byte) uint64 {
+	c.pending = append(c.pending, b)
+	c.queued++
+	c.cond.Broadcast()
+	return c.queued
+}
+
+// writeLoop writes queued bytes to the underlying connection, until it is closed and all queued bytes are written.
+func (c *MimicryConn) writeLoop() {
+	defer close(c.done)
+
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	for {
+		for len(c.pending) == 0 && !c.closed {
+			c.cond.Wait()
+		}
+		if len(c.pending) == 0 {
+			return
+		}
+		pending := c.pending
+		c.pending = nil
+
+		c.access.Unlock()
+		var err error
+		for _, b := range pending {
+			if _, err = c.Conn.Write(b); err != nil {
+				break
+			}
+		}
+		c.access.Lock()
+
+		if err != nil {
+			c.writeErr = err
+			c.cond.Broadcast()
+			return
+		}
+		c.flushed += uint64(len(pending))
+		c.cond.Broadcast()
+	}
+}
+
+// Read implements net.Conn.Read().
+func (c *MimicryConn) Read(b []byte) (int, error) {
+	for {
+		if c.body == nil {
+			if err := c.readHeader(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := c.body.Read(b)
+		if n > 0 {
+			// The body will return io.EOF again if it has ended.
+			if err := c.onPayload(n); err != nil {
+				return n, err
+			}
+			return n, nil
+		}
+		if err != io.EOF {
+			return 0, err
+		}
+		c.body = nil
+		if err := c.onMessageEnd(); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (c *MimicryConn) readHeader() error {
+	if err := c.readHeaderInternal(); err != nil {
+		c.access.Lock()
+		c.eof = true
+		c.cond.Broadcast()
+		c.access.Unlock()
+		return err
+	}
+	c.bodyBytes = 0
+	return nil
+}
+
+func (c *MimicryConn) readHeaderInternal() error {
+	if !c.isServer {
+		resp, err := http.ReadResponse(c.reader, nil)
+		if err != nil {
+			return err
+		}
+		if !isChunked(resp.TransferEncoding) {
+			return newError("unexpected response: ", resp.Status)
+		}
+		c.body = resp.Body
+		return nil
+	}
+
+	req, err := http.ReadRequest(c.reader)
+	if err != nil {
+		return err
+	}
+	if !c.matchRequest(req) {
+		c.serveProbe()
+		return newError("invalid request: ", req.Method, " ", req.RequestURI)
+	}
+	c.body = req.Body
+
+	c.access.Lock()
+	c.received++
+	c.cond.Broadcast()
+	c.access.Unlock()
+	return nil
+}
+
+func isChunked(te []string) bool {
+	return len(te) == 1 && te[0] == "chunked"
+}
+
+func (c *MimicryConn) matchRequest(req *http.Request) bool {
+	config := c.config.Request
+	if req.Method != config.GetMethodValue() || !isChunked(req.TransferEncoding) {
+		return false
+	}
+	if config == nil || len(config.Uri) == 0 {
+		return true
+	}
+	for _, uri := range config.Uri {
+		if req.RequestURI == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// serveProbe responds to a request that doesn't match the settings like an ordinary web server.
+func (c *MimicryConn) serveProbe() {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.started > 0 {
+		return
+	}
+
+	page := c.config.Mimicry.ProbePage
+	var header bytes.Buffer
+	if len(page) > 0 {
+		header.WriteString("HTTP/1.1 200 OK" + CRLF)
+		header.WriteString("Content-Type: text/html; charset=utf-8" + CRLF)
+	} else {
+		header.WriteString("HTTP/1.1 404 Not Found" + CRLF)
+	}
+	header.WriteString("Content-Length: " + strconv.Itoa(len(page)) + CRLF)
+	header.WriteString("Date: " + time.Now().UTC().Format(http.TimeFormat) + CRLF)
+	header.WriteString("Connection: close" + ENDING)
+	header.Write(page)
+	c.enqueue(header.Bytes())
+	c.closed = true
+	c.cond.Broadcast()
+}
+
+// onPayload is called when payload of an incoming message is read.
+func (c *MimicryConn) onPayload(n int) error {
+	if c.isServer || c.bodyBytes >= c.maxSize {
+		return nil
+	}
+	c.bodyBytes += n
+	if c.bodyBytes < c.maxSize {
+		return nil
+	}
+
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	// The response is large enough. End its request to let the server end it as well.
+	if c.writing && c.started == c.received+1 {
+		c.endMessage()
+		c.startMessage()
+	}
+	return c.writeErr
+}
+
+// onMessageEnd is called when an incoming request or response ends.
+func (c *MimicryConn) onMessageEnd() error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.isServer {
+		// Every request must be answered before the next one arrives.
+		if c.writing {
+			c.endMessage()
+		}
+		for c.started < c.received {
+			c.startMessage()
+			c.endMessage()
+		}
+		return c.writeErr
+	}
+
+	if c.writing && c.started == c.received+1 {
+		c.endMessage()
+		c.startMessage()
+	}
+	c.received++
+	return c.writeErr
+}
+
+func (c *MimicryConn) messageHeader() []byte {
+	var header bytes.Buffer
+	var headers []string
+
+	if c.isServer {
+		config := c.config.Response
+		status := config.GetStatusValue()
+		header.WriteString(strings.Join([]string{config.GetFullVersion(), status.Code, status.Reason}, " ") + CRLF)
+		if config != nil {
+			headers = config.PickHeaders()
+		}
+		if config == nil || !config.HasHeader("Date") {
+			headers = append(headers, "Date: "+time.Now().UTC().Format(http.TimeFormat))
+		}
+	} else {
+		config := c.config.Request
+		uri := "/"
+		if config != nil {
+			if u := config.PickUri(); len(u) > 0 {
+				uri = u
+			}
+			headers = config.PickHeaders()
+		}
+		header.WriteString(strings.Join([]string{config.GetMethodValue(), uri, config.GetFullVersion()}, " ") + CRLF)
+	}
+
+	for _, h := range headers {
+		name := strings.ToLower(strings.TrimSpace(h[:strings.IndexByte(h, ':')]))
+		if name == "content-length" || name == "transfer-encoding" {
+			continue
+		}
+		header.WriteString(h + CRLF)
+	}
+	header.WriteString("Transfer-Encoding: chunked" + ENDING)
+	return header.Bytes()
+}
+
+// startMessage queues the header of a new message. It must be called with c.access held.
+func (c *MimicryConn) startMessage() {
+	c.enqueue(c.messageHeader())
+	c.writing = true
+	c.written = 0
+	c.started++
+}
+
+// endMessage queues the last chunk of the current message. It must be called with c.access held.
+func (c *MimicryConn) endMessage() {
+	c.enqueue([]byte("0" + ENDING))
+	c.writing = false
+}
+
+// Write implements net.Conn.Write().
+func (c *MimicryConn) Write(b []byte) (int, error) {
+	written := 0
+	for len(b) > 0 {
+		n, err := c.writeChunk(b)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+	}
+	return written, nil
+}
+
+// writeChunk writes a chunk from the beginning of b, and waits until it is written to the underlying connection. The
+// lock is released while waiting, so that the reading side is able to end messages in time.
+func (c *MimicryConn) writeChunk(b []byte) (int, error) {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	for !c.writing {
+		if c.closed {
+			return 0, io.ErrClosedPipe
+		}
+		if c.writeErr != nil {
+			return 0, c.writeErr
+		}
+		if c.isServer && c.started >= c.received {
+			if c.eof {
+				return 0, io.ErrClosedPipe
+			}
+			c.cond.Wait()
+			continue
+		}
+		c.startMessage()
+	}
+	if c.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if c.writeErr != nil {
+		return 0, c.writeErr
+	}
+
+	n := len(b)
+	if n > c.maxSize {
+		n = c.maxSize
+	}
+	if limit := c.maxSize - c.written; !c.isServer && n > limit {
+		n = limit
+	}
+	chunk := make([]byte, 0, n+16)
+	chunk = append(chunk, strconv.FormatInt(int64(n), 16)+CRLF...)
+	chunk = append(chunk, b[:n]...)
+	chunk = append(chunk, CRLF...)
+	seq := c.enqueue(chunk)
+	c.written += n
+
+	if !c.isServer && c.written >= c.maxSize {
+		c.endMessage()
+		c.startMessage()
+	}
+
+	for c.flushed < seq {
+		if c.writeErr != nil {
+			return 0, c.writeErr
+		}
+		c.cond.Wait()
+	}
+	return n, nil
+}
+
+// Close implements net.Conn.Close().
+func (c *MimicryConn) Close() error {
+	c.access.Lock()
+	if !c.closed {
+		c.closed = true
+		if c.writing {
+			c.endMessage()
+		}
+		c.cond.Broadcast()
+	}
+	c.access.Unlock()
+
+	// Queued bytes are written before the connection is closed. If the peer has stopped reading, the connection is
+	// closed anyway, which also ends the pending write.
+	timer := time.NewTimer(mimicryFlushTimeout)
+	defer timer.Stop()
+	select {
+	case <-c.done:
+	case <-timer.C:
+		newError("failed to flush within ", mimicryFlushTimeout).AtDebug().WriteToLog()
+	}
+	return c.Conn.Close()
+}
@@ -0,0 +1,335 @@
+package http_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"io/ioutil"
+	gonet "net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	. "v2ray.com/core/transport/internet/headers/http"
+)
+
+// recordConn records all bytes written to the underlying connection.
+type recordConn struct {
+	net.Conn
+	sync.Mutex
+	record bytes.Buffer
+}
+
+func (c *recordConn) Write(b []byte) (int, error) {
+	c.Lock()
+	c.record.Write(b)
+	c.Unlock()
+	return c.Conn.Write(b)
+}
+
+func (c *recordConn) Bytes() []byte {
+	c.Lock()
+	defer c.Unlock()
+	return append([]byte(nil), c.record.Bytes()...)
+}
+
+func newMimicryConfig() *Config {
+	return &Config{
+		Request: &RequestConfig{
+			Method: &Method{Value: "POST"},
+			Uri:    []string{"/upload", "/api/v1/sync"},
+			Header: []*Header{
+				{
+					Name:  "Host",
+					Value: []string{"www.v2ray.com"},
+				},
+				{
+					Name:  "Content-Length",
+					Value: []string{"100"},
+				},
+			},
+		},
+		Response: &ResponseConfig{
+			Header: []*Header{
+				{
+					Name:  "Content-Type",
+					Value: []string{"application/octet-stream"},
+				},
+			},
+		},
+		Mimicry: &MimicryConfig{
+			MaxMessageSize: 1000,
+			ProbePage:      []byte("<html><body>It works!</body></html>"),
+		},
+	}
+}
+
+func TestMimicryConnection(t *testing.T) {
+	auth, err := NewHttpAuthenticator(context.Background(), newMimicryConfig())
+	common.Must(err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	serverConn := make(chan *recordConn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		rc := &recordConn{Conn: conn}
+		authConn := auth.Server(rc)
+		io.Copy(authConn, authConn)
+		authConn.Close()
+		serverConn <- rc
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	clientConn := &recordConn{Conn: conn}
+	authConn := auth.Client(clientConn)
+
+	payload := make([]byte, 10000)
+	common.Must2(rand.Read(payload))
+	go func() {
+		for i := 0; i < len(payload); i += 700 {
+			end := i + 700
+			if end > len(payload) {
+				end = len(payload)
+			}
+			common.Must2(authConn.Write(payload[i:end]))
+		}
+	}()
+
+	common.Must(authConn.SetReadDeadline(time.Now().Add(time.Second * 5)))
+	received := make([]byte, len(payload))
+	if _, err := io.ReadFull(authConn, received); err != nil {
+		t.Fatal("failed to read: ", err)
+	}
+	if !bytes.Equal(received, payload) {
+		t.Error("payload mismatch")
+	}
+	common.Must(authConn.Close())
+
+	requests, responses := countMessages(t, clientConn.Bytes(), (<-serverConn).Bytes())
+	if requests < 10 || requests != responses && requests != responses+1 {
+		t.Error("requests and responses are not paired: ", requests, " requests, ", responses, " responses")
+	}
+}
+
+func TestMimicryDownload(t *testing.T) {
+	auth, err := NewHttpAuthenticator(context.Background(), newMimicryConfig())
+	common.Must(err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	payload := make([]byte, 20000)
+	common.Must2(rand.Read(payload))
+
+	serverConn := make(chan *recordConn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		rc := &recordConn{Conn: conn}
+		authConn := auth.Server(rc)
+		if _, err := authConn.Read(make([]byte, 16)); err == nil {
+			go authConn.Write(payload)
+			io.Copy(ioutil.Discard, authConn)
+		}
+		authConn.Close()
+		serverConn <- rc
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	clientConn := &recordConn{Conn: conn}
+	authConn := auth.Client(clientConn)
+	common.Must2(authConn.Write([]byte("hello")))
+
+	common.Must(authConn.SetReadDeadline(time.Now().Add(time.Second * 5)))
+	received := make([]byte, len(payload))
+	if _, err := io.ReadFull(authConn, received); err != nil {
+		t.Fatal("failed to read: ", err)
+	}
+	if !bytes.Equal(received, payload) {
+		t.Error("payload mismatch")
+	}
+	common.Must(authConn.Close())
+
+	// The client sends only a few bytes, so it must have started new requests because of the responses.
+	requests, responses := countMessages(t, clientConn.Bytes(), (<-serverConn).Bytes())
+	if requests < 2 || requests != responses && requests != responses+1 {
+		t.Error("requests and responses are not paired: ", requests, " requests, ", responses, " responses")
+	}
+}
+
+// TestMimicryBidirectional sends bulk data in both directions at the same time. Writers block on full socket buffers,
+// so neither side makes progress unless readers keep draining the connection meanwhile.
+func TestMimicryBidirectional(t *testing.T) {
+	config := newMimicryConfig()
+	config.Mimicry.MaxMessageSize = 16 * 1024
+	auth, err := NewHttpAuthenticator(context.Background(), config)
+	common.Must(err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	const size = 32 * 1024 * 1024
+	upload := make([]byte, size)
+	common.Must2(rand.Read(upload))
+	download := make([]byte, size)
+	common.Must2(rand.Read(download))
+
+	exchange := func(conn net.Conn, send []byte, expected []byte) error {
+		written := make(chan error, 1)
+		go func() {
+			_, err := conn.Write(send)
+			written <- err
+		}()
+
+		common.Must(conn.SetReadDeadline(time.Now().Add(time.Second * 20)))
+		received := make([]byte, len(expected))
+		if _, err := io.ReadFull(conn, received); err != nil {
+			return err
+		}
+		if !bytes.Equal(received, expected) {
+			return errors.New("payload mismatch")
+		}
+		return <-written
+	}
+
+	serverErr := make(chan error, 1)
+	clientDone := make(chan struct{})
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		authConn := auth.Server(conn)
+		// Closing with unread data resets the connection, which may discard data the client has not read yet.
+		defer func() {
+			<-clientDone
+			authConn.Close()
+		}()
+		serverErr <- exchange(authConn, download, upload)
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	authConn := auth.Client(conn)
+	defer authConn.Close()
+
+	if err := exchange(authConn, upload, download); err != nil {
+		t.Error("client: ", err)
+	}
+	close(clientDone)
+	if err := <-serverErr; err != nil {
+		t.Error("server: ", err)
+	}
+}
+
+// countMessages parses the recorded traffic as HTTP requests and responses. The last message may be incomplete
+// as the connection is closed.
+func countMessages(t *testing.T, clientRecord []byte, serverRecord []byte) (int, int) {
+	requests := 0
+	reader := bufio.NewReader(bytes.NewReader(clientRecord))
+	for {
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			break
+		}
+		requests++
+		if req.Method != "POST" || req.Header.Get("Content-Length") != "" || req.TransferEncoding[0] != "chunked" {
+			t.Error("unexpected request: ", req.Method, " ", req.Header)
+		}
+		if _, err := ioutil.ReadAll(req.Body); err != nil {
+			break
+		}
+	}
+
+	responses := 0
+	reader = bufio.NewReader(bytes.NewReader(serverRecord))
+	for {
+		resp, err := http.ReadResponse(reader, nil)
+		if err != nil {
+			break
+		}
+		responses++
+		if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "application/octet-stream" {
+			t.Error("unexpected response: ", resp.Status, " ", resp.Header)
+		}
+		if _, err := ioutil.ReadAll(resp.Body); err != nil {
+			break
+		}
+	}
+	return requests, responses
+}
+
+func TestMimicryProbe(t *testing.T) {
+	config := newMimicryConfig()
+	auth, err := NewHttpAuthenticator(context.Background(), config)
+	common.Must(err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		authConn := auth.Server(conn)
+		defer authConn.Close()
+		if _, err := authConn.Read(make([]byte, 1024)); err == nil {
+			t.Error("expected error for a probe, but got nil")
+		}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	common.Must2(conn.Write([]byte("GET / HTTP/1.1\r\nHost: www.v2ray.com\r\n\r\n")))
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	common.Must(err)
+	body, err := ioutil.ReadAll(resp.Body)
+	common.Must(err)
+	if resp.StatusCode != 200 || !bytes.Equal(body, config.Mimicry.ProbePage) {
+		t.Error("unexpected response: ", resp.Status, " ", string(body))
+	}
+}
+
+func TestMimicryCloseWithoutReader(t *testing.T) {
+	clientConn, serverConn := gonet.Pipe()
+	defer serverConn.Close()
+
+	client := NewMimicryConn(clientConn, newMimicryConfig(), false)
+	go client.Write(make([]byte, 100)) // nolint: errcheck
+	time.Sleep(time.Millisecond * 100)
+
+	// The server never reads, so queued bytes can't be flushed.
+	closed := make(chan error, 1)
+	go func() {
+		closed <- client.Close()
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Close blocked on a peer that doesn't read")
+	}
+}
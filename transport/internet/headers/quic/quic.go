@@ -0,0 +1,158 @@
+package quic
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/dice"
+	"v2ray.com/core/transport/internet"
+)
+
+const (
+	headerSize        = 30
+	paddingOffset     = headerSize - 2
+	minInitialSize    = 1200
+	connectionIDLen   = 8
+	version1          = 0x00000001
+	initialPackets    = 2
+	handshakePackets  = 2
+	typeInitial       = 0xC0
+	typeHandshake     = 0xE0
+	shortHeader       = 0x40
+	longHeaderRandom  = 0x0F // reserved bits and packet number length, protected by header protection
+	shortHeaderRandom = 0x3F
+)
+
+// QUIC is a PacketHeader that makes packets look like QUIC version 1 packets. The first packets carry Initial and
+// Handshake long headers, and the following ones carry 1-RTT short headers. Initial packets are padded to 1200 bytes.
+type QUIC struct {
+	access      sync.Mutex
+	destination [connectionIDLen]byte
+	source      [connectionIDLen]byte
+	count       uint32
+	learned     bool
+}
+
+// Size implements PacketHeader.
+func (*QUIC) Size() int32 {
+	return headerSize
+}
+
+// Serialize implements PacketHeader.
+func (q *QUIC) Serialize(b []byte) {
+	q.SerializePacket(b, 0)
+}
+
+// SerializePacket implements internet.StatefulPacketHeader.
+func (q *QUIC) SerializePacket(b []byte, payloadSize int) int {
+	q.access.Lock()
+	q.count++
+	n := q.count
+	destination := q.destination
+	q.access.Unlock()
+
+	if n > initialPackets+handshakePackets {
+		b = b[:headerSize]
+		b[0] = shortHeader | byte(dice.Roll(shortHeaderRandom+1))
+		copy(b[1:], destination[:])
+		common.Must2(rand.Read(b[1+connectionIDLen:])) // protected packet number and payload
+		return 0
+	}
+
+	b = b[:headerSize]
+	packetType := byte(typeInitial)
+	padding := 0
+	if n > initialPackets {
+		packetType = typeHandshake
+	} else if size := headerSize + payloadSize; size < minInitialSize {
+		padding = minInitialSize - size
+	}
+	b[0] = packetType | byte(dice.Roll(longHeaderRandom+1))
+	binary.BigEndian.PutUint32(b[1:], version1)
+	b[5] = connectionIDLen
+	copy(b[6:], destination[:])
+	b[14] = connectionIDLen
+	copy(b[15:], q.source[:])
+	offset := lengthOffset(packetType)
+	if packetType == typeInitial {
+		b[offset-1] = 0 // token length
+	}
+	// Length in 2-byte variable-length integer encoding, covering the packet number and the payload.
+	binary.BigEndian.PutUint16(b[offset:], 0x4000|uint16(headerSize-offset-2+payloadSize+padding))
+	common.Must2(rand.Read(b[offset+2:])) // protected packet number and payload
+	// The size of padding is masked in the protected packet number.
+	binary.BigEndian.PutUint16(b[paddingOffset:], binary.BigEndian.Uint16(b[paddingOffset-2:])^uint16(padding))
+	return padding
+}
+
+// PayloadSize implements internet.StatefulPacketHeader.
+func (*QUIC) PayloadSize(packet []byte) int {
+	if len(packet) <= headerSize {
+		return -1
+	}
+	if packet[0]&0x80 == 0 {
+		return len(packet) - headerSize
+	}
+
+	offset := lengthOffset(packet[0] & 0xF0)
+	length := int(binary.BigEndian.Uint16(packet[offset:]) & 0x3FFF)
+	if offset+2+length != len(packet) {
+		return -1
+	}
+	padding := int(binary.BigEndian.Uint16(packet[paddingOffset:]) ^ binary.BigEndian.Uint16(packet[paddingOffset-2:]))
+	return len(packet) - headerSize - padding
+}
+
+// Receive implements internet.StatefulPacketHeader. The first long header from the peer tells the connection ID
+// that it expects in following packets.
+func (q *QUIC) Receive(packet []byte) {
+	if len(packet) <= headerSize || packet[0]&0x80 == 0 {
+		return
+	}
+
+	q.access.Lock()
+	defer q.access.Unlock()
+
+	if !q.learned {
+		copy(q.destination[:], packet[15:15+connectionIDLen])
+		q.learned = true
+	}
+}
+
+// NewSession implements internet.StatefulPacketHeader.
+func (*QUIC) NewSession() internet.PacketHeader {
+	return newQUIC()
+}
+
+// ServerHeader implements internet.StatefulPacketHeader. Servers send the same packets as clients.
+func (q *QUIC) ServerHeader() internet.PacketHeader {
+	return q
+}
+
+func lengthOffset(packetType byte) int {
+	if packetType == typeInitial {
+		return 24
+	}
+	return 23
+}
+
+func newQUIC() *QUIC {
+	q := new(QUIC)
+	common.Must2(rand.Read(q.destination[:]))
+	common.Must2(rand.Read(q.source[:]))
+	return q
+}
+
+// New returns a new QUIC instance based on the given config.
+func New(ctx context.Context, config *Config) (*QUIC, error) {
+	return newQUIC(), nil
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
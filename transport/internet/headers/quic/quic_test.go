@@ -0,0 +1,126 @@
+package quic_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/transport/internet"
+	. "v2ray.com/core/transport/internet/headers/quic"
+)
+
+func TestQUICHeader(t *testing.T) {
+	header, err := New(context.Background(), &Config{})
+	common.Must(err)
+
+	var destination []byte
+	for i := 0; i < 6; i++ {
+		b := make([]byte, header.Size())
+		header.Serialize(b)
+
+		switch {
+		case i < 2:
+			if b[0]&0xF0 != 0xC0 {
+				t.Error("expected Initial packet, but got ", b[0])
+			}
+		case i < 4:
+			if b[0]&0xF0 != 0xE0 {
+				t.Error("expected Handshake packet, but got ", b[0])
+			}
+		default:
+			if b[0]&0xC0 != 0x40 {
+				t.Error("expected short header packet, but got ", b[0])
+			}
+			if !bytes.Equal(b[1:9], destination) {
+				t.Error("unexpected destination connection ID: ", b[1:9])
+			}
+			continue
+		}
+
+		if v := binary.BigEndian.Uint32(b[1:]); v != 1 {
+			t.Error("unexpected version: ", v)
+		}
+		if b[5] != 8 || b[14] != 8 {
+			t.Error("unexpected connection ID length: ", b[5], " ", b[14])
+		}
+		if destination == nil {
+			destination = append([]byte(nil), b[6:14]...)
+		} else if !bytes.Equal(b[6:14], destination) {
+			t.Error("destination connection ID changed: ", b[6:14])
+		}
+	}
+}
+
+func TestQUICHeaderLength(t *testing.T) {
+	header, err := New(context.Background(), &Config{})
+	common.Must(err)
+
+	for i, payloadSize := range []int{100, 1300, 100, 1300, 100, 1300} {
+		b := make([]byte, header.Size())
+		padding := internet.SerializePacketHeader(header, b, payloadSize)
+		packet := append(b, make([]byte, payloadSize+padding)...)
+
+		if i < 2 {
+			if payloadSize < 1200 && len(packet) != 1200 {
+				t.Error("Initial packet is not padded to 1200 bytes: ", len(packet))
+			}
+			if payloadSize >= 1200 && padding != 0 {
+				t.Error("unexpected padding: ", padding)
+			}
+		} else if padding != 0 {
+			t.Error("unexpected padding: ", padding)
+		}
+
+		if i < 4 {
+			offset := 23
+			if i < 2 {
+				offset = 24
+			}
+			length := int(binary.BigEndian.Uint16(packet[offset:]) & 0x3FFF)
+			if offset+2+length != len(packet) {
+				t.Error("length ", length, " doesn't match packet size ", len(packet))
+			}
+		}
+
+		if payload := internet.PacketPayload(header, packet); len(payload) != payloadSize {
+			t.Error("expected payload size ", payloadSize, ", but got ", len(payload))
+		}
+	}
+}
+
+func TestQUICHeaderSessions(t *testing.T) {
+	header, err := New(context.Background(), &Config{})
+	common.Must(err)
+	sessions := internet.NewPacketHeaderSessions(header)
+
+	clients := make([][]byte, 2)
+	for i, peer := range []string{"127.0.0.1:1000", "127.0.0.1:1001"} {
+		b := make([]byte, header.Size())
+		sessions.Get(peer).Serialize(b)
+		if b[0]&0xF0 != 0xC0 {
+			t.Error("expected Initial packet for ", peer, ", but got ", b[0])
+		}
+		clients[i] = b
+	}
+	if bytes.Equal(clients[0][15:23], clients[1][15:23]) {
+		t.Error("sessions share the same connection ID")
+	}
+
+	client, err := New(context.Background(), &Config{})
+	common.Must(err)
+	request := make([]byte, header.Size()+100)
+	client.Serialize(request)
+	sessions.Receive("127.0.0.1:1002", request)
+
+	server := sessions.Get("127.0.0.1:1002")
+	for i := 0; i < 5; i++ {
+		server.Serialize(make([]byte, header.Size()))
+	}
+	b := make([]byte, header.Size())
+	server.Serialize(b)
+	if !bytes.Equal(b[1:9], request[15:23]) {
+		t.Error("short header doesn't carry the connection ID of the client")
+	}
+}
@@ -40,6 +40,14 @@ func (c *Config) getNormalizedPath() string {
 	return c.Path
 }
 
+// Validate checks whether the settings are valid.
+func (c *Config) Validate() error {
+	if _, err := internet.ParseTrustedProxies(c.TrustedProxy); err != nil {
+		return newError("invalid trusted_proxy").Base(err)
+	}
+	return nil
+}
+
 func init() {
 	common.Must(internet.RegisterProtocolConfigCreatorByName(protocolName, func() interface{} {
 		return new(Config)
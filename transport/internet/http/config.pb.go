@@ -18,8 +18,12 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Config struct {
-	Host                 []string `protobuf:"bytes,1,rep,name=host,proto3" json:"host,omitempty"`
-	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Host []string `protobuf:"bytes,1,rep,name=host,proto3" json:"host,omitempty"`
+	Path string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// IPs or CIDRs of reverse proxies in front of the server, such as CDNs. For
+	// connections from them, the client address is taken from PROXY protocol
+	// header, X-Forwarded-For or X-Real-IP.
+	TrustedProxy         []string `protobuf:"bytes,3,rep,name=trusted_proxy,json=trustedProxy,proto3" json:"trusted_proxy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -64,6 +68,13 @@ func (m *Config) GetPath() string {
 	return ""
 }
 
+func (m *Config) GetTrustedProxy() []string {
+	if m != nil {
+		return m.TrustedProxy
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Config)(nil), "v2ray.core.transport.internet.http.Config")
 }
@@ -73,16 +84,18 @@ func init() {
 }
 
 var fileDescriptor_18c29e00ea34cfae = []byte{
-	// 173 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x2e, 0x33, 0x2a, 0x4a,
-	0xac, 0xd4, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xce, 0x2f, 0x4a, 0xd5, 0x2f, 0x29, 0x4a, 0xcc, 0x2b,
-	0x2e, 0xc8, 0x2f, 0x2a, 0xd1, 0xcf, 0xcc, 0x2b, 0x49, 0x2d, 0xca, 0x4b, 0x2d, 0xd1, 0xcf, 0x28,
-	0x29, 0x29, 0xd0, 0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
-	0x52, 0x82, 0x69, 0x2a, 0x4a, 0xd5, 0x83, 0x6b, 0xd0, 0x83, 0x69, 0xd0, 0x03, 0x69, 0x50, 0x32,
-	0xe0, 0x62, 0x73, 0x06, 0xeb, 0x11, 0x12, 0xe2, 0x62, 0xc9, 0xc8, 0x2f, 0x2e, 0x91, 0x60, 0x54,
-	0x60, 0xd6, 0xe0, 0x0c, 0x02, 0xb3, 0x41, 0x62, 0x05, 0x89, 0x25, 0x19, 0x12, 0x4c, 0x0a, 0x8c,
-	0x20, 0x31, 0x10, 0xdb, 0x29, 0x94, 0x4b, 0x2d, 0x39, 0x3f, 0x57, 0x8f, 0xb0, 0xd9, 0x01, 0x8c,
-	0x51, 0x2c, 0x20, 0x7a, 0x15, 0x93, 0x52, 0x98, 0x51, 0x50, 0x62, 0xa5, 0x9e, 0x33, 0x48, 0x71,
-	0x08, 0x5c, 0xb1, 0x27, 0x4c, 0xb1, 0x47, 0x49, 0x49, 0x41, 0x12, 0x1b, 0xd8, 0xcd, 0xc6, 0x80,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xdc, 0xf2, 0x95, 0x63, 0xea, 0x00, 0x00, 0x00,
+	// 199 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x8f, 0xbd, 0x6a, 0xc3, 0x30,
+	0x14, 0x46, 0xf1, 0x0f, 0x86, 0x8a, 0x76, 0xd1, 0xe4, 0xd1, 0xb8, 0x50, 0x3a, 0x49, 0x60, 0xbf,
+	0x41, 0xbd, 0xb4, 0x9b, 0x31, 0x75, 0x87, 0x2e, 0x41, 0x51, 0x94, 0xd8, 0x83, 0x75, 0xc5, 0xf5,
+	0x4d, 0x88, 0x5f, 0x29, 0x4f, 0x19, 0x24, 0x62, 0xaf, 0x99, 0xf4, 0x71, 0x38, 0x07, 0x74, 0x59,
+	0x7d, 0xa9, 0x50, 0x2d, 0x42, 0xc3, 0x24, 0x35, 0xa0, 0x91, 0x84, 0xca, 0xce, 0x0e, 0x90, 0xe4,
+	0x68, 0xc9, 0xa0, 0x35, 0x24, 0x07, 0x22, 0x27, 0x35, 0xd8, 0xe3, 0x78, 0x12, 0x0e, 0x81, 0x80,
+	0x97, 0x6b, 0x84, 0x46, 0x6c, 0x81, 0x58, 0x03, 0xe1, 0x83, 0xb2, 0x67, 0x59, 0x13, 0x1a, 0xce,
+	0x59, 0x3a, 0xc0, 0x4c, 0x79, 0x54, 0x24, 0x9f, 0x2f, 0x5d, 0xd8, 0x9e, 0x39, 0x45, 0x43, 0x1e,
+	0x17, 0x91, 0x67, 0x7e, 0xf3, 0x77, 0xf6, 0x46, 0x78, 0x9e, 0xc9, 0x1c, 0x76, 0x0e, 0xe1, 0xba,
+	0xe4, 0x49, 0x08, 0x5e, 0x1f, 0xb0, 0xf5, 0xec, 0xab, 0x67, 0x1f, 0x1a, 0x26, 0xf1, 0xfc, 0x03,
+	0x6d, 0xf4, 0x9f, 0xfa, 0xf7, 0x16, 0x97, 0x7f, 0x55, 0xa7, 0x16, 0xd1, 0x78, 0xf9, 0x77, 0x93,
+	0x7f, 0x56, 0xf9, 0x9b, 0xc8, 0xed, 0xb3, 0x70, 0x58, 0x7d, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x0a,
+	0x80, 0x1e, 0x65, 0x0f, 0x01, 0x00, 0x00,
 }
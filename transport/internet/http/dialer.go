@@ -16,8 +16,14 @@ import (
 	"v2ray.com/core/transport/pipe"
 )
 
+// dialerKey identifies HTTP clients. Clients that open raw connections with different dialers are not shared.
+type dialerKey struct {
+	dest   net.Destination
+	dialer internet.SystemDialer
+}
+
 var (
-	globalDialerMap    map[net.Destination]*http.Client
+	globalDialerMap    map[dialerKey]*http.Client
 	globalDailerAccess sync.Mutex
 )
 
@@ -26,12 +32,15 @@ func getHTTPClient(ctx context.Context, dest net.Destination, tlsSettings *tls.C
 	defer globalDailerAccess.Unlock()
 
 	if globalDialerMap == nil {
-		globalDialerMap = make(map[net.Destination]*http.Client)
+		globalDialerMap = make(map[dialerKey]*http.Client)
 	}
 
-	if client, found := globalDialerMap[dest]; found {
+	key := dialerKey{dest: dest, dialer: internet.SystemDialerFromContext(ctx)}
+	if client, found := globalDialerMap[key]; found {
 		return client, nil
 	}
+	// Connections are shared by requests, so they don't live within the context of any request.
+	dialCtx := internet.ContextWithSystemDialer(context.Background(), key.dialer)
 
 	transport := &http2.Transport{
 		DialTLS: func(network string, addr string, tlsConfig *gotls.Config) (net.Conn, error) {
@@ -48,10 +57,13 @@ func getHTTPClient(ctx context.Context, dest net.Destination, tlsSettings *tls.C
 			}
 			address := net.ParseAddress(rawHost)
 
-			pconn, err := internet.DialSystem(context.Background(), net.TCPDestination(address, port), nil)
+			pconn, err := internet.DialSystem(dialCtx, net.TCPDestination(address, port), nil)
 			if err != nil {
 				return nil, err
 			}
+			if fingerprint := tls.GetFingerprint(tlsSettings.Fingerprint); fingerprint != nil {
+				return tls.UClient(pconn, tlsConfig, fingerprint), nil
+			}
 			return gotls.Client(pconn, tlsConfig), nil
 		},
 		TLSClientConfig: tlsSettings.GetTLSConfig(tls.WithDestination(dest), tls.WithNextProto("h2")),
@@ -61,7 +73,7 @@ func getHTTPClient(ctx context.Context, dest net.Destination, tlsSettings *tls.C
 		Transport: transport,
 	}
 
-	globalDialerMap[dest] = client
+	globalDialerMap[key] = client
 	return client, nil
 }
 
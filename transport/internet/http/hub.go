@@ -12,6 +12,7 @@ import (
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/common/signal/done"
 	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/proxyproto"
 	"v2ray.com/core/transport/internet/tls"
 )
 
@@ -20,6 +21,7 @@ type Listener struct {
 	handler internet.ConnHandler
 	local   net.Addr
 	config  Config
+	trusted internet.TrustedProxies
 }
 
 func (l *Listener) Addr() net.Addr {
@@ -71,7 +73,7 @@ func (l *Listener) ServeHTTP(writer http.ResponseWriter, request *http.Request)
 		newError("failed to parse request remote addr: ", request.RemoteAddr).Base(err).WriteToLog()
 	} else {
 		remoteAddr = &net.TCPAddr{
-			IP:   dest.Address.IP(),
+			IP:   l.trusted.ClientIP(dest.Address.IP(), request.Header),
 			Port: int(dest.Port),
 		}
 	}
@@ -84,19 +86,24 @@ func (l *Listener) ServeHTTP(writer http.ResponseWriter, request *http.Request)
 		net.ConnectionLocalAddr(l.Addr()),
 		net.ConnectionRemoteAddr(remoteAddr),
 	)
-	l.handler(conn)
+	l.handler(tls.WithVerifiedUser(conn, request.TLS))
 	<-done.Wait()
 }
 
 func Listen(ctx context.Context, address net.Address, port net.Port, streamSettings *internet.MemoryStreamConfig, handler internet.ConnHandler) (internet.Listener, error) {
 	httpSettings := streamSettings.ProtocolSettings.(*Config)
+	trusted, err := internet.ParseTrustedProxies(httpSettings.TrustedProxy)
+	if err != nil {
+		return nil, newError("invalid trusted_proxy").Base(err)
+	}
 	listener := &Listener{
 		handler: handler,
 		local: &net.TCPAddr{
 			IP:   address.IP(),
 			Port: int(port),
 		},
-		config: *httpSettings,
+		config:  *httpSettings,
+		trusted: trusted,
 	}
 
 	config := tls.ConfigFromStreamSettings(streamSettings)
@@ -120,6 +127,9 @@ func Listen(ctx context.Context, address net.Address, port net.Port, streamSetti
 			newError("failed to listen on", address, ":", port).Base(err).WriteToLog(session.ExportIDToError(ctx))
 			return
 		}
+		if len(trusted) > 0 {
+			tcpListener = proxyproto.NewListener(tcpListener, trusted.Contains)
+		}
 
 		err = server.ServeTLS(tcpListener, "", "")
 		if err != nil {
@@ -0,0 +1,155 @@
+package kcp
+
+type bbrState int
+
+const (
+	bbrStartup bbrState = iota
+	bbrDrain
+	bbrProbeBandwidth
+)
+
+const (
+	bbrStartupGain       = 2.89
+	bbrDrainGain         = 1 / bbrStartupGain
+	bbrCwndGain          = 2
+	bbrMinWindow         = 16
+	bbrMinRTTExpiration  = 10000 // milliseconds
+	bbrBandwidthRounds   = 10
+	bbrFullBandwidthGain = 1.25
+	bbrFullRounds        = 3
+)
+
+var bbrPacingGains = [...]float64{1.25, 0.75, 1, 1, 1, 1, 1, 1}
+
+// bbrControl estimates the bottleneck bandwidth and minimum round trip time of the path, and keeps the window around
+// their product, in the way of BBR. Unlike loss-based algorithms, it doesn't back off on random packet loss.
+type bbrControl struct {
+	state  bbrState
+	window uint32
+
+	minRTT          uint32
+	minRTTTimestamp uint32
+
+	// A round is a period of minRTT, in which delivery rate is sampled.
+	delivered      uint32
+	round          uint32
+	roundStart     uint32
+	roundDelivered uint32
+	// Delivery rates in segments per millisecond of the recent rounds.
+	bandwidth [bbrBandwidthRounds]float64
+
+	fullBandwidth       float64
+	fullBandwidthRounds int
+	cycleIndex          int
+}
+
+func newBBRControl(config *Config) CongestionControl {
+	return &bbrControl{
+		window: config.GetSendingInFlightSize(),
+	}
+}
+
+func (c *bbrControl) maxBandwidth() float64 {
+	var max float64
+	for _, bw := range c.bandwidth {
+		if bw > max {
+			max = bw
+		}
+	}
+	return max
+}
+
+func (c *bbrControl) OnAck(current uint32, rtt uint32) {
+	if rtt > 0 && (c.minRTT == 0 || rtt <= c.minRTT || current-c.minRTTTimestamp > bbrMinRTTExpiration) {
+		c.minRTT = rtt
+		c.minRTTTimestamp = current
+	}
+
+	c.delivered++
+	c.updateWindow()
+	if c.minRTT == 0 {
+		return
+	}
+	if c.round == 0 && c.roundStart == 0 {
+		c.roundStart = current
+		c.roundDelivered = c.delivered
+		c.round = 1
+		return
+	}
+
+	interval := current - c.roundStart
+	if interval < c.minRTT || interval == 0 {
+		return
+	}
+	c.bandwidth[c.round%bbrBandwidthRounds] = float64(c.delivered-c.roundDelivered) / float64(interval)
+	c.round++
+	c.roundStart = current
+	c.roundDelivered = c.delivered
+	c.onRoundEnd()
+}
+
+func (c *bbrControl) onRoundEnd() {
+	switch c.state {
+	case bbrStartup:
+		bw := c.maxBandwidth()
+		if bw >= c.fullBandwidth*bbrFullBandwidthGain {
+			c.fullBandwidth = bw
+			c.fullBandwidthRounds = 0
+			return
+		}
+		c.fullBandwidthRounds++
+		if c.fullBandwidthRounds >= bbrFullRounds {
+			c.state = bbrDrain
+		}
+	case bbrDrain:
+		c.state = bbrProbeBandwidth
+		c.cycleIndex = 0
+	case bbrProbeBandwidth:
+		c.cycleIndex = (c.cycleIndex + 1) % len(bbrPacingGains)
+	}
+}
+
+// OnPacketLoss implements CongestionControl. Loss is not a signal of congestion for BBR.
+func (*bbrControl) OnPacketLoss(current uint32, lossRate uint32) {}
+
+// updateWindow grows the window by one segment for each acknowledged segment, which doubles it every round trip.
+// The window grows without bound in startup, and is kept at the target afterwards.
+func (c *bbrControl) updateWindow() {
+	if c.state == bbrStartup {
+		c.window++
+		return
+	}
+	target := c.targetWindow()
+	if c.window < target {
+		c.window++
+	} else {
+		c.window = target
+	}
+}
+
+// targetWindow returns the estimated bandwidth-delay product of the path multiplied by the gain of current state,
+// after startup.
+func (c *bbrControl) targetWindow() uint32 {
+	bw := c.maxBandwidth()
+	if c.minRTT == 0 || bw == 0 {
+		return c.window
+	}
+
+	bdp := bw * float64(c.minRTT)
+	var gain float64
+	switch c.state {
+	case bbrDrain:
+		gain = bbrDrainGain
+	default:
+		gain = bbrCwndGain * bbrPacingGains[c.cycleIndex]
+	}
+	return uint32(bdp * gain)
+}
+
+// Window implements CongestionControl.
+func (c *bbrControl) Window(current uint32) uint32 {
+	if c.window < bbrMinWindow {
+		return bbrMinWindow
+	}
+	return c.window
+}
@@ -1,7 +1,9 @@
 package kcp
 
 import (
+	"crypto/aes"
 	"crypto/cipher"
+	"crypto/sha256"
 
 	"v2ray.com/core/common"
 	"v2ray.com/core/transport/internet"
@@ -58,7 +60,15 @@ func (c *Config) GetReadBufferSize() uint32 {
 }
 
 // GetSecurity returns the security settings.
-func (*Config) GetSecurity() (cipher.AEAD, error) {
+func (c *Config) GetSecurity() (cipher.AEAD, error) {
+	if c.Seed != nil && len(c.Seed.Seed) > 0 {
+		key := sha256.Sum256([]byte(c.Seed.Seed))
+		block, err := aes.NewCipher(key[:16])
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	}
 	return NewSimpleAuthenticator(), nil
 }
 
@@ -74,6 +84,19 @@ func (c *Config) GetPackerHeader() (internet.PacketHeader, error) {
 	return nil, nil
 }
 
+// GetServerPacketHeader returns the packet header for packets sent by servers.
+func (c *Config) GetServerPacketHeader() (internet.PacketHeader, error) {
+	if c.HeaderConfig != nil {
+		rawConfig, err := c.HeaderConfig.GetInstance()
+		if err != nil {
+			return nil, err
+		}
+
+		return internet.CreateServerPacketHeader(rawConfig)
+	}
+	return nil, nil
+}
+
 func (c *Config) GetSendingInFlightSize() uint32 {
 	size := c.GetUplinkCapacityValue() * 1024 * 1024 / c.GetMTUValue() / (1000 / c.GetTTIValue())
 	if size < 8 {
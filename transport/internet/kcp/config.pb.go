@@ -258,6 +258,97 @@ func (m *ReadBuffer) GetSize() uint32 {
 	return 0
 }
 
+// Seed of encryption key. Segments are encrypted with AES-128-GCM using a key
+// derived from the seed.
+type EncryptionSeed struct {
+	Seed                 string   `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptionSeed) Reset()         { *m = EncryptionSeed{} }
+func (m *EncryptionSeed) String() string { return proto.CompactTextString(m) }
+func (*EncryptionSeed) ProtoMessage()    {}
+func (*EncryptionSeed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3746d5d763e81577, []int{6}
+}
+
+func (m *EncryptionSeed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptionSeed.Unmarshal(m, b)
+}
+func (m *EncryptionSeed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptionSeed.Marshal(b, m, deterministic)
+}
+func (m *EncryptionSeed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptionSeed.Merge(m, src)
+}
+func (m *EncryptionSeed) XXX_Size() int {
+	return xxx_messageInfo_EncryptionSeed.Size(m)
+}
+func (m *EncryptionSeed) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptionSeed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptionSeed proto.InternalMessageInfo
+
+func (m *EncryptionSeed) GetSeed() string {
+	if m != nil {
+		return m.Seed
+	}
+	return ""
+}
+
+// Reed-Solomon forward error correction. Every data_shards packets are
+// followed by parity_shards packets, with which the receiver recovers lost
+// packets in the group.
+type ForwardErrorCorrection struct {
+	DataShards           uint32   `protobuf:"varint,1,opt,name=data_shards,json=dataShards,proto3" json:"data_shards,omitempty"`
+	ParityShards         uint32   `protobuf:"varint,2,opt,name=parity_shards,json=parityShards,proto3" json:"parity_shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForwardErrorCorrection) Reset()         { *m = ForwardErrorCorrection{} }
+func (m *ForwardErrorCorrection) String() string { return proto.CompactTextString(m) }
+func (*ForwardErrorCorrection) ProtoMessage()    {}
+func (*ForwardErrorCorrection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3746d5d763e81577, []int{7}
+}
+
+func (m *ForwardErrorCorrection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwardErrorCorrection.Unmarshal(m, b)
+}
+func (m *ForwardErrorCorrection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwardErrorCorrection.Marshal(b, m, deterministic)
+}
+func (m *ForwardErrorCorrection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwardErrorCorrection.Merge(m, src)
+}
+func (m *ForwardErrorCorrection) XXX_Size() int {
+	return xxx_messageInfo_ForwardErrorCorrection.Size(m)
+}
+func (m *ForwardErrorCorrection) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwardErrorCorrection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwardErrorCorrection proto.InternalMessageInfo
+
+func (m *ForwardErrorCorrection) GetDataShards() uint32 {
+	if m != nil {
+		return m.DataShards
+	}
+	return 0
+}
+
+func (m *ForwardErrorCorrection) GetParityShards() uint32 {
+	if m != nil {
+		return m.ParityShards
+	}
+	return 0
+}
+
 type ConnectionReuse struct {
 	Enable               bool     `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -269,7 +360,7 @@ func (m *ConnectionReuse) Reset()         { *m = ConnectionReuse{} }
 func (m *ConnectionReuse) String() string { return proto.CompactTextString(m) }
 func (*ConnectionReuse) ProtoMessage()    {}
 func (*ConnectionReuse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3746d5d763e81577, []int{6}
+	return fileDescriptor_3746d5d763e81577, []int{8}
 }
 
 func (m *ConnectionReuse) XXX_Unmarshal(b []byte) error {
@@ -298,24 +389,34 @@ func (m *ConnectionReuse) GetEnable() bool {
 }
 
 type Config struct {
-	Mtu                  *MTU                 `protobuf:"bytes,1,opt,name=mtu,proto3" json:"mtu,omitempty"`
-	Tti                  *TTI                 `protobuf:"bytes,2,opt,name=tti,proto3" json:"tti,omitempty"`
-	UplinkCapacity       *UplinkCapacity      `protobuf:"bytes,3,opt,name=uplink_capacity,json=uplinkCapacity,proto3" json:"uplink_capacity,omitempty"`
-	DownlinkCapacity     *DownlinkCapacity    `protobuf:"bytes,4,opt,name=downlink_capacity,json=downlinkCapacity,proto3" json:"downlink_capacity,omitempty"`
-	Congestion           bool                 `protobuf:"varint,5,opt,name=congestion,proto3" json:"congestion,omitempty"`
-	WriteBuffer          *WriteBuffer         `protobuf:"bytes,6,opt,name=write_buffer,json=writeBuffer,proto3" json:"write_buffer,omitempty"`
-	ReadBuffer           *ReadBuffer          `protobuf:"bytes,7,opt,name=read_buffer,json=readBuffer,proto3" json:"read_buffer,omitempty"`
-	HeaderConfig         *serial.TypedMessage `protobuf:"bytes,8,opt,name=header_config,json=headerConfig,proto3" json:"header_config,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Mtu              *MTU                 `protobuf:"bytes,1,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Tti              *TTI                 `protobuf:"bytes,2,opt,name=tti,proto3" json:"tti,omitempty"`
+	UplinkCapacity   *UplinkCapacity      `protobuf:"bytes,3,opt,name=uplink_capacity,json=uplinkCapacity,proto3" json:"uplink_capacity,omitempty"`
+	DownlinkCapacity *DownlinkCapacity    `protobuf:"bytes,4,opt,name=downlink_capacity,json=downlinkCapacity,proto3" json:"downlink_capacity,omitempty"`
+	Congestion       bool                 `protobuf:"varint,5,opt,name=congestion,proto3" json:"congestion,omitempty"`
+	WriteBuffer      *WriteBuffer         `protobuf:"bytes,6,opt,name=write_buffer,json=writeBuffer,proto3" json:"write_buffer,omitempty"`
+	ReadBuffer       *ReadBuffer          `protobuf:"bytes,7,opt,name=read_buffer,json=readBuffer,proto3" json:"read_buffer,omitempty"`
+	HeaderConfig     *serial.TypedMessage `protobuf:"bytes,8,opt,name=header_config,json=headerConfig,proto3" json:"header_config,omitempty"`
+	// Segments are obfuscated by a checksum and XOR, if seed is not set.
+	Seed *EncryptionSeed `protobuf:"bytes,10,opt,name=seed,proto3" json:"seed,omitempty"`
+	// Name of congestion control algorithm, "loss" or "bbr". If empty, "loss"
+	// is used when congestion is true, otherwise congestion control is off.
+	CongestionControl string `protobuf:"bytes,11,opt,name=congestion_control,json=congestionControl,proto3" json:"congestion_control,omitempty"`
+	// FEC is off if not set. It must be the same on client and server.
+	Fec *ForwardErrorCorrection `protobuf:"bytes,12,opt,name=fec,proto3" json:"fec,omitempty"`
+	// Publishes metrics of each connection to the stats manager, in addition
+	// to the aggregates of all connections.
+	ConnectionStats      bool     `protobuf:"varint,13,opt,name=connection_stats,json=connectionStats,proto3" json:"connection_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Config) Reset()         { *m = Config{} }
 func (m *Config) String() string { return proto.CompactTextString(m) }
 func (*Config) ProtoMessage()    {}
 func (*Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3746d5d763e81577, []int{7}
+	return fileDescriptor_3746d5d763e81577, []int{9}
 }
 
 func (m *Config) XXX_Unmarshal(b []byte) error {
@@ -392,6 +493,34 @@ func (m *Config) GetHeaderConfig() *serial.TypedMessage {
 	return nil
 }
 
+func (m *Config) GetSeed() *EncryptionSeed {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *Config) GetCongestionControl() string {
+	if m != nil {
+		return m.CongestionControl
+	}
+	return ""
+}
+
+func (m *Config) GetFec() *ForwardErrorCorrection {
+	if m != nil {
+		return m.Fec
+	}
+	return nil
+}
+
+func (m *Config) GetConnectionStats() bool {
+	if m != nil {
+		return m.ConnectionStats
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*MTU)(nil), "v2ray.core.transport.internet.kcp.MTU")
 	proto.RegisterType((*TTI)(nil), "v2ray.core.transport.internet.kcp.TTI")
@@ -399,6 +528,8 @@ func init() {
 	proto.RegisterType((*DownlinkCapacity)(nil), "v2ray.core.transport.internet.kcp.DownlinkCapacity")
 	proto.RegisterType((*WriteBuffer)(nil), "v2ray.core.transport.internet.kcp.WriteBuffer")
 	proto.RegisterType((*ReadBuffer)(nil), "v2ray.core.transport.internet.kcp.ReadBuffer")
+	proto.RegisterType((*EncryptionSeed)(nil), "v2ray.core.transport.internet.kcp.EncryptionSeed")
+	proto.RegisterType((*ForwardErrorCorrection)(nil), "v2ray.core.transport.internet.kcp.ForwardErrorCorrection")
 	proto.RegisterType((*ConnectionReuse)(nil), "v2ray.core.transport.internet.kcp.ConnectionReuse")
 	proto.RegisterType((*Config)(nil), "v2ray.core.transport.internet.kcp.Config")
 }
@@ -408,35 +539,43 @@ func init() {
 }
 
 var fileDescriptor_3746d5d763e81577 = []byte{
-	// 471 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5f, 0x6f, 0xd3, 0x3e,
-	0x14, 0x55, 0xd7, 0xae, 0xbf, 0xfe, 0x6e, 0xf7, 0xa7, 0x44, 0x08, 0x45, 0x20, 0xa1, 0xb5, 0x12,
-	0xd3, 0x78, 0xc0, 0x81, 0xee, 0x85, 0xe7, 0x95, 0x97, 0x32, 0x15, 0x81, 0x95, 0x82, 0xb4, 0x97,
-	0xe2, 0x3a, 0xb7, 0xc5, 0x6a, 0x63, 0x5b, 0x8e, 0xb3, 0xaa, 0x7c, 0x24, 0x3e, 0x0d, 0x1f, 0x09,
-	0xc5, 0x6e, 0xd6, 0xae, 0x68, 0x2c, 0x6f, 0x71, 0xee, 0x39, 0xc7, 0xd6, 0x39, 0xf7, 0x40, 0xff,
-	0xb6, 0x6f, 0xd8, 0x9a, 0x70, 0x95, 0x46, 0x5c, 0x19, 0x8c, 0xac, 0x61, 0x32, 0xd3, 0xca, 0xd8,
-	0x48, 0x48, 0x8b, 0x46, 0xa2, 0x8d, 0x16, 0x5c, 0x47, 0x5c, 0xc9, 0x99, 0x98, 0x13, 0x6d, 0x94,
-	0x55, 0x41, 0xb7, 0xe4, 0x18, 0x24, 0x77, 0x78, 0x52, 0xe2, 0xc9, 0x82, 0xeb, 0xe7, 0x6f, 0xf7,
-	0x64, 0xb9, 0x4a, 0x53, 0x25, 0xa3, 0x0c, 0x8d, 0x60, 0xcb, 0xc8, 0xae, 0x35, 0x26, 0x93, 0x14,
-	0xb3, 0x8c, 0xcd, 0xd1, 0x8b, 0xf6, 0x5e, 0x40, 0x7d, 0x14, 0x8f, 0x83, 0xa7, 0x70, 0x78, 0xcb,
-	0x96, 0x39, 0x86, 0xb5, 0xb3, 0xda, 0xc5, 0x31, 0xf5, 0x87, 0x62, 0x18, 0xc7, 0xc3, 0x07, 0x86,
-	0xe7, 0x70, 0x32, 0xd6, 0x4b, 0x21, 0x17, 0x03, 0xa6, 0x19, 0x17, 0x76, 0xfd, 0x00, 0xee, 0x02,
-	0x3a, 0x1f, 0xd4, 0x4a, 0x56, 0x40, 0x76, 0xa1, 0xfd, 0xcd, 0x08, 0x8b, 0x57, 0xf9, 0x6c, 0x86,
-	0x26, 0x08, 0xa0, 0x91, 0x89, 0x9f, 0x25, 0xc6, 0x7d, 0xf7, 0xce, 0x00, 0x28, 0xb2, 0xe4, 0x1f,
-	0x88, 0xd7, 0x70, 0x3a, 0x50, 0x52, 0x22, 0xb7, 0x42, 0x49, 0x8a, 0x79, 0x86, 0xc1, 0x33, 0x68,
-	0xa2, 0x64, 0xd3, 0xa5, 0x07, 0xb6, 0xe8, 0xe6, 0xd4, 0xfb, 0xdd, 0x80, 0xe6, 0xc0, 0x39, 0x1c,
-	0xbc, 0x87, 0x7a, 0x6a, 0x73, 0x37, 0x6f, 0xf7, 0xcf, 0xc9, 0xa3, 0x4e, 0x93, 0x51, 0x3c, 0xa6,
-	0x05, 0xa5, 0x60, 0x5a, 0x2b, 0xc2, 0x83, 0xca, 0xcc, 0x38, 0x1e, 0xd2, 0x82, 0x12, 0xdc, 0xc0,
-	0x69, 0xee, 0x0c, 0x9c, 0xf0, 0x8d, 0x2f, 0x61, 0xdd, 0xa9, 0xbc, 0xab, 0xa0, 0x72, 0xdf, 0x7a,
-	0x7a, 0x92, 0xdf, 0x8f, 0xe2, 0x3b, 0x3c, 0x49, 0x36, 0xa6, 0x6f, 0xd5, 0x1b, 0x4e, 0xfd, 0xb2,
-	0x82, 0xfa, 0x7e, 0x60, 0xb4, 0x93, 0xec, 0x47, 0xf8, 0x12, 0x80, 0x2b, 0x39, 0xc7, 0xac, 0xf0,
-	0x39, 0x3c, 0x74, 0xc6, 0xee, 0xfc, 0x09, 0xbe, 0xc0, 0xd1, 0xaa, 0x08, 0x73, 0x32, 0x75, 0x59,
-	0x85, 0x4d, 0x77, 0x39, 0xa9, 0x70, 0xf9, 0xce, 0x0e, 0xd0, 0xf6, 0x6a, 0x67, 0x21, 0x3e, 0x41,
-	0xdb, 0x20, 0x4b, 0x4a, 0xc5, 0xff, 0x9c, 0xe2, 0x9b, 0x0a, 0x8a, 0xdb, 0x95, 0xa1, 0x60, 0xb6,
-	0xeb, 0x73, 0x0d, 0xc7, 0x3f, 0x90, 0x25, 0x68, 0x26, 0xbe, 0x67, 0x61, 0xeb, 0xef, 0x10, 0x7d,
-	0x83, 0x88, 0x6f, 0x10, 0x89, 0x8b, 0x06, 0x8d, 0x7c, 0x81, 0xe8, 0x91, 0x27, 0xfb, 0x0d, 0xfa,
-	0xd8, 0x68, 0xfd, 0xdf, 0x81, 0x2b, 0x0a, 0xaf, 0xb8, 0x4a, 0x1f, 0x7f, 0xd2, 0xe7, 0xda, 0x4d,
-	0x7d, 0xc1, 0xf5, 0xaf, 0x83, 0xee, 0xd7, 0x3e, 0x65, 0x6b, 0x32, 0x28, 0xa0, 0xf1, 0x1d, 0x74,
-	0x58, 0x42, 0xaf, 0xb9, 0x9e, 0x36, 0x5d, 0x53, 0x2f, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x54,
-	0xdd, 0xba, 0xf9, 0x34, 0x04, 0x00, 0x00,
+	// 607 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5d, 0x4f, 0x13, 0x41,
+	0x14, 0x0d, 0x14, 0x2a, 0xdc, 0xb6, 0x50, 0x26, 0x86, 0x6c, 0x34, 0x51, 0xa8, 0x4a, 0xe0, 0x81,
+	0x5d, 0x2d, 0x2f, 0xfa, 0x4a, 0xc5, 0x04, 0x09, 0x46, 0x87, 0x45, 0x13, 0x1e, 0xac, 0xc3, 0xec,
+	0x2d, 0x6c, 0xda, 0xce, 0x6c, 0xee, 0x4e, 0x69, 0xea, 0x4f, 0xf2, 0x47, 0xf8, 0xdb, 0xcc, 0xcc,
+	0xf4, 0x03, 0xaa, 0xc8, 0xbe, 0xcd, 0xdc, 0x7b, 0xee, 0xb9, 0x9b, 0x39, 0xe7, 0x2c, 0x34, 0x6f,
+	0x9a, 0x24, 0x46, 0xa1, 0xd4, 0xfd, 0x48, 0x6a, 0xc2, 0xc8, 0x90, 0x50, 0x79, 0xa6, 0xc9, 0x44,
+	0xa9, 0x32, 0x48, 0x0a, 0x4d, 0xd4, 0x95, 0x59, 0x24, 0xb5, 0xea, 0xa4, 0x57, 0x61, 0x46, 0xda,
+	0x68, 0xb6, 0x3d, 0x99, 0x21, 0x0c, 0xa7, 0xf8, 0x70, 0x82, 0x0f, 0xbb, 0x32, 0x7b, 0xf2, 0x7a,
+	0x8e, 0x56, 0xea, 0x7e, 0x5f, 0xab, 0x28, 0x47, 0x4a, 0x45, 0x2f, 0x32, 0xa3, 0x0c, 0x93, 0x76,
+	0x1f, 0xf3, 0x5c, 0x5c, 0xa1, 0x27, 0x6d, 0x3c, 0x85, 0xd2, 0x69, 0x7c, 0xce, 0x1e, 0xc3, 0xf2,
+	0x8d, 0xe8, 0x0d, 0x30, 0x58, 0xd8, 0x5a, 0xd8, 0xad, 0x71, 0x7f, 0xb1, 0xcd, 0x38, 0x3e, 0xbe,
+	0xa7, 0xb9, 0x03, 0x6b, 0xe7, 0x59, 0x2f, 0x55, 0xdd, 0x96, 0xc8, 0x84, 0x4c, 0xcd, 0xe8, 0x1e,
+	0xdc, 0x2e, 0xd4, 0xdf, 0xeb, 0xa1, 0x2a, 0x80, 0xdc, 0x86, 0xca, 0x37, 0x4a, 0x0d, 0x1e, 0x0e,
+	0x3a, 0x1d, 0x24, 0xc6, 0x60, 0x29, 0x4f, 0x7f, 0x4e, 0x30, 0xee, 0xdc, 0xd8, 0x02, 0xe0, 0x28,
+	0x92, 0xff, 0x20, 0x5e, 0xc2, 0xda, 0x91, 0x92, 0x34, 0xca, 0x4c, 0xaa, 0xd5, 0x19, 0x62, 0xe2,
+	0x50, 0x88, 0x89, 0x43, 0xad, 0x72, 0x77, 0x6e, 0x7c, 0x87, 0xcd, 0x0f, 0x9a, 0x86, 0x82, 0x92,
+	0x23, 0x22, 0x4d, 0x2d, 0x4d, 0x84, 0xd2, 0x4e, 0xb0, 0xe7, 0x50, 0x49, 0x84, 0x11, 0xed, 0xfc,
+	0x5a, 0x50, 0x92, 0x8f, 0xa9, 0xc1, 0x96, 0xce, 0x5c, 0x85, 0xbd, 0x80, 0x5a, 0x26, 0x28, 0x35,
+	0xa3, 0x09, 0x64, 0xd1, 0x41, 0xaa, 0xbe, 0xe8, 0x41, 0x8d, 0x3d, 0x58, 0x6f, 0x69, 0xa5, 0x3c,
+	0x27, 0xc7, 0x41, 0x8e, 0x6c, 0x13, 0xca, 0xa8, 0xc4, 0x65, 0xcf, 0x7f, 0xee, 0x0a, 0x1f, 0xdf,
+	0x1a, 0xbf, 0xcb, 0x50, 0x6e, 0x39, 0x9d, 0xd9, 0x5b, 0x28, 0xf5, 0xcd, 0xc0, 0xf5, 0x2b, 0xcd,
+	0x9d, 0xf0, 0x41, 0xbd, 0xc3, 0xd3, 0xf8, 0x9c, 0xdb, 0x11, 0x3b, 0x69, 0x4c, 0x1a, 0x2c, 0x16,
+	0x9e, 0x8c, 0xe3, 0x63, 0x6e, 0x47, 0xd8, 0x05, 0xac, 0x0f, 0x9c, 0x8c, 0x6d, 0x39, 0x56, 0x27,
+	0x28, 0x39, 0x96, 0x37, 0x05, 0x58, 0xee, 0x1a, 0x80, 0xaf, 0x0d, 0xee, 0x1a, 0xe2, 0x07, 0x6c,
+	0x24, 0x63, 0xe9, 0x67, 0xec, 0x4b, 0x8e, 0xfd, 0xa0, 0x00, 0xfb, 0xbc, 0x6d, 0x78, 0x3d, 0x99,
+	0x37, 0xd2, 0x33, 0x00, 0xa9, 0xd5, 0x15, 0xe6, 0xf6, 0x9d, 0x83, 0x65, 0xf7, 0xb0, 0xb7, 0x2a,
+	0xec, 0x0b, 0x54, 0x87, 0xd6, 0x52, 0xed, 0x4b, 0xe7, 0x98, 0xa0, 0xec, 0x96, 0x87, 0x05, 0x96,
+	0xdf, 0x72, 0x22, 0xaf, 0x0c, 0x67, 0x17, 0xf6, 0x09, 0x2a, 0x84, 0x22, 0x99, 0x30, 0x3e, 0x72,
+	0x8c, 0xfb, 0x05, 0x18, 0x67, 0xc6, 0xe5, 0x40, 0xd3, 0x33, 0x3b, 0x81, 0xda, 0x35, 0x8a, 0x04,
+	0xa9, 0xed, 0xd3, 0x1e, 0xac, 0xfc, 0x2d, 0xa2, 0xcf, 0x71, 0xe8, 0x73, 0x1c, 0xc6, 0x36, 0xc7,
+	0xa7, 0x3e, 0xc6, 0xbc, 0xea, 0x87, 0xc7, 0x0e, 0x3a, 0x1a, 0x7b, 0x1d, 0x0a, 0x4b, 0x78, 0x37,
+	0x2c, 0x3e, 0x1e, 0x6c, 0x1f, 0xd8, 0xec, 0x11, 0xed, 0x77, 0x19, 0xd2, 0xbd, 0xa0, 0xe2, 0x02,
+	0xb4, 0x31, 0xeb, 0xb4, 0x7c, 0x83, 0x9d, 0x40, 0xa9, 0x83, 0x32, 0xa8, 0xba, 0xa5, 0xef, 0x0a,
+	0x2c, 0xfd, 0x77, 0xf6, 0xb8, 0x65, 0x61, 0x7b, 0x50, 0x97, 0xd3, 0xe8, 0xb4, 0x73, 0x23, 0x4c,
+	0x1e, 0xd4, 0x9c, 0xb0, 0xeb, 0xb3, 0xfa, 0x99, 0x2d, 0x7f, 0x5c, 0x5a, 0x59, 0xad, 0xc3, 0x21,
+	0x87, 0x57, 0x52, 0xf7, 0x1f, 0xde, 0xfa, 0x79, 0xe1, 0xa2, 0xd4, 0x95, 0xd9, 0xaf, 0xc5, 0xed,
+	0xaf, 0x4d, 0x2e, 0x46, 0x61, 0xcb, 0x42, 0xe3, 0x29, 0xf4, 0x78, 0x02, 0x3d, 0x91, 0xd9, 0x65,
+	0xd9, 0xfd, 0x1d, 0x0f, 0xfe, 0x0c, 0x00, 0x45, 0xa5, 0xb6, 0xa0, 0xa8, 0x05, 0x00, 0x00,
 }
@@ -0,0 +1,97 @@
+package kcp
+
+import "v2ray.com/core/common"
+
+// CongestionControl limits the number of data segments in flight on a connection. Its methods are called with the
+// lock of SendingWorker held, so implementations don't need to be thread-safe.
+type CongestionControl interface {
+	// OnAck is called when a data segment is acknowledged. rtt is the round trip time in milliseconds, or 0 if unknown.
+	OnAck(current uint32, rtt uint32)
+	// OnPacketLoss is called after segments are sent, with the percentage of retransmitted ones.
+	OnPacketLoss(current uint32, lossRate uint32)
+	// Window returns the maximum number of segments in flight.
+	Window(current uint32) uint32
+}
+
+// CongestionControlCreator creates a CongestionControl for a connection.
+type CongestionControlCreator func(config *Config) CongestionControl
+
+var congestionControlCreators = make(map[string]CongestionControlCreator)
+
+// RegisterCongestionControl registers a congestion control algorithm by its name.
+func RegisterCongestionControl(name string, creator CongestionControlCreator) error {
+	if _, found := congestionControlCreators[name]; found {
+		return newError("congestion control ", name, " is already registered")
+	}
+	congestionControlCreators[name] = creator
+	return nil
+}
+
+func (c *Config) getCongestionControlName() string {
+	if len(c.CongestionControl) > 0 {
+		return c.CongestionControl
+	}
+	if c.Congestion {
+		return "loss"
+	}
+	return ""
+}
+
+// CreateCongestionControl returns a new instance of the congestion control algorithm, or nil if it is off.
+func (c *Config) CreateCongestionControl() (CongestionControl, error) {
+	name := c.getCongestionControlName()
+	if len(name) == 0 {
+		return nil, nil
+	}
+	creator, found := congestionControlCreators[name]
+	if !found {
+		return nil, newError("unknown congestion control: ", name)
+	}
+	return creator(c), nil
+}
+
+// Validate checks whether the settings are valid.
+func (c *Config) Validate() error {
+	if _, err := c.CreateCongestionControl(); err != nil {
+		return err
+	}
+	return validateFEC(c.Fec)
+}
+
+// lossControl shrinks the window when loss rate is high, and grows it when loss rate is low.
+type lossControl struct {
+	window    uint32
+	maxWindow uint32
+}
+
+func newLossControl(config *Config) CongestionControl {
+	return &lossControl{
+		window:    config.GetSendingInFlightSize(),
+		maxWindow: 2 * config.GetSendingInFlightSize(),
+	}
+}
+
+func (*lossControl) OnAck(current uint32, rtt uint32) {}
+
+func (c *lossControl) OnPacketLoss(current uint32, lossRate uint32) {
+	if lossRate >= 15 {
+		c.window = 3 * c.window / 4
+	} else if lossRate <= 5 {
+		c.window += c.window / 4
+	}
+	if c.window < 16 {
+		c.window = 16
+	}
+	if c.window > c.maxWindow {
+		c.window = c.maxWindow
+	}
+}
+
+func (c *lossControl) Window(current uint32) uint32 {
+	return c.window
+}
+
+func init() {
+	common.Must(RegisterCongestionControl("loss", newLossControl))
+	common.Must(RegisterCongestionControl("bbr", newBBRControl))
+}
@@ -0,0 +1,323 @@
+package kcp_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	mrand "math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/transport/internet"
+	. "v2ray.com/core/transport/internet/kcp"
+)
+
+// simulatedLink is an in-memory link that delivers packets to the peer after a delay, and drops some at random.
+type simulatedLink struct {
+	sync.Mutex
+	peer     *Connection
+	delay    time.Duration
+	lossRate float64
+	random   *mrand.Rand
+	monitor  *flowMonitor
+	client   bool
+}
+
+// flowMonitor checks that the client never sends a data segment beyond the receiving window last advertised by the
+// server. It sees packets when they are written, before they are delayed or dropped, so the client can't know a
+// larger window than the monitor.
+type flowMonitor struct {
+	sync.Mutex
+	advertised uint32
+	maxNumber  uint32
+	violations int
+}
+
+func (m *flowMonitor) observe(client bool, b []byte) {
+	var reader KCPPacketReader
+	segments := reader.Read(b)
+
+	m.Lock()
+	defer m.Unlock()
+	for _, seg := range segments {
+		switch seg := seg.(type) {
+		case *DataSegment:
+			if client {
+				if seg.Number >= m.advertised {
+					m.violations++
+				}
+				if seg.Number > m.maxNumber {
+					m.maxNumber = seg.Number
+				}
+			}
+		case *AckSegment:
+			if !client && seg.ReceivingWindow > m.advertised {
+				m.advertised = seg.ReceivingWindow
+			}
+		}
+		seg.Release()
+	}
+}
+
+func (*simulatedLink) Overhead() int {
+	return 0
+}
+
+func (l *simulatedLink) Write(b []byte) (int, error) {
+	l.monitor.observe(l.client, b)
+
+	l.Lock()
+	drop := l.random.Float64() < l.lossRate
+	peer := l.peer
+	l.Unlock()
+
+	if drop || peer == nil {
+		return len(b), nil
+	}
+
+	packet := append([]byte(nil), b...)
+	time.AfterFunc(l.delay, func() {
+		var reader KCPPacketReader
+		peer.Input(reader.Read(packet))
+	})
+	return len(b), nil
+}
+
+// newSimulatedPair creates two connections linked to each other through simulatedLinks.
+func newSimulatedPair(config *Config, clientMeta ConnMetadata, serverMeta ConnMetadata, rtt time.Duration, lossRate float64) (*Connection, *Connection) {
+	client, server, _ := newMonitoredPair(config, clientMeta, serverMeta, rtt, lossRate)
+	return client, server
+}
+
+// newMonitoredPair is newSimulatedPair, and also returns the flowMonitor of the pair.
+func newMonitoredPair(config *Config, clientMeta ConnMetadata, serverMeta ConnMetadata, rtt time.Duration, lossRate float64) (*Connection, *Connection, *flowMonitor) {
+	// The initial receiving window, as assumed by the sending worker before the first ack.
+	monitor := &flowMonitor{advertised: 32}
+	clientLink := &simulatedLink{delay: rtt / 2, lossRate: lossRate, random: mrand.New(mrand.NewSource(1)), monitor: monitor, client: true}
+	serverLink := &simulatedLink{delay: rtt / 2, lossRate: lossRate, random: mrand.New(mrand.NewSource(2)), monitor: monitor}
+	client := NewConnection(clientMeta, clientLink, NoOpCloser(0), config)
+	server := NewConnection(serverMeta, serverLink, NoOpCloser(0), config)
+	clientLink.Lock()
+	clientLink.peer = server
+	clientLink.Unlock()
+	serverLink.Lock()
+	serverLink.peer = client
+	serverLink.Unlock()
+	return client, server, monitor
+}
+
+func transfer(t *testing.T, client *Connection, server *Connection, size int) time.Duration {
+	payload := make([]byte, size)
+	common.Must2(rand.Read(payload))
+
+	start := time.Now()
+	go client.Write(payload)
+
+	common.Must(server.SetReadDeadline(time.Now().Add(time.Second * 30)))
+	received := make([]byte, size)
+	if _, err := io.ReadFull(server, received); err != nil {
+		t.Fatal("failed to receive payload: ", err)
+	}
+	elapsed := time.Since(start)
+
+	if !bytes.Equal(received, payload) {
+		t.Error("received payload doesn't match")
+	}
+	return elapsed
+}
+
+// TestTransferOnLossyLink transfers over a link that drops 10% of packets, with a fixed seed, in both directions. All
+// data must arrive, and congestion control must not send beyond the receiving window of the peer.
+func TestTransferOnLossyLink(t *testing.T) {
+	for _, tc := range []struct {
+		algorithm string
+		limited   bool
+	}{
+		{algorithm: "", limited: false},
+		{algorithm: "loss", limited: true},
+		{algorithm: "bbr", limited: true},
+	} {
+		client, server, monitor := newMonitoredPair(&Config{
+			Tti:               &TTI{Value: 20},
+			CongestionControl: tc.algorithm,
+		}, ConnMetadata{Conversation: 1}, ConnMetadata{Conversation: 1}, time.Millisecond*40, 0.1)
+		transfer(t, client, server, 128*1024)
+		client.Terminate()
+		server.Terminate()
+
+		monitor.Lock()
+		if tc.limited && monitor.violations > 0 {
+			t.Error("algorithm ", tc.algorithm, " sent ", monitor.violations, " segments beyond the receiving window ", monitor.advertised)
+		}
+		if monitor.maxNumber < 128*1024/1400 {
+			t.Error("algorithm ", tc.algorithm, " sent too few segments: ", monitor.maxNumber)
+		}
+		monitor.Unlock()
+	}
+}
+
+// TestCongestionControlOnRandomLoss checks that BBR, which doesn't back off on random loss, keeps a larger window than
+// the loss-based algorithm on a lossy link.
+func TestCongestionControlOnRandomLoss(t *testing.T) {
+	loss, err := (&Config{CongestionControl: "loss"}).CreateCongestionControl()
+	common.Must(err)
+	bbr, err := (&Config{CongestionControl: "bbr"}).CreateCongestionControl()
+	common.Must(err)
+
+	// The path delivers 1 segment per millisecond with 100ms round trip, and drops 20% of segments at random.
+	for current := uint32(1); current <= 5000; current++ {
+		loss.OnAck(current, 100)
+		bbr.OnAck(current, 100)
+		if current%20 == 0 {
+			loss.OnPacketLoss(current, 20)
+			bbr.OnPacketLoss(current, 20)
+		}
+	}
+
+	if window := bbr.Window(5000); window < 100 {
+		t.Error("bbr window ", window, " is below the BDP of 100 segments")
+	}
+	if loss.Window(5000) >= bbr.Window(5000) {
+		t.Error("loss window ", loss.Window(5000), " is not below bbr window ", bbr.Window(5000))
+	}
+}
+
+// recordingLink records the numbers of data segments written to it.
+type recordingLink struct {
+	sync.Mutex
+	numbers map[uint32]bool
+}
+
+func (*recordingLink) Overhead() int {
+	return 0
+}
+
+func (l *recordingLink) Write(b []byte) (int, error) {
+	var reader KCPPacketReader
+	segments := reader.Read(b)
+
+	l.Lock()
+	defer l.Unlock()
+	for _, seg := range segments {
+		if data, ok := seg.(*DataSegment); ok {
+			l.numbers[data.Number] = true
+		}
+		seg.Release()
+	}
+	return len(b), nil
+}
+
+func (l *recordingLink) Len() int {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.numbers)
+}
+
+func TestSendingWindowLimit(t *testing.T) {
+	// The peer doesn't respond, so its receiving window stays at the initial 32 segments.
+	for _, tc := range []struct {
+		algorithm string
+		limited   bool
+	}{
+		{algorithm: "", limited: false},
+		{algorithm: "loss", limited: true},
+		{algorithm: "bbr", limited: true},
+	} {
+		link := &recordingLink{numbers: make(map[uint32]bool)}
+		conn := NewConnection(ConnMetadata{Conversation: 1}, link, NoOpCloser(0), &Config{
+			Tti:               &TTI{Value: 20},
+			CongestionControl: tc.algorithm,
+		})
+		common.Must2(conn.Write(make([]byte, 64*1024)))
+		time.Sleep(time.Millisecond * 200)
+		conn.Terminate()
+
+		sent := link.Len()
+		if tc.limited && sent > 32 {
+			t.Error("algorithm ", tc.algorithm, " sent ", sent, " segments beyond the receiving window")
+		}
+		if !tc.limited && sent <= 32 {
+			t.Error("algorithm ", tc.algorithm, " sent only ", sent, " segments")
+		}
+	}
+}
+
+func TestBBRWindow(t *testing.T) {
+	cc, err := (&Config{CongestionControl: "bbr"}).CreateCongestionControl()
+	common.Must(err)
+
+	// The path delivers 1 segment per millisecond with 50ms round trip, so its BDP is 50 segments.
+	for current := uint32(1); current <= 5000; current++ {
+		cc.OnAck(current, 50)
+		if current%10 == 0 {
+			cc.OnPacketLoss(current, 30)
+		}
+	}
+
+	var min, max uint32 = 0xFFFFFFFF, 0
+	for current := uint32(5001); current <= 5500; current++ {
+		cc.OnAck(current, 50)
+		window := cc.Window(current)
+		if window < min {
+			min = window
+		}
+		if window > max {
+			max = window
+		}
+	}
+	if min < 50 || max > 2*50*5/4 {
+		t.Error("window out of range: [", min, ", ", max, "]")
+	}
+}
+
+func TestLossWindow(t *testing.T) {
+	config := &Config{Congestion: true}
+	cc, err := config.CreateCongestionControl()
+	common.Must(err)
+
+	initial := cc.Window(0)
+	cc.OnPacketLoss(0, 20)
+	if window := cc.Window(0); window != initial*3/4 {
+		t.Error("expect window ", initial*3/4, ", but got ", window)
+	}
+
+	for i := 0; i < 20; i++ {
+		cc.OnPacketLoss(0, 0)
+	}
+	if window := cc.Window(0); window != 2*config.GetSendingInFlightSize() {
+		t.Error("expect window ", 2*config.GetSendingInFlightSize(), ", but got ", window)
+	}
+}
+
+func TestUnknownCongestionControl(t *testing.T) {
+	config := &Config{CongestionControl: "cubic"}
+	if err := config.Validate(); err == nil {
+		t.Error("expect error for unknown congestion control")
+	}
+
+	if _, err := internet.ToMemoryStreamConfig(&internet.StreamConfig{
+		ProtocolName: "mkcp",
+		TransportSettings: []*internet.TransportConfig{{
+			ProtocolName: "mkcp",
+			Settings:     serial.ToTypedMessage(config),
+		}},
+	}); err == nil {
+		t.Error("expect error when building stream settings with unknown congestion control")
+	}
+
+	streamSettings := &internet.MemoryStreamConfig{
+		ProtocolName:     "mkcp",
+		ProtocolSettings: config,
+	}
+	if _, err := NewListener(context.Background(), net.LocalHostIP, net.Port(0), streamSettings, func(internet.Connection) {}); err == nil {
+		t.Error("expect error when listening with unknown congestion control")
+	}
+	if _, err := DialKCP(context.Background(), net.UDPDestination(net.LocalHostIP, net.Port(0)), streamSettings); err == nil {
+		t.Error("expect error when dialing with unknown congestion control")
+	}
+}
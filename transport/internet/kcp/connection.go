@@ -12,6 +12,7 @@ import (
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/signal"
 	"v2ray.com/core/common/signal/semaphore"
+	"v2ray.com/core/features/stats"
 )
 
 var (
@@ -171,6 +172,8 @@ type ConnMetadata struct {
 	LocalAddr    net.Addr
 	RemoteAddr   net.Addr
 	Conversation uint16
+	// Stats is optional. Metrics of the connection are published to it if set.
+	Stats stats.Manager
 }
 
 // Connection is a KCP connection over UDP.
@@ -199,6 +202,8 @@ type Connection struct {
 
 	dataUpdater *Updater
 	pingUpdater *Updater
+
+	stats *connectionStats
 }
 
 // NewConnection create a new KCP connection between local and remote.
@@ -218,6 +223,7 @@ func NewConnection(meta ConnMetadata, writer PacketWriter, closer io.Closer, con
 			rto:    100,
 			minRtt: config.GetTTIValue(),
 		},
+		stats: newConnectionStats(meta.Stats, meta, config.ConnectionStats),
 	}
 
 	conn.receivingWorker = NewReceivingWorker(conn)
@@ -537,6 +543,7 @@ func (c *Connection) Terminate() {
 	c.closer.Close()
 	c.sendingWorker.Release()
 	c.receivingWorker.Release()
+	c.stats.close()
 }
 
 func (c *Connection) HandleOption(opt SegmentOption) {
@@ -635,6 +642,7 @@ func (c *Connection) flush() {
 	// flush acknowledges
 	c.receivingWorker.Flush(current)
 	c.sendingWorker.Flush(current)
+	c.stats.updateRoundTrip(c.roundTrip.SmoothedTime(), c.roundTrip.Timeout())
 
 	if current-atomic.LoadUint32(&c.lastPingTime) >= 3000 {
 		c.Ping(current, CommandPing)
@@ -18,7 +18,7 @@ var (
 	globalConv = uint32(dice.RollUint16())
 )
 
-func fetchInput(ctx context.Context, input io.Reader, reader PacketReader, conn *Connection) {
+func fetchInput(ctx context.Context, input io.Reader, reader PacketReader, header internet.PacketHeader, conn *Connection) {
 	cache := make(chan *buf.Buffer, 1024)
 	go func() {
 		for {
@@ -38,10 +38,13 @@ func fetchInput(ctx context.Context, input io.Reader, reader PacketReader, conn
 
 	for payload := range cache {
 		segments := reader.Read(payload.Bytes())
-		payload.Release()
-		if len(segments) > 0 {
-			conn.Input(segments)
+		if len(segments) == 0 {
+			payload.Release()
+			continue
 		}
+		internet.ReceivePacketHeader(header, payload.Bytes())
+		payload.Release()
+		conn.Input(segments)
 	}
 }
 
@@ -49,13 +52,16 @@ func DialKCP(ctx context.Context, dest net.Destination, streamSettings *internet
 	dest.Network = net.Network_UDP
 	newError("dialing mKCP to ", dest).WriteToLog()
 
+	kcpSettings := streamSettings.ProtocolSettings.(*Config)
+	if err := kcpSettings.Validate(); err != nil {
+		return nil, newError("invalid mKCP settings").Base(err)
+	}
+
 	rawConn, err := internet.DialSystem(ctx, dest, streamSettings.SocketSettings)
 	if err != nil {
 		return nil, newError("failed to dial to dest: ", err).AtWarning().Base(err)
 	}
 
-	kcpSettings := streamSettings.ProtocolSettings.(*Config)
-
 	header, err := kcpSettings.GetPackerHeader()
 	if err != nil {
 		return nil, newError("failed to create packet header").Base(err)
@@ -64,14 +70,26 @@ func DialKCP(ctx context.Context, dest net.Destination, streamSettings *internet
 	if err != nil {
 		return nil, newError("failed to create security").Base(err)
 	}
+	decoder, err := kcpSettings.CreateFECDecoder()
+	if err != nil {
+		return nil, newError("failed to create FEC").Base(err)
+	}
+	encoder, err := kcpSettings.CreateFECEncoder()
+	if err != nil {
+		return nil, newError("failed to create FEC").Base(err)
+	}
 	reader := &KCPPacketReader{
 		Header:   header,
 		Security: security,
+		FEC:      decoder,
 	}
 	writer := &KCPPacketWriter{
 		Header:   header,
 		Security: security,
+		FEC:      encoder,
 		Writer:   rawConn,
+
+		FECFlushDelay: kcpSettings.fecFlushDelay(),
 	}
 
 	conv := uint16(atomic.AddUint32(&globalConv, 1))
@@ -79,15 +97,20 @@ func DialKCP(ctx context.Context, dest net.Destination, streamSettings *internet
 		LocalAddr:    rawConn.LocalAddr(),
 		RemoteAddr:   rawConn.RemoteAddr(),
 		Conversation: conv,
+		Stats:        statsManagerFromContext(ctx),
 	}, writer, rawConn, kcpSettings)
 
-	go fetchInput(ctx, rawConn, reader, session)
+	go fetchInput(ctx, rawConn, reader, header, session)
 
 	var iConn internet.Connection = session
 
 	if config := v2tls.ConfigFromStreamSettings(streamSettings); config != nil {
-		tlsConn := tls.Client(iConn, config.GetTLSConfig(v2tls.WithDestination(dest)))
-		iConn = tlsConn
+		tlsConfig := config.GetTLSConfig(v2tls.WithDestination(dest))
+		if fingerprint := v2tls.GetFingerprint(config.Fingerprint); fingerprint != nil {
+			iConn = v2tls.UClient(iConn, tlsConfig, fingerprint)
+		} else {
+			iConn = tls.Client(iConn, tlsConfig)
+		}
 	}
 
 	return iConn, nil
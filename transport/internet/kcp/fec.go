@@ -0,0 +1,302 @@
+package kcp
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/klauspost/reedsolomon"
+)
+
+const (
+	fecHeaderSize = 6
+	fecSizeLen    = 2
+	fecMaxGroups  = 16
+)
+
+// CreateFECEncoder returns a new FECEncoder, or nil if FEC is off.
+func (c *Config) CreateFECEncoder() (*FECEncoder, error) {
+	if c.Fec == nil {
+		return nil, nil
+	}
+	return NewFECEncoder(int(c.Fec.DataShards), int(c.Fec.ParityShards))
+}
+
+// CreateFECDecoder returns a new FECDecoder, or nil if FEC is off.
+func (c *Config) CreateFECDecoder() (*FECDecoder, error) {
+	if c.Fec == nil {
+		return nil, nil
+	}
+	return NewFECDecoder(int(c.Fec.DataShards), int(c.Fec.ParityShards))
+}
+
+// fecFlushDelay returns how long a partial FEC group waits before its parity is sent, which is one TTI.
+func (c *Config) fecFlushDelay() time.Duration {
+	return time.Duration(c.GetTTIValue()) * time.Millisecond
+}
+
+func validateFEC(fec *ForwardErrorCorrection) error {
+	if fec == nil {
+		return nil
+	}
+	if fec.DataShards == 0 || fec.ParityShards == 0 {
+		return newError("FEC data and parity shards must be positive")
+	}
+	if fec.DataShards+fec.ParityShards > 256 {
+		return newError("too many FEC shards: ", fec.DataShards+fec.ParityShards)
+	}
+	return nil
+}
+
+// FECEncoder groups outgoing packets and appends Reed-Solomon parity packets to each group.
+//
+// Each packet is prefixed by a group ID (4 bytes), a shard index (1 byte) and the number of
+// data shards in the group (1 byte, zero in data shards as it is not known yet). A data shard
+// carries the payload length (2 bytes) before the payload, so that it can be recovered from
+// a parity computed over padded shards. A group flushed before it is full is encoded as if the
+// missing data shards were empty.
+type FECEncoder struct {
+	sync.Mutex
+	codec        reedsolomon.Encoder
+	dataShards   int
+	parityShards int
+	group        uint32
+	shards       [][]byte
+}
+
+// NewFECEncoder creates a new FECEncoder with the given number of data and parity shards.
+func NewFECEncoder(dataShards, parityShards int) (*FECEncoder, error) {
+	codec, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, newError("failed to create FEC encoder").Base(err)
+	}
+	return &FECEncoder{
+		codec:        codec,
+		dataShards:   dataShards,
+		parityShards: parityShards,
+	}, nil
+}
+
+// Overhead returns the number of bytes added to each payload.
+func (e *FECEncoder) Overhead() int {
+	return fecHeaderSize + fecSizeLen
+}
+
+// Encode returns the packet for the given payload, followed by the parity packets if the payload completes a group.
+func (e *FECEncoder) Encode(payload []byte) [][]byte {
+	e.Lock()
+	defer e.Unlock()
+
+	shard := make([]byte, fecSizeLen+len(payload))
+	binary.BigEndian.PutUint16(shard, uint16(len(payload)))
+	copy(shard[fecSizeLen:], payload)
+
+	packets := [][]byte{e.packet(len(e.shards), 0, shard)}
+	e.shards = append(e.shards, shard)
+	if len(e.shards) < e.dataShards {
+		return packets
+	}
+	return append(packets, e.parity()...)
+}
+
+// Pending returns the number of data shards in the current group that are not protected by parity yet.
+func (e *FECEncoder) Pending() int {
+	e.Lock()
+	defer e.Unlock()
+
+	return len(e.shards)
+}
+
+// Flush returns the parity packets for the current group even if it is not full, and starts a new group.
+func (e *FECEncoder) Flush() [][]byte {
+	e.Lock()
+	defer e.Unlock()
+
+	if len(e.shards) == 0 {
+		return nil
+	}
+	return e.parity()
+}
+
+func (e *FECEncoder) parity() [][]byte {
+	count := len(e.shards)
+	size := 0
+	for _, s := range e.shards {
+		if len(s) > size {
+			size = len(s)
+		}
+	}
+	shards := make([][]byte, e.dataShards+e.parityShards)
+	for i := range shards {
+		shards[i] = make([]byte, size)
+		if i < count {
+			copy(shards[i], e.shards[i])
+		}
+	}
+	var packets [][]byte
+	if err := e.codec.Encode(shards); err == nil {
+		for i := e.dataShards; i < len(shards); i++ {
+			packets = append(packets, e.packet(i, count, shards[i]))
+		}
+	} else {
+		newError("failed to encode FEC group").Base(err).WriteToLog()
+	}
+
+	e.shards = e.shards[:0]
+	e.group++
+	return packets
+}
+
+func (e *FECEncoder) packet(index int, count int, shard []byte) []byte {
+	b := make([]byte, fecHeaderSize+len(shard))
+	binary.BigEndian.PutUint32(b, e.group)
+	b[4] = byte(index)
+	b[5] = byte(count)
+	copy(b[fecHeaderSize:], shard)
+	return b
+}
+
+type fecGroup struct {
+	shards    [][]byte
+	received  int
+	recovered bool
+}
+
+// FECDecoder restores lost packets from the packets produced by FECEncoder.
+type FECDecoder struct {
+	sync.Mutex
+	codec        reedsolomon.Encoder
+	dataShards   int
+	parityShards int
+	groups       map[uint32]*fecGroup
+	newest       uint32
+
+	received  uint64
+	recovered uint64
+}
+
+// NewFECDecoder creates a new FECDecoder with the given number of data and parity shards.
+func NewFECDecoder(dataShards, parityShards int) (*FECDecoder, error) {
+	codec, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, newError("failed to create FEC decoder").Base(err)
+	}
+	return &FECDecoder{
+		codec:        codec,
+		dataShards:   dataShards,
+		parityShards: parityShards,
+		groups:       make(map[uint32]*fecGroup),
+	}, nil
+}
+
+// Decode returns the payload in the given packet, along with payloads recovered from its group. It returns false
+// if the packet is not an FEC packet. Parity shards that recover nothing, duplicates and shards of expired groups
+// are consumed without payloads, but still valid.
+func (d *FECDecoder) Decode(b []byte) ([][]byte, bool) {
+	if len(b) < fecHeaderSize {
+		return nil, false
+	}
+	id := binary.BigEndian.Uint32(b)
+	index := int(b[4])
+	count := int(b[5])
+	shard := b[fecHeaderSize:]
+	if index >= d.dataShards+d.parityShards || count > d.dataShards {
+		return nil, false
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if int32(id-d.newest) > 0 {
+		d.newest = id
+		for k := range d.groups {
+			if int32(d.newest-k) >= fecMaxGroups {
+				delete(d.groups, k)
+			}
+		}
+	} else if int32(d.newest-id) >= fecMaxGroups {
+		return nil, true
+	}
+
+	group, found := d.groups[id]
+	if !found {
+		group = &fecGroup{
+			shards: make([][]byte, d.dataShards+d.parityShards),
+		}
+		d.groups[id] = group
+	}
+	if group.shards[index] != nil {
+		return nil, true
+	}
+	group.shards[index] = append([]byte(nil), shard...)
+	group.received++
+	if index >= d.dataShards && count > 0 {
+		// The group was flushed before it was full. Its remaining data shards are empty.
+		for i := count; i < d.dataShards; i++ {
+			if group.shards[i] == nil {
+				group.shards[i] = []byte{}
+				group.received++
+			}
+		}
+	}
+
+	var result [][]byte
+	if index < d.dataShards {
+		d.received++
+		if payload := unpackShard(shard); payload != nil {
+			result = append(result, payload)
+		}
+	}
+
+	if group.recovered || group.received < d.dataShards {
+		return result, true
+	}
+	group.recovered = true
+
+	var missing []int
+	for i := 0; i < d.dataShards; i++ {
+		if group.shards[i] == nil {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return result, true
+	}
+
+	size := 0
+	for _, s := range group.shards {
+		if len(s) > size {
+			size = len(s)
+		}
+	}
+	for i, s := range group.shards {
+		if s != nil && len(s) < size {
+			padded := make([]byte, size)
+			copy(padded, s)
+			group.shards[i] = padded
+		}
+	}
+	if err := d.codec.ReconstructData(group.shards); err != nil {
+		newError("failed to recover FEC group ", id).Base(err).WriteToLog()
+		return result, true
+	}
+	for _, i := range missing {
+		if payload := unpackShard(group.shards[i]); payload != nil {
+			result = append(result, payload)
+			d.recovered++
+		}
+	}
+	newError("recovered ", len(missing), " packets in FEC group ", id, ", total recovered/received: ", d.recovered, "/", d.received).AtDebug().WriteToLog()
+	return result, true
+}
+
+func unpackShard(shard []byte) []byte {
+	if len(shard) < fecSizeLen {
+		return nil
+	}
+	size := int(binary.BigEndian.Uint16(shard))
+	if size == 0 || fecSizeLen+size > len(shard) {
+		return nil
+	}
+	return shard[fecSizeLen : fecSizeLen+size]
+}
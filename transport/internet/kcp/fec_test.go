@@ -0,0 +1,184 @@
+package kcp_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	. "v2ray.com/core/transport/internet/kcp"
+)
+
+func TestFECRecovery(t *testing.T) {
+	encoder, err := NewFECEncoder(4, 2)
+	common.Must(err)
+	decoder, err := NewFECDecoder(4, 2)
+	common.Must(err)
+
+	var payloads [][]byte
+	var packets [][]byte
+	for i := 0; i < 8; i++ {
+		payload := []byte(fmt.Sprint("payload ", i, bytes.Repeat([]byte{'x'}, i*10)))
+		payloads = append(payloads, payload)
+		packets = append(packets, encoder.Encode(payload)...)
+	}
+	if len(packets) != 12 {
+		t.Fatal("expected 12 packets, but got ", len(packets))
+	}
+
+	// Drop two data shards in the first group, and one data shard and one parity shard in the second.
+	dropped := map[int]bool{0: true, 2: true, 7: true, 10: true}
+	received := make(map[string]bool)
+	for i, packet := range packets {
+		if dropped[i] {
+			continue
+		}
+		payloads, valid := decoder.Decode(packet)
+		if !valid {
+			t.Error("packet ", i, " is not valid")
+		}
+		for _, payload := range payloads {
+			if received[string(payload)] {
+				t.Error("duplicated payload: ", string(payload))
+			}
+			received[string(payload)] = true
+		}
+	}
+
+	for _, payload := range payloads {
+		if !received[string(payload)] {
+			t.Error("missing payload: ", string(payload))
+		}
+	}
+}
+
+func TestFECUnrecoverable(t *testing.T) {
+	encoder, err := NewFECEncoder(2, 1)
+	common.Must(err)
+	decoder, err := NewFECDecoder(2, 1)
+	common.Must(err)
+
+	packets := encoder.Encode([]byte("a"))
+	packets = append(packets, encoder.Encode([]byte("b"))...)
+	if len(packets) != 3 {
+		t.Fatal("expected 3 packets, but got ", len(packets))
+	}
+
+	if r, valid := decoder.Decode(packets[2]); len(r) != 0 || !valid {
+		t.Error("expected a valid parity shard with nothing recovered, but got ", r, ", ", valid)
+	}
+	if _, valid := decoder.Decode([]byte("garbage")); valid {
+		t.Error("expected garbage to be invalid")
+	}
+}
+
+func TestFECFlushPartialGroup(t *testing.T) {
+	encoder, err := NewFECEncoder(4, 2)
+	common.Must(err)
+	decoder, err := NewFECDecoder(4, 2)
+	common.Must(err)
+
+	packets := encoder.Encode([]byte("a"))
+	packets = append(packets, encoder.Encode([]byte("bb"))...)
+	if encoder.Pending() != 2 {
+		t.Fatal("expected 2 pending shards, but got ", encoder.Pending())
+	}
+	packets = append(packets, encoder.Flush()...)
+	if len(packets) != 4 {
+		t.Fatal("expected 4 packets, but got ", len(packets))
+	}
+	if encoder.Pending() != 0 || encoder.Flush() != nil {
+		t.Error("expected nothing pending after flush")
+	}
+
+	// Lose both data shards. They are recovered from the two parity shards.
+	received, _ := decoder.Decode(packets[2])
+	recovered, _ := decoder.Decode(packets[3])
+	received = append(received, recovered...)
+	if len(received) != 2 {
+		t.Fatal("expected 2 payloads, but got ", len(received))
+	}
+	if string(received[0]) != "a" || string(received[1]) != "bb" {
+		t.Error("unexpected payloads: ", string(received[0]), ", ", string(received[1]))
+	}
+
+	// The next group starts over.
+	packets = encoder.Encode([]byte("c"))
+	if r, _ := decoder.Decode(packets[0]); len(r) != 1 || string(r[0]) != "c" {
+		t.Error("unexpected payloads: ", r)
+	}
+}
+
+func TestKCPPacketWriterFlushesFEC(t *testing.T) {
+	encoder, err := NewFECEncoder(4, 1)
+	common.Must(err)
+
+	packets := make(chan []byte, 8)
+	writer := &KCPPacketWriter{
+		FEC:           encoder,
+		FECFlushDelay: 20 * time.Millisecond,
+		Writer: writerFunc(func(b []byte) (int, error) {
+			packets <- append([]byte(nil), b...)
+			return len(b), nil
+		}),
+	}
+
+	common.Must2(writer.Write([]byte("a")))
+	<-packets
+
+	select {
+	case <-packets:
+	case <-time.After(time.Second):
+		t.Fatal("parity of the partial group is not sent")
+	}
+	if encoder.Pending() != 0 {
+		t.Error("expected nothing pending, but got ", encoder.Pending())
+	}
+}
+
+func TestKCPPacketWithFEC(t *testing.T) {
+	encoder, err := NewFECEncoder(2, 1)
+	common.Must(err)
+	decoder, err := NewFECDecoder(2, 1)
+	common.Must(err)
+
+	var packets [][]byte
+	writer := &KCPPacketWriter{
+		FEC: encoder,
+		Writer: writerFunc(func(b []byte) (int, error) {
+			packets = append(packets, append([]byte(nil), b...))
+			return len(b), nil
+		}),
+	}
+	reader := &KCPPacketReader{
+		FEC: decoder,
+	}
+
+	for _, conv := range []uint16{1, 2} {
+		seg := NewCmdOnlySegment()
+		seg.Conv = conv
+		seg.Cmd = CommandPing
+		b := make([]byte, seg.ByteSize())
+		seg.Serialize(b)
+		common.Must2(writer.Write(b))
+	}
+	if len(packets) != 3 {
+		t.Fatal("expected 3 packets, but got ", len(packets))
+	}
+
+	segments := reader.Read(packets[1])
+	segments = append(segments, reader.Read(packets[2])...)
+	if len(segments) != 2 {
+		t.Fatal("expected 2 segments, but got ", len(segments))
+	}
+	if segments[0].Conversation() != 2 || segments[1].Conversation() != 1 {
+		t.Error("unexpected segments: ", segments[0].Conversation(), segments[1].Conversation())
+	}
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) {
+	return f(b)
+}
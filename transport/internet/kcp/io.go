@@ -4,6 +4,8 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"io"
+	"sync"
+	"time"
 
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/buf"
@@ -22,25 +24,48 @@ type PacketWriter interface {
 type KCPPacketReader struct {
 	Security cipher.AEAD
 	Header   internet.PacketHeader
+	FEC      *FECDecoder
 }
 
 func (r *KCPPacketReader) Read(b []byte) []Segment {
+	segments, _ := r.readPacket(b)
+	return segments
+}
+
+// readPacket returns the segments in the given packet. It returns false if the packet is invalid. A valid packet may
+// carry no segments, e.g., an FEC parity shard that recovers nothing.
+func (r *KCPPacketReader) readPacket(b []byte) ([]Segment, bool) {
 	if r.Header != nil {
-		b = b[r.Header.Size():]
+		b = internet.PacketPayload(r.Header, b)
+		if b == nil {
+			return nil, false
+		}
 	}
 	if r.Security != nil {
 		nonceSize := r.Security.NonceSize()
 		overhead := r.Security.Overhead()
 		if len(b) <= nonceSize+overhead {
-			return nil
+			return nil, false
 		}
 		out, err := r.Security.Open(b[nonceSize:nonceSize], b[:nonceSize], b[nonceSize:], nil)
 		if err != nil {
-			return nil
+			return nil, false
 		}
 		b = out
 	}
+	if r.FEC == nil {
+		segments := readSegments(nil, b)
+		return segments, len(segments) > 0
+	}
+	payloads, valid := r.FEC.Decode(b)
 	var result []Segment
+	for _, payload := range payloads {
+		result = readSegments(result, payload)
+	}
+	return result, valid
+}
+
+func readSegments(result []Segment, b []byte) []Segment {
 	for len(b) > 0 {
 		seg, x := ReadSegment(b)
 		if seg == nil {
@@ -55,7 +80,14 @@ func (r *KCPPacketReader) Read(b []byte) []Segment {
 type KCPPacketWriter struct {
 	Header   internet.PacketHeader
 	Security cipher.AEAD
-	Writer   io.Writer
+	FEC      *FECEncoder
+	// FECFlushDelay is how long a partial FEC group waits for more packets before its parity is sent.
+	// Partial groups are not flushed if it is zero.
+	FECFlushDelay time.Duration
+	Writer        io.Writer
+
+	access     sync.Mutex
+	flushTimer *time.Timer
 }
 
 func (w *KCPPacketWriter) Overhead() int {
@@ -64,17 +96,61 @@ func (w *KCPPacketWriter) Overhead() int {
 		overhead += int(w.Header.Size())
 	}
 	if w.Security != nil {
-		overhead += w.Security.Overhead()
+		overhead += w.Security.NonceSize() + w.Security.Overhead()
+	}
+	if w.FEC != nil {
+		overhead += w.FEC.Overhead()
 	}
 	return overhead
 }
 
 func (w *KCPPacketWriter) Write(b []byte) (int, error) {
+	if w.FEC == nil {
+		return len(b), w.writePacket(b)
+	}
+
+	w.access.Lock()
+	defer w.access.Unlock()
+
+	for _, packet := range w.FEC.Encode(b) {
+		if err := w.writePacket(packet); err != nil {
+			return 0, err
+		}
+	}
+	// Start the timer with the first packet of a group, so that the tail of a burst is protected as well.
+	if w.FECFlushDelay > 0 && w.FEC.Pending() == 1 {
+		if w.flushTimer == nil {
+			w.flushTimer = time.AfterFunc(w.FECFlushDelay, w.flushFEC)
+		} else {
+			w.flushTimer.Reset(w.FECFlushDelay)
+		}
+	}
+	return len(b), nil
+}
+
+func (w *KCPPacketWriter) flushFEC() {
+	w.access.Lock()
+	defer w.access.Unlock()
+
+	for _, packet := range w.FEC.Flush() {
+		if err := w.writePacket(packet); err != nil {
+			newError("failed to write FEC parity").Base(err).AtDebug().WriteToLog()
+			return
+		}
+	}
+}
+
+func (w *KCPPacketWriter) writePacket(b []byte) error {
 	bb := buf.StackNew()
 	defer bb.Release()
 
+	padding := 0
 	if w.Header != nil {
-		w.Header.Serialize(bb.Extend(w.Header.Size()))
+		payloadSize := len(b)
+		if w.Security != nil {
+			payloadSize += w.Security.NonceSize() + w.Security.Overhead()
+		}
+		padding = internet.SerializePacketHeader(w.Header, bb.Extend(w.Header.Size()), payloadSize)
 	}
 	if w.Security != nil {
 		nonceSize := w.Security.NonceSize()
@@ -86,7 +162,10 @@ func (w *KCPPacketWriter) Write(b []byte) (int, error) {
 	} else {
 		bb.Write(b)
 	}
+	if padding > 0 {
+		common.Must2(bb.ReadFullFrom(rand.Reader, int32(padding)))
+	}
 
 	_, err := w.Writer.Write(bb.Bytes())
-	return len(b), err
+	return err
 }
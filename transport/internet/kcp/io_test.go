@@ -1,8 +1,12 @@
 package kcp_test
 
 import (
+	"bytes"
 	"testing"
 
+	"v2ray.com/core/common"
+	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/headers/srtp"
 	. "v2ray.com/core/transport/internet/kcp"
 )
 
@@ -35,3 +39,51 @@ func TestKCPPacketReader(t *testing.T) {
 	}
 
 }
+
+func TestKCPPacketWithSeed(t *testing.T) {
+	security, err := (&Config{Seed: &EncryptionSeed{Seed: "v2ray"}}).GetSecurity()
+	common.Must(err)
+	header, err := internet.CreatePacketHeader(&srtp.Config{})
+	common.Must(err)
+
+	seg := &DataSegment{
+		Conv:   1,
+		Number: 2,
+	}
+	seg.Data().Write([]byte("payload"))
+	payload := make([]byte, seg.ByteSize())
+	seg.Serialize(payload)
+
+	var output bytes.Buffer
+	writer := &KCPPacketWriter{
+		Header:   header,
+		Security: security,
+		Writer:   &output,
+	}
+	common.Must2(writer.Write(payload))
+	if output.Len() != writer.Overhead()+len(payload) {
+		t.Error("unexpected packet size ", output.Len(), ", overhead ", writer.Overhead())
+	}
+	if bytes.Contains(output.Bytes(), []byte("payload")) {
+		t.Error("payload is not encrypted")
+	}
+
+	reader := &KCPPacketReader{
+		Header:   header,
+		Security: security,
+	}
+	segments := reader.Read(output.Bytes())
+	if len(segments) != 1 {
+		t.Fatal("expect 1 segment, but got ", len(segments))
+	}
+	if data := segments[0].(*DataSegment).Data().String(); data != "payload" {
+		t.Error("unexpected data ", data)
+	}
+
+	otherSecurity, err := (&Config{Seed: &EncryptionSeed{Seed: "v2fly"}}).GetSecurity()
+	common.Must(err)
+	reader.Security = otherSecurity
+	if segments := reader.Read(output.Bytes()); segments != nil {
+		t.Error("expect nothing with mismatched seed, but got ", segments)
+	}
+}
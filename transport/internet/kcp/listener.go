@@ -9,6 +9,7 @@ import (
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/net"
+	"v2ray.com/core/features/stats"
 	"v2ray.com/core/transport/internet"
 	v2tls "v2ray.com/core/transport/internet/tls"
 	"v2ray.com/core/transport/internet/udp"
@@ -24,18 +25,25 @@ type ConnectionID struct {
 type Listener struct {
 	sync.Mutex
 	sessions  map[ConnectionID]*Connection
+	sources   map[net.Destination]int // number of sessions from each source
 	hub       *udp.Hub
 	tlsConfig *tls.Config
 	config    *Config
-	reader    PacketReader
+	reader    *KCPPacketReader
+	readers   map[net.Destination]*KCPPacketReader
 	header    internet.PacketHeader
+	headers   *internet.PacketHeaderSessions
 	security  cipher.AEAD
 	addConn   internet.ConnHandler
+	stats     stats.Manager
 }
 
 func NewListener(ctx context.Context, address net.Address, port net.Port, streamSettings *internet.MemoryStreamConfig, addConn internet.ConnHandler) (*Listener, error) {
 	kcpSettings := streamSettings.ProtocolSettings.(*Config)
-	header, err := kcpSettings.GetPackerHeader()
+	if err := kcpSettings.Validate(); err != nil {
+		return nil, newError("invalid mKCP settings").Base(err).AtError()
+	}
+	header, err := kcpSettings.GetServerPacketHeader()
 	if err != nil {
 		return nil, newError("failed to create packet header").Base(err).AtError()
 	}
@@ -45,14 +53,20 @@ func NewListener(ctx context.Context, address net.Address, port net.Port, stream
 	}
 	l := &Listener{
 		header:   header,
+		headers:  internet.NewPacketHeaderSessions(header),
 		security: security,
 		reader: &KCPPacketReader{
 			Header:   header,
 			Security: security,
 		},
 		sessions: make(map[ConnectionID]*Connection),
+		sources:  make(map[net.Destination]int),
 		config:   kcpSettings,
 		addConn:  addConn,
+		stats:    statsManagerFromContext(ctx),
+	}
+	if kcpSettings.Fec != nil {
+		l.readers = make(map[net.Destination]*KCPPacketReader)
 	}
 
 	if config := v2tls.ConfigFromStreamSettings(streamSettings); config != nil {
@@ -80,12 +94,51 @@ func (l *Listener) handlePackets() {
 	}
 }
 
+// getReader returns the PacketReader for packets from the given source, and whether there is a session from the
+// source. FEC state is kept per source.
+func (l *Listener) getReader(src net.Destination) (*KCPPacketReader, bool) {
+	if l.readers == nil {
+		return l.reader, true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	hasSession := l.sources[src] > 0
+	if reader, found := l.readers[src]; found {
+		return reader, hasSession
+	}
+	decoder, err := l.config.CreateFECDecoder()
+	common.Must(err)
+	reader := &KCPPacketReader{
+		Header:   l.header,
+		Security: l.security,
+		FEC:      decoder,
+	}
+	l.readers[src] = reader
+	return reader, hasSession
+}
+
 func (l *Listener) OnReceive(payload *buf.Buffer, src net.Destination) {
-	segments := l.reader.Read(payload.Bytes())
+	reader, hasSession := l.getReader(src)
+	segments, valid := reader.readPacket(payload.Bytes())
+	if len(segments) > 0 {
+		l.headers.Receive(src.NetAddr(), payload.Bytes())
+	}
 	payload.Release()
 
 	if len(segments) == 0 {
-		newError("discarding invalid payload from ", src).WriteToLog()
+		// Don't keep FEC state for sources without sessions.
+		if !hasSession {
+			l.Lock()
+			if l.sources[src] == 0 {
+				delete(l.readers, src)
+			}
+			l.Unlock()
+		}
+		if !valid {
+			newError("discarding invalid payload from ", src).WriteToLog()
+		}
 		return
 	}
 
@@ -118,30 +171,43 @@ func (l *Listener) OnReceive(payload *buf.Buffer, src net.Destination) {
 			Port: int(src.Port),
 		}
 		localAddr := l.hub.Addr()
+		encoder, err := l.config.CreateFECEncoder()
+		common.Must(err)
 		conn = NewConnection(ConnMetadata{
 			LocalAddr:    localAddr,
 			RemoteAddr:   remoteAddr,
 			Conversation: conv,
+			Stats:        l.stats,
 		}, &KCPPacketWriter{
-			Header:   l.header,
+			Header:   l.headers.Get(src.NetAddr()),
 			Security: l.security,
+			FEC:      encoder,
 			Writer:   writer,
+
+			FECFlushDelay: l.config.fecFlushDelay(),
 		}, writer, l.config)
 		var netConn internet.Connection = conn
 		if l.tlsConfig != nil {
-			tlsConn := tls.Server(conn, l.tlsConfig)
-			netConn = tlsConn
+			netConn = v2tls.Server(conn, l.tlsConfig)
 		}
 
 		l.addConn(netConn)
 		l.sessions[id] = conn
+		l.sources[src]++
 	}
 	conn.Input(segments)
 }
 
 func (l *Listener) Remove(id ConnectionID) {
 	l.Lock()
-	delete(l.sessions, id)
+	if _, found := l.sessions[id]; found {
+		delete(l.sessions, id)
+		src := net.UDPDestination(id.Remote, id.Port)
+		if l.sources[src]--; l.sources[src] == 0 {
+			delete(l.sources, src)
+			delete(l.readers, src)
+		}
+	}
 	l.Unlock()
 }
 
@@ -95,15 +95,22 @@ func (sw *SendingWindow) Visit(visitor func(seg *DataSegment) bool) {
 	}
 }
 
-func (sw *SendingWindow) Flush(current uint32, rto uint32, maxInFlightSize uint32) {
+// Flush sends at most maxInFlightSize segments that are new or timed out. If cwnd is not 0, only the segments whose
+// number is less than cwnd are sent. It returns the number of segments in flight.
+func (sw *SendingWindow) Flush(current uint32, rto uint32, maxInFlightSize uint32, cwnd uint32) uint32 {
 	if sw.IsEmpty() {
-		return
+		return 0
 	}
 
 	var lost uint32
 	var inFlightSize uint32
+	var total uint32
 
 	sw.Visit(func(segment *DataSegment) bool {
+		if cwnd != 0 && segment.Number-cwnd <= 0x7FFFFFFF {
+			return false
+		}
+		total++
 		if current-segment.timeout >= 0x7FFFFFFF {
 			return true
 		}
@@ -129,6 +136,7 @@ func (sw *SendingWindow) Flush(current uint32, rto uint32, maxInFlightSize uint3
 		rate := lost * 100 / sw.totalInFlightSize
 		sw.onPacketLoss(rate)
 	}
+	return total
 }
 
 func (sw *SendingWindow) Remove(number uint32) bool {
@@ -160,7 +168,7 @@ type SendingWorker struct {
 	firstUnacknowledged        uint32
 	nextNumber                 uint32
 	remoteNextNumber           uint32
-	controlWindow              uint32
+	congestion                 CongestionControl
 	fastResend                 uint32
 	windowSize                 uint32
 	firstUnacknowledgedUpdated bool
@@ -172,9 +180,13 @@ func NewSendingWorker(kcp *Connection) *SendingWorker {
 		conn:             kcp,
 		fastResend:       2,
 		remoteNextNumber: 32,
-		controlWindow:    kcp.Config.GetSendingInFlightSize(),
 		windowSize:       kcp.Config.GetSendingBufferSize(),
 	}
+	congestion, err := kcp.Config.CreateCongestionControl()
+	if err != nil {
+		newError("congestion control is off").Base(err).AtWarning().WriteToLog()
+	}
+	worker.congestion = congestion
 	worker.window = NewSendingWindow(worker, worker.OnPacketLoss)
 	return worker
 }
@@ -236,7 +248,19 @@ func (w *SendingWorker) ProcessSegment(current uint32, seg *AckSegment, rto uint
 	if w.remoteNextNumber < seg.ReceivingWindow {
 		w.remoteNextNumber = seg.ReceivingWindow
 	}
+	var rtt uint32
+	if !seg.IsEmpty() && current-seg.Timestamp < 10000 {
+		rtt = current - seg.Timestamp
+	}
+
+	size := w.window.Len()
 	w.ProcessReceivingNextWithoutLock(seg.ReceivingNext)
+	if w.congestion != nil {
+		// Segments acknowledged by ReceivingNext are delivered as well.
+		for i := w.window.Len(); i < size; i++ {
+			w.congestion.OnAck(current, rtt)
+		}
+	}
 
 	if seg.IsEmpty() {
 		return
@@ -246,6 +270,9 @@ func (w *SendingWorker) ProcessSegment(current uint32, seg *AckSegment, rto uint
 	var maxackRemoved bool
 	for _, number := range seg.NumberList {
 		removed := w.processAck(number)
+		if removed && w.congestion != nil {
+			w.congestion.OnAck(current, rtt)
+		}
 		if maxack < number {
 			maxack = number
 			maxackRemoved = removed
@@ -286,26 +313,17 @@ func (w *SendingWorker) Write(seg Segment) error {
 	if w.conn.State() == StateReadyToClose {
 		dataSeg.Option = SegmentOptionClose
 	}
+	w.conn.stats.onSent(dataSeg.transmit > 1)
 
 	return w.conn.output.Write(dataSeg)
 }
 
 func (w *SendingWorker) OnPacketLoss(lossRate uint32) {
-	if !w.conn.Config.Congestion || w.conn.roundTrip.Timeout() == 0 {
+	if w.congestion == nil || w.conn.roundTrip.Timeout() == 0 {
 		return
 	}
 
-	if lossRate >= 15 {
-		w.controlWindow = 3 * w.controlWindow / 4
-	} else if lossRate <= 5 {
-		w.controlWindow += w.controlWindow / 4
-	}
-	if w.controlWindow < 16 {
-		w.controlWindow = 16
-	}
-	if w.controlWindow > 2*w.conn.Config.GetSendingInFlightSize() {
-		w.controlWindow = 2 * w.conn.Config.GetSendingInFlightSize()
-	}
+	w.congestion.OnPacketLoss(w.conn.Elapsed(), lossRate)
 }
 
 func (w *SendingWorker) Flush(current uint32) {
@@ -320,14 +338,22 @@ func (w *SendingWorker) Flush(current uint32) {
 	if cwnd > w.remoteNextNumber {
 		cwnd = w.remoteNextNumber
 	}
-	if w.conn.Config.Congestion && cwnd > w.firstUnacknowledged+w.controlWindow {
-		cwnd = w.firstUnacknowledged + w.controlWindow
+	// With congestion control, the congestion window, bounded by the receiving window of the peer, limits the
+	// segments in flight.
+	var limit uint32
+	if w.congestion != nil {
+		limit = w.firstUnacknowledged + w.congestion.Window(current)
+		if limit > w.remoteNextNumber {
+			limit = w.remoteNextNumber
+		}
 	}
 
+	var inFlight uint32
 	if !w.window.IsEmpty() {
-		w.window.Flush(current, w.conn.roundTrip.Timeout(), cwnd)
+		inFlight = w.window.Flush(current, w.conn.roundTrip.Timeout(), cwnd, limit)
 		w.firstUnacknowledgedUpdated = false
 	}
+	w.conn.stats.updateInFlight(inFlight)
 
 	updated := w.firstUnacknowledgedUpdated
 	w.firstUnacknowledgedUpdated = false
@@ -0,0 +1,141 @@
+package kcp
+
+import (
+	"context"
+	"sync"
+
+	"v2ray.com/core"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/features/stats"
+)
+
+const statsPrefix = "transport>>>kcp>>>"
+
+func statsManagerFromContext(ctx context.Context) stats.Manager {
+	if v := core.FromContext(ctx); v != nil {
+		if m, ok := v.GetFeature(stats.ManagerType()).(stats.Manager); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// connectionStats publishes the metrics of a Connection to the stats manager.
+//
+// Aggregate counters are named "transport>>>kcp>>>[metric]". The aggregate rtt is the smoothed RTT most recently
+// updated on any connection that has measured one. Per-connection counters are only published if enabled in
+// Config. They are named "transport>>>kcp>>>connection>>>[remote]#[conv]>>>[metric]", and are removed when the
+// connection terminates.
+type connectionStats struct {
+	manager   stats.Manager
+	names     []string
+	closeOnce sync.Once
+
+	rtt           stats.Counter // smoothed RTT in milliseconds
+	rto           stats.Counter // retransmission timeout in milliseconds
+	inFlight      stats.Counter // segments sent but not acknowledged
+	sent          stats.Counter // data segments sent, including retransmissions
+	retransmitted stats.Counter // data segments retransmitted because of loss
+
+	connections        stats.Counter
+	lastRTT            stats.Counter
+	totalInFlight      stats.Counter
+	totalSent          stats.Counter
+	totalRetransmitted stats.Counter
+
+	lastInFlight int64
+}
+
+func newConnectionStats(manager stats.Manager, meta ConnMetadata, perConnection bool) *connectionStats {
+	if manager == nil {
+		return nil
+	}
+
+	s := &connectionStats{
+		manager: manager,
+	}
+	if perConnection {
+		prefix := statsPrefix + "connection>>>" + serial.ToString(meta.RemoteAddr) + "#" + serial.ToString(meta.Conversation) + ">>>"
+		s.rtt = s.register(prefix + "rtt")
+		s.rto = s.register(prefix + "rto")
+		s.inFlight = s.register(prefix + "inflight")
+		s.sent = s.register(prefix + "sent")
+		s.retransmitted = s.register(prefix + "retransmitted")
+	}
+
+	s.connections, _ = stats.GetOrRegisterCounter(manager, statsPrefix+"connections")
+	s.lastRTT, _ = stats.GetOrRegisterCounter(manager, statsPrefix+"rtt")
+	s.totalInFlight, _ = stats.GetOrRegisterCounter(manager, statsPrefix+"inflight")
+	s.totalSent, _ = stats.GetOrRegisterCounter(manager, statsPrefix+"sent")
+	s.totalRetransmitted, _ = stats.GetOrRegisterCounter(manager, statsPrefix+"retransmitted")
+	addCounter(s.connections, 1)
+
+	return s
+}
+
+func (s *connectionStats) register(name string) stats.Counter {
+	c, err := stats.GetOrRegisterCounter(s.manager, name)
+	if err != nil {
+		return nil
+	}
+	s.names = append(s.names, name)
+	return c
+}
+
+func addCounter(c stats.Counter, delta int64) {
+	if c != nil {
+		c.Add(delta)
+	}
+}
+
+func setCounter(c stats.Counter, value int64) {
+	if c != nil {
+		c.Set(value)
+	}
+}
+
+func (s *connectionStats) updateRoundTrip(rtt uint32, rto uint32) {
+	if s == nil {
+		return
+	}
+	setCounter(s.rtt, int64(rtt))
+	setCounter(s.rto, int64(rto))
+	if rtt > 0 {
+		setCounter(s.lastRTT, int64(rtt))
+	}
+}
+
+// updateInFlight must not be called concurrently.
+func (s *connectionStats) updateInFlight(size uint32) {
+	if s == nil {
+		return
+	}
+	setCounter(s.inFlight, int64(size))
+	addCounter(s.totalInFlight, int64(size)-s.lastInFlight)
+	s.lastInFlight = int64(size)
+}
+
+func (s *connectionStats) onSent(retransmit bool) {
+	if s == nil {
+		return
+	}
+	addCounter(s.sent, 1)
+	addCounter(s.totalSent, 1)
+	if retransmit {
+		addCounter(s.retransmitted, 1)
+		addCounter(s.totalRetransmitted, 1)
+	}
+}
+
+func (s *connectionStats) close() {
+	if s == nil {
+		return
+	}
+	s.closeOnce.Do(func() {
+		for _, name := range s.names {
+			stats.UnregisterCounter(s.manager, name) // nolint: errcheck
+		}
+		addCounter(s.connections, -1)
+		addCounter(s.totalInFlight, -s.lastInFlight)
+	})
+}
@@ -0,0 +1,95 @@
+package kcp_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+	feature_stats "v2ray.com/core/features/stats"
+	. "v2ray.com/core/transport/internet/kcp"
+)
+
+func TestConnectionStats(t *testing.T) {
+	manager, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	clientMeta := ConnMetadata{
+		RemoteAddr:   &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234},
+		Conversation: 1,
+		Stats:        manager,
+	}
+	serverMeta := ConnMetadata{
+		RemoteAddr:   &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5678},
+		Conversation: 1,
+		Stats:        manager,
+	}
+	client, server := newSimulatedPair(&Config{Tti: &TTI{Value: 20}, ConnectionStats: true}, clientMeta, serverMeta, time.Millisecond*40, 0.1)
+	transfer(t, client, server, 128*1024)
+
+	prefix := "transport>>>kcp>>>connection>>>127.0.0.1:1234#1>>>"
+	for _, name := range []string{"rtt", "rto", "sent", "retransmitted"} {
+		c := manager.GetCounter(prefix + name)
+		if c == nil {
+			t.Fatal("counter not found: ", name)
+		}
+		if c.Value() <= 0 {
+			t.Error("unexpected value of ", name, ": ", c.Value())
+		}
+	}
+	if v := manager.GetCounter("transport>>>kcp>>>connections").Value(); v != 2 {
+		t.Error("expected 2 connections, but got ", v)
+	}
+	if v := manager.GetCounter("transport>>>kcp>>>rtt").Value(); v <= 0 {
+		t.Error("unexpected aggregate rtt: ", v)
+	}
+	if v := manager.GetCounter("transport>>>kcp>>>sent").Value(); v < 128*1024/1400 {
+		t.Error("too few segments sent: ", v)
+	}
+
+	client.Terminate()
+	server.Terminate()
+
+	if c := manager.GetCounter(prefix + "rtt"); c != nil {
+		t.Error("per-connection counters are not removed")
+	}
+	if v := manager.GetCounter("transport>>>kcp>>>connections").Value(); v != 0 {
+		t.Error("expected no connection, but got ", v)
+	}
+	if v := manager.GetCounter("transport>>>kcp>>>inflight").Value(); v != 0 {
+		t.Error("expected nothing in flight, but got ", v)
+	}
+}
+
+func TestConnectionStatsOff(t *testing.T) {
+	manager, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	meta := ConnMetadata{
+		RemoteAddr:   &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234},
+		Conversation: 1,
+		Stats:        manager,
+	}
+	client, server := newSimulatedPair(&Config{Tti: &TTI{Value: 20}}, meta, meta, time.Millisecond*40, 0)
+	transfer(t, client, server, 16*1024)
+	defer client.Terminate()
+	defer server.Terminate()
+
+	manager.Visit(func(name string, c feature_stats.Counter) bool {
+		if strings.HasPrefix(name, "transport>>>kcp>>>connection>>>") {
+			t.Error("unexpected per-connection counter: ", name)
+		}
+		return true
+	})
+	if v := manager.GetCounter("transport>>>kcp>>>connections").Value(); v != 2 {
+		t.Error("expected 2 connections, but got ", v)
+	}
+	for _, name := range []string{"rtt", "inflight", "sent", "retransmitted"} {
+		if manager.GetCounter("transport>>>kcp>>>"+name) == nil {
+			t.Error("aggregate counter not found: ", name)
+		}
+	}
+}
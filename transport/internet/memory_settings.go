@@ -9,12 +9,22 @@ type MemoryStreamConfig struct {
 	SocketSettings   *SocketConfig
 }
 
+// validator is implemented by settings that can check themselves for invalid values.
+type validator interface {
+	Validate() error
+}
+
 // ToMemoryStreamConfig converts a StreamConfig to MemoryStreamConfig. It returns a default non-nil MemoryStreamConfig for nil input.
 func ToMemoryStreamConfig(s *StreamConfig) (*MemoryStreamConfig, error) {
 	ets, err := s.GetEffectiveTransportSettings()
 	if err != nil {
 		return nil, err
 	}
+	if v, ok := ets.(validator); ok {
+		if err := v.Validate(); err != nil {
+			return nil, newError("invalid transport settings").Base(err)
+		}
+	}
 
 	mss := &MemoryStreamConfig{
 		ProtocolName:     s.GetEffectiveProtocol(),
@@ -30,6 +40,11 @@ func ToMemoryStreamConfig(s *StreamConfig) (*MemoryStreamConfig, error) {
 		if err != nil {
 			return nil, err
 		}
+		if v, ok := ess.(validator); ok {
+			if err := v.Validate(); err != nil {
+				return nil, newError("invalid security settings").Base(err)
+			}
+		}
 		mss.SecurityType = s.SecurityType
 		mss.SecuritySettings = ess
 	}
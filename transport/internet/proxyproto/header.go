@@ -0,0 +1,243 @@
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+
+	"v2ray.com/core/common/net"
+)
+
+const (
+	v1Prefix       = "PROXY "
+	v1MaxLength    = 107
+	v2HeaderLength = 16
+)
+
+var v2Signature = []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A}
+
+// HasHeader returns true if the data in the reader starts with a PROXY protocol header of any version.
+func HasHeader(r *bufio.Reader) (bool, error) {
+	first, err := r.Peek(1)
+	if err != nil {
+		return false, err
+	}
+	switch first[0] {
+	case v1Prefix[0]:
+		prefix, err := r.Peek(len(v1Prefix))
+		if err != nil {
+			return false, err
+		}
+		return string(prefix) == v1Prefix, nil
+	case v2Signature[0]:
+		signature, err := r.Peek(len(v2Signature))
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(signature, v2Signature), nil
+	default:
+		return false, nil
+	}
+}
+
+// ReadHeader reads a PROXY protocol header of version 1 or 2. It returns the source address in the header, or nil if
+// the header doesn't carry an address, for example in health checks of the proxy.
+func ReadHeader(r *bufio.Reader) (net.Addr, error) {
+	first, err := r.Peek(1)
+	if err != nil {
+		return nil, newError("failed to read PROXY protocol header").Base(err)
+	}
+	if first[0] == v1Prefix[0] {
+		return readV1(r)
+	}
+	return readV2(r)
+}
+
+func readV1(r *bufio.Reader) (net.Addr, error) {
+	line := make([]byte, 0, v1MaxLength)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, newError("failed to read PROXY protocol v1 header").Base(err)
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) == v1MaxLength {
+			return nil, newError("PROXY protocol v1 header too long")
+		}
+	}
+
+	if !bytes.HasPrefix(line, []byte(v1Prefix)) || !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, newError("invalid PROXY protocol v1 header")
+	}
+	fields := strings.Split(string(line[len(v1Prefix):len(line)-2]), " ")
+	switch fields[0] {
+	case "UNKNOWN":
+		return nil, nil
+	case "TCP4", "TCP6":
+	default:
+		return nil, newError("unknown protocol in PROXY protocol v1 header: ", fields[0])
+	}
+	if len(fields) != 5 {
+		return nil, newError("invalid PROXY protocol v1 header")
+	}
+
+	ip := net.ParseIP(fields[1])
+	if ip == nil || (fields[0] == "TCP4") == strings.Contains(fields[1], ":") {
+		return nil, newError("invalid source address in PROXY protocol v1 header: ", fields[1])
+	}
+	port, err := strconv.ParseUint(fields[3], 10, 16)
+	if err != nil {
+		return nil, newError("invalid source port in PROXY protocol v1 header: ", fields[3]).Base(err)
+	}
+	return &net.TCPAddr{
+		IP:   ip,
+		Port: int(port),
+	}, nil
+}
+
+func readV2(r *bufio.Reader) (net.Addr, error) {
+	var header [v2HeaderLength]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, newError("failed to read PROXY protocol v2 header").Base(err)
+	}
+	if !bytes.Equal(header[:len(v2Signature)], v2Signature) {
+		return nil, newError("invalid PROXY protocol v2 signature")
+	}
+	if header[12]>>4 != 2 {
+		return nil, newError("unknown PROXY protocol version: ", header[12]>>4)
+	}
+
+	length := int(binary.BigEndian.Uint16(header[14:]))
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, newError("failed to read PROXY protocol v2 addresses").Base(err)
+	}
+
+	switch header[12] & 0x0F {
+	case 0x00: // LOCAL
+		return nil, nil
+	case 0x01: // PROXY
+	default:
+		return nil, newError("unknown PROXY protocol v2 command: ", header[12]&0x0F)
+	}
+
+	var ip net.IP
+	var port uint16
+	switch header[13] >> 4 {
+	case 0x01: // AF_INET
+		if length < 12 {
+			return nil, newError("PROXY protocol v2 addresses too short")
+		}
+		ip = net.IP(payload[0:4])
+		port = binary.BigEndian.Uint16(payload[8:])
+	case 0x02: // AF_INET6
+		if length < 36 {
+			return nil, newError("PROXY protocol v2 addresses too short")
+		}
+		ip = net.IP(payload[0:16])
+		port = binary.BigEndian.Uint16(payload[32:])
+	default:
+		return nil, nil
+	}
+
+	if header[13]&0x0F == 0x02 {
+		return &net.UDPAddr{
+			IP:   ip,
+			Port: int(port),
+		}, nil
+	}
+	return &net.TCPAddr{
+		IP:   ip,
+		Port: int(port),
+	}, nil
+}
+
+func addrIPPort(addr net.Addr) (net.IP, int) {
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		return addr.IP, addr.Port
+	case *net.UDPAddr:
+		return addr.IP, addr.Port
+	default:
+		return nil, 0
+	}
+}
+
+// WriteHeader writes a PROXY protocol header of the given version, which conveys a TCP connection from source to
+// dest. If source or dest is not an IP address, the header conveys no address.
+func WriteHeader(w io.Writer, version uint32, source net.Addr, dest net.Addr) error {
+	var header []byte
+	switch version {
+	case 1:
+		header = encodeV1(source, dest)
+	case 2:
+		header = encodeV2(source, dest)
+	default:
+		return newError("unknown PROXY protocol version: ", version)
+	}
+	if _, err := w.Write(header); err != nil {
+		return newError("failed to write PROXY protocol header").Base(err)
+	}
+	return nil
+}
+
+// addrPair returns the IPs and ports of source and dest in the same length, or nil IPs if any of them is not IP.
+func addrPair(source net.Addr, dest net.Addr) (net.IP, int, net.IP, int) {
+	srcIP, srcPort := addrIPPort(source)
+	dstIP, dstPort := addrIPPort(dest)
+	if srcIP == nil || dstIP == nil {
+		return nil, 0, nil, 0
+	}
+	if src4, dst4 := srcIP.To4(), dstIP.To4(); src4 != nil && dst4 != nil {
+		return src4, srcPort, dst4, dstPort
+	}
+	return srcIP.To16(), srcPort, dstIP.To16(), dstPort
+}
+
+// formatIPv6 formats the IP in IPv6 notation, even if it is an IPv4-mapped address.
+func formatIPv6(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return "::ffff:" + ip4.String()
+	}
+	return ip.String()
+}
+
+func encodeV1(source net.Addr, dest net.Addr) []byte {
+	srcIP, srcPort, dstIP, dstPort := addrPair(source, dest)
+	if srcIP == nil {
+		return []byte(v1Prefix + "UNKNOWN\r\n")
+	}
+	if len(srcIP) == net.IPv4len {
+		return []byte(v1Prefix + "TCP4 " + srcIP.String() + " " + dstIP.String() + " " + strconv.Itoa(srcPort) + " " + strconv.Itoa(dstPort) + "\r\n")
+	}
+	return []byte(v1Prefix + "TCP6 " + formatIPv6(srcIP) + " " + formatIPv6(dstIP) + " " + strconv.Itoa(srcPort) + " " + strconv.Itoa(dstPort) + "\r\n")
+}
+
+func encodeV2(source net.Addr, dest net.Addr) []byte {
+	header := make([]byte, v2HeaderLength, v2HeaderLength+36)
+	copy(header, v2Signature)
+
+	srcIP, srcPort, dstIP, dstPort := addrPair(source, dest)
+	if srcIP == nil {
+		header[12] = 0x20 // LOCAL
+		return header
+	}
+
+	header[12] = 0x21 // PROXY
+	if len(srcIP) == net.IPv4len {
+		header[13] = 0x11 // TCP over IPv4
+	} else {
+		header[13] = 0x21 // TCP over IPv6
+	}
+	header = append(header, srcIP...)
+	header = append(header, dstIP...)
+	header = append(header, byte(srcPort>>8), byte(srcPort), byte(dstPort>>8), byte(dstPort))
+	binary.BigEndian.PutUint16(header[14:], uint16(len(header)-v2HeaderLength))
+	return header
+}
@@ -0,0 +1,153 @@
+package proxyproto_test
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	. "v2ray.com/core/transport/internet/proxyproto"
+)
+
+func TestReadHeader(t *testing.T) {
+	testCases := []struct {
+		input  []byte
+		source net.Addr
+	}{
+		{
+			input:  []byte("PROXY TCP4 1.2.3.4 5.6.7.8 1234 443\r\n"),
+			source: &net.TCPAddr{IP: net.ParseIP("1.2.3.4").To4(), Port: 1234},
+		},
+		{
+			input:  []byte("PROXY TCP6 2001:db8::1 2001:db8::2 1234 443\r\n"),
+			source: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 1234},
+		},
+		{
+			input: []byte("PROXY UNKNOWN\r\n"),
+		},
+		{
+			input: []byte{
+				0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A,
+				0x21, 0x11, 0x00, 0x0F,
+				1, 2, 3, 4, 5, 6, 7, 8, 0x04, 0xD2, 0x01, 0xBB,
+				0x04, 0x00, 0x00, // TLV of NOOP
+			},
+			source: &net.TCPAddr{IP: []byte{1, 2, 3, 4}, Port: 1234},
+		},
+		{
+			input: []byte{
+				0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A,
+				0x20, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		reader := bufio.NewReader(bytes.NewReader(append(tc.input, "payload"...)))
+		source, err := ReadHeader(reader)
+		common.Must(err)
+		if r := cmp.Diff(source, tc.source); r != "" {
+			t.Error(r)
+		}
+		rest, err := ioutil.ReadAll(reader)
+		common.Must(err)
+		if string(rest) != "payload" {
+			t.Error("unexpected payload after header: ", string(rest))
+		}
+	}
+}
+
+func TestReadInvalidHeader(t *testing.T) {
+	testCases := [][]byte{
+		[]byte("PROXY TCP4 1.2.3.4 5.6.7.8 1234\r\n"),
+		[]byte("PROXY TCP4 2001:db8::1 5.6.7.8 1234 443\r\n"),
+		[]byte("PROXY TCP4 1.2.3.4 5.6.7.8 1234 443\n"),
+		[]byte("GET / HTTP/1.1\r\n"),
+		{
+			0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A,
+			0x21, 0x11, 0x00, 0x04,
+			1, 2, 3, 4,
+		},
+	}
+
+	for _, input := range testCases {
+		if _, err := ReadHeader(bufio.NewReader(bytes.NewReader(input))); err == nil {
+			t.Error("expect error for ", input)
+		}
+	}
+}
+
+func TestOptionalHeader(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	listener = NewListener(listener, func(net.IP) bool { return true })
+	defer listener.Close()
+
+	for _, payload := range []string{"PROXY TCP4 1.2.3.4 5.6.7.8 1234 443\r\nGET", "GET"} {
+		client, err := net.Dial("tcp", listener.Addr().String())
+		common.Must(err)
+		common.Must2(client.Write([]byte(payload)))
+
+		conn, err := listener.Accept()
+		common.Must(err)
+
+		b := make([]byte, 3)
+		common.Must2(conn.Read(b))
+		if string(b) != "GET" {
+			t.Error("unexpected payload: ", string(b))
+		}
+		if payload == "GET" {
+			if conn.RemoteAddr().String() != client.LocalAddr().String() {
+				t.Error("unexpected remote address: ", conn.RemoteAddr())
+			}
+		} else if conn.RemoteAddr().String() != "1.2.3.4:1234" {
+			t.Error("unexpected remote address: ", conn.RemoteAddr())
+		}
+
+		conn.Close()
+		client.Close()
+	}
+}
+
+func TestWriteHeader(t *testing.T) {
+	testCases := []struct {
+		source net.Addr
+		dest   net.Addr
+		expect net.Addr
+	}{
+		{
+			source: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+			dest:   &net.TCPAddr{IP: net.ParseIP("5.6.7.8"), Port: 443},
+			expect: &net.TCPAddr{IP: net.ParseIP("1.2.3.4").To4(), Port: 1234},
+		},
+		{
+			source: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+			dest:   &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443},
+			expect: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+		},
+		{
+			source: nil,
+			dest:   &net.TCPAddr{IP: net.ParseIP("5.6.7.8"), Port: 443},
+		},
+	}
+
+	for _, version := range []uint32{1, 2} {
+		for _, tc := range testCases {
+			var buffer bytes.Buffer
+			common.Must(WriteHeader(&buffer, version, tc.source, tc.dest))
+			source, err := ReadHeader(bufio.NewReader(&buffer))
+			common.Must(err)
+			if r := cmp.Diff(source, tc.expect); r != "" {
+				t.Error("version ", version, ": ", r)
+			}
+		}
+	}
+
+	if err := WriteHeader(ioutil.Discard, 3, nil, nil); err == nil {
+		t.Error("expect error for unknown version")
+	}
+}
@@ -0,0 +1,106 @@
+package proxyproto
+
+import (
+	"bufio"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common/net"
+)
+
+const headerTimeout = time.Second * 8
+
+// Conn is a connection that starts with a PROXY protocol header. The header is read on first use of the connection,
+// so that a slow peer doesn't block the listener.
+type Conn struct {
+	net.Conn
+
+	optional bool
+	once     sync.Once
+	reader   *bufio.Reader
+	source   net.Addr
+	err      error
+}
+
+// NewConn returns a connection which reads the PROXY protocol header from conn. If optional is true, connections
+// without the header are accepted as is.
+func NewConn(conn net.Conn, optional bool) *Conn {
+	return &Conn{
+		Conn:     conn,
+		optional: optional,
+	}
+}
+
+func (c *Conn) readHeader() {
+	if err := c.Conn.SetReadDeadline(time.Now().Add(headerTimeout)); err != nil {
+		newError("failed to set read deadline").Base(err).WriteToLog()
+	}
+	defer c.Conn.SetReadDeadline(time.Time{}) // nolint: errcheck
+
+	c.reader = bufio.NewReaderSize(c.Conn, 256)
+	if c.optional {
+		found, err := HasHeader(c.reader)
+		if err != nil || !found {
+			return
+		}
+	}
+
+	source, err := ReadHeader(c.reader)
+	if err != nil {
+		c.err = newError("failed to read PROXY protocol header from ", c.Conn.RemoteAddr()).Base(err)
+		return
+	}
+	c.source = source
+}
+
+// Read implements io.Reader.
+func (c *Conn) Read(b []byte) (int, error) {
+	c.once.Do(c.readHeader)
+	if c.err != nil {
+		return 0, c.err
+	}
+	if c.reader != nil {
+		if c.reader.Buffered() > 0 {
+			return c.reader.Read(b)
+		}
+		c.reader = nil
+	}
+	return c.Conn.Read(b)
+}
+
+// RemoteAddr implements net.Conn. It returns the source address in PROXY protocol header if there is one.
+func (c *Conn) RemoteAddr() net.Addr {
+	c.once.Do(c.readHeader)
+	if c.source != nil {
+		return c.source
+	}
+	return c.Conn.RemoteAddr()
+}
+
+type listener struct {
+	net.Listener
+	trusted func(net.IP) bool
+}
+
+// NewListener returns a listener whose connections start with a PROXY protocol header. If trusted is not nil, the
+// header is only read from connections whose peer address is trusted, and is optional for them.
+func NewListener(l net.Listener, trusted func(net.IP) bool) net.Listener {
+	return &listener{
+		Listener: l,
+		trusted:  trusted,
+	}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if l.trusted == nil {
+		return NewConn(conn, false), nil
+	}
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok && l.trusted(addr.IP) {
+		return NewConn(conn, true), nil
+	}
+	return conn, nil
+}
@@ -0,0 +1,5 @@
+// Package proxyproto implements the PROXY protocol of HAProxy, which conveys the address of the original client to
+// the server behind a proxy. See https://www.haproxy.org/download/2.0/doc/proxy-protocol.txt
+package proxyproto
+
+//go:generate errorgen
@@ -4,13 +4,42 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/sha256"
+	"time"
 
+	quic "github.com/lucas-clemente/quic-go"
 	"golang.org/x/crypto/chacha20poly1305"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/transport/internet"
 )
 
+func (c *Config) getIdleTimeout() time.Duration {
+	if c.IdleTimeout == 0 {
+		return time.Second * 30
+	}
+	return time.Second * time.Duration(c.IdleTimeout)
+}
+
+func (c *Config) getMaxIncomingStreams() int {
+	if c.MaxIncomingStreams == 0 {
+		return 128
+	}
+	return int(c.MaxIncomingStreams)
+}
+
+func (c *Config) getQuicConfig() *quic.Config {
+	return &quic.Config{
+		ConnectionIDLength:                    12,
+		HandshakeTimeout:                      time.Second * 8,
+		IdleTimeout:                           c.getIdleTimeout(),
+		MaxReceiveStreamFlowControlWindow:     c.StreamReceiveWindow,
+		MaxReceiveConnectionFlowControlWindow: c.ConnectionReceiveWindow,
+		MaxIncomingStreams:                    c.getMaxIncomingStreams(),
+		MaxIncomingUniStreams:                 32,
+		KeepAlive:                             c.KeepAlive,
+	}
+}
+
 func getAuth(config *Config) (cipher.AEAD, error) {
 	security := config.Security.GetSecurityType()
 	if security == protocol.SecurityType_NONE {
@@ -33,7 +62,7 @@ func getAuth(config *Config) (cipher.AEAD, error) {
 	return nil, newError("unsupported security type")
 }
 
-func getHeader(config *Config) (internet.PacketHeader, error) {
+func getHeader(config *Config, isServer bool) (internet.PacketHeader, error) {
 	if config.Header == nil {
 		return nil, nil
 	}
@@ -43,5 +72,8 @@ func getHeader(config *Config) (internet.PacketHeader, error) {
 		return nil, err
 	}
 
+	if isServer {
+		return internet.CreateServerPacketHeader(msg)
+	}
 	return internet.CreatePacketHeader(msg)
 }
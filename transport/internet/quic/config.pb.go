@@ -19,13 +19,36 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// 0-RTT session resumption and connection migration are not supported
+// yet. The vendored quic-go neither keeps TLS session tickets nor accepts
+// 0-RTT packets, and it always disables connection migration in its
+// transport parameters. Both need a newer quic-go first.
 type Config struct {
-	Key                  string                   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Security             *protocol.SecurityConfig `protobuf:"bytes,2,opt,name=security,proto3" json:"security,omitempty"`
-	Header               *serial.TypedMessage     `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	Key      string                   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Security *protocol.SecurityConfig `protobuf:"bytes,2,opt,name=security,proto3" json:"security,omitempty"`
+	Header   *serial.TypedMessage     `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	// Seconds without network activity before a session is closed. Default 30.
+	IdleTimeout uint32 `protobuf:"varint,4,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
+	// Maximum flow control window of a stream, in bytes. 0 for the default of
+	// the QUIC library.
+	StreamReceiveWindow uint64 `protobuf:"varint,5,opt,name=stream_receive_window,json=streamReceiveWindow,proto3" json:"stream_receive_window,omitempty"`
+	// Maximum flow control window of a session, in bytes. 0 for the default of
+	// the QUIC library.
+	ConnectionReceiveWindow uint64 `protobuf:"varint,6,opt,name=connection_receive_window,json=connectionReceiveWindow,proto3" json:"connection_receive_window,omitempty"`
+	// Maximum number of concurrent streams a peer may open. Default 128.
+	MaxIncomingStreams uint32 `protobuf:"varint,7,opt,name=max_incoming_streams,json=maxIncomingStreams,proto3" json:"max_incoming_streams,omitempty"`
+	// Whether to send PING frames periodically to keep sessions alive.
+	KeepAlive bool `protobuf:"varint,8,opt,name=keep_alive,json=keepAlive,proto3" json:"keep_alive,omitempty"`
+	// Maximum number of sessions kept for reuse per destination. Sessions
+	// beyond this limit are closed once their streams are closed. 0 for
+	// unlimited.
+	MaxSessions uint32 `protobuf:"varint,9,opt,name=max_sessions,json=maxSessions,proto3" json:"max_sessions,omitempty"`
+	// Maximum number of concurrent streams opened on a session before a new
+	// session is created. 1 disables session reuse. 0 for unlimited.
+	MaxStreamsPerSession uint32   `protobuf:"varint,10,opt,name=max_streams_per_session,json=maxStreamsPerSession,proto3" json:"max_streams_per_session,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Config) Reset()         { *m = Config{} }
@@ -74,6 +97,55 @@ func (m *Config) GetHeader() *serial.TypedMessage {
 	return nil
 }
 
+func (m *Config) GetIdleTimeout() uint32 {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return 0
+}
+
+func (m *Config) GetStreamReceiveWindow() uint64 {
+	if m != nil {
+		return m.StreamReceiveWindow
+	}
+	return 0
+}
+
+func (m *Config) GetConnectionReceiveWindow() uint64 {
+	if m != nil {
+		return m.ConnectionReceiveWindow
+	}
+	return 0
+}
+
+func (m *Config) GetMaxIncomingStreams() uint32 {
+	if m != nil {
+		return m.MaxIncomingStreams
+	}
+	return 0
+}
+
+func (m *Config) GetKeepAlive() bool {
+	if m != nil {
+		return m.KeepAlive
+	}
+	return false
+}
+
+func (m *Config) GetMaxSessions() uint32 {
+	if m != nil {
+		return m.MaxSessions
+	}
+	return 0
+}
+
+func (m *Config) GetMaxStreamsPerSession() uint32 {
+	if m != nil {
+		return m.MaxStreamsPerSession
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Config)(nil), "v2ray.core.transport.internet.quic.Config")
 }
@@ -83,22 +155,33 @@ func init() {
 }
 
 var fileDescriptor_462e2eb906061b36 = []byte{
-	// 269 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0xc1, 0x4b, 0xc3, 0x30,
-	0x14, 0xc6, 0xe9, 0x26, 0x45, 0xe3, 0x45, 0x7a, 0x2a, 0x3b, 0x8d, 0x1e, 0xc6, 0x10, 0x79, 0x91,
-	0xee, 0xee, 0xc1, 0x81, 0xe0, 0x41, 0xd0, 0x3a, 0x3d, 0x78, 0x91, 0x98, 0x3d, 0x67, 0x70, 0xed,
-	0xab, 0x2f, 0xa9, 0xd0, 0x7f, 0xc7, 0xa3, 0x7f, 0xa5, 0xa4, 0x59, 0x8b, 0xc8, 0xc0, 0x53, 0x0b,
-	0xf9, 0x7e, 0xbf, 0x7c, 0x5f, 0xc4, 0xe2, 0x33, 0x67, 0xd5, 0x82, 0xa6, 0x52, 0x6a, 0x62, 0x94,
-	0x8e, 0x55, 0x65, 0x6b, 0x62, 0x27, 0x4d, 0xe5, 0x90, 0x2b, 0x74, 0xf2, 0xa3, 0x31, 0x5a, 0x6a,
-	0xaa, 0x5e, 0xcd, 0x06, 0x6a, 0x26, 0x47, 0x49, 0xd6, 0x43, 0x8c, 0x30, 0x00, 0xd0, 0x03, 0xe0,
-	0x81, 0xc9, 0xf9, 0x1f, 0xb1, 0xa6, 0xb2, 0xa4, 0x4a, 0x5a, 0x64, 0xa3, 0xb6, 0xd2, 0xb5, 0x35,
-	0xae, 0x9f, 0x4b, 0xb4, 0x56, 0x6d, 0x30, 0x58, 0x27, 0x67, 0xfb, 0x89, 0xee, 0x50, 0xd3, 0x56,
-	0xbe, 0xa1, 0x5a, 0x23, 0xdb, 0x90, 0xce, 0xbe, 0x22, 0x11, 0x2f, 0xbb, 0x52, 0xc9, 0x89, 0x18,
-	0xbf, 0x63, 0x9b, 0x46, 0xd3, 0x68, 0x7e, 0x54, 0xf8, 0xdf, 0xe4, 0x4a, 0x1c, 0x5a, 0xd4, 0x0d,
-	0x1b, 0xd7, 0xa6, 0xa3, 0x69, 0x34, 0x3f, 0xce, 0x4f, 0xe1, 0x57, 0xe7, 0x60, 0x86, 0xde, 0x0c,
-	0xf7, 0xbb, 0x6c, 0xf0, 0x15, 0x03, 0x9b, 0x5c, 0x88, 0x38, 0xdc, 0x9a, 0x8e, 0x3b, 0xcb, 0x6c,
-	0x8f, 0x25, 0x2c, 0x82, 0x95, 0x5f, 0x74, 0x13, 0x06, 0x15, 0x3b, 0xea, 0xf2, 0x41, 0xcc, 0x34,
-	0x95, 0xf0, 0xff, 0x73, 0xdd, 0x46, 0x4f, 0x07, 0xfe, 0xfb, 0x3d, 0xca, 0x1e, 0xf3, 0x42, 0xb5,
-	0xb0, 0xf4, 0xe1, 0xd5, 0x10, 0xbe, 0xee, 0xc3, 0x77, 0x8d, 0xd1, 0x2f, 0x71, 0xd7, 0x7c, 0xf1,
-	0x13, 0x00, 0x00, 0xff, 0xff, 0xd0, 0x05, 0x0f, 0xf6, 0xbd, 0x01, 0x00, 0x00,
+	// 438 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x51, 0x8b, 0x13, 0x31,
+	0x14, 0x85, 0x99, 0x6d, 0xad, 0x6d, 0x56, 0x41, 0xa2, 0xb2, 0x71, 0x41, 0x18, 0xfb, 0xb0, 0x0c,
+	0x22, 0x99, 0xa5, 0x8b, 0x2f, 0x3e, 0x08, 0xba, 0x20, 0xec, 0x83, 0xb0, 0x4e, 0xab, 0x82, 0x2f,
+	0x43, 0x4c, 0xaf, 0x35, 0x6c, 0x93, 0x3b, 0x26, 0x69, 0xb7, 0xf3, 0x97, 0xfc, 0x55, 0xfe, 0x14,
+	0x49, 0x32, 0x53, 0x75, 0x59, 0xf0, 0x69, 0x86, 0x7b, 0xce, 0x77, 0xee, 0x3d, 0xcc, 0x90, 0xb3,
+	0xed, 0xcc, 0x8a, 0x96, 0x4b, 0xd4, 0xa5, 0x44, 0x0b, 0xa5, 0xb7, 0xc2, 0xb8, 0x06, 0xad, 0x2f,
+	0x95, 0xf1, 0x60, 0x0d, 0xf8, 0xf2, 0xc7, 0x46, 0xc9, 0x52, 0xa2, 0xf9, 0xa6, 0x56, 0xbc, 0xb1,
+	0xe8, 0x91, 0x4e, 0x7b, 0xc8, 0x02, 0xdf, 0x03, 0xbc, 0x07, 0x78, 0x00, 0x8e, 0x4f, 0x6f, 0x04,
+	0x4b, 0xd4, 0x1a, 0x4d, 0xe9, 0xc0, 0x2a, 0xb1, 0x2e, 0x7d, 0xdb, 0xc0, 0xb2, 0xd6, 0xe0, 0x9c,
+	0x58, 0x41, 0x4a, 0x3d, 0x7e, 0x71, 0x3b, 0x11, 0x45, 0x89, 0xeb, 0xf2, 0x3b, 0x88, 0x25, 0x58,
+	0x97, 0xdc, 0xd3, 0x5f, 0x03, 0x32, 0x3a, 0x8f, 0x47, 0xd1, 0x07, 0x64, 0x70, 0x05, 0x2d, 0xcb,
+	0xf2, 0xac, 0x98, 0x54, 0xe1, 0x95, 0xbe, 0x23, 0x63, 0x07, 0x72, 0x63, 0x95, 0x6f, 0xd9, 0x41,
+	0x9e, 0x15, 0x87, 0xb3, 0xe7, 0xfc, 0xaf, 0x9b, 0x53, 0x32, 0xef, 0x93, 0xf9, 0xbc, 0xf3, 0xa6,
+	0xbc, 0x6a, 0xcf, 0xd2, 0xd7, 0x64, 0x94, 0xb6, 0xb2, 0x41, 0x4c, 0x39, 0xb9, 0x25, 0x25, 0x35,
+	0xe2, 0x8b, 0xd0, 0xe8, 0x7d, 0x2a, 0x54, 0x75, 0x14, 0x7d, 0x46, 0xee, 0xa9, 0xe5, 0x1a, 0x6a,
+	0xaf, 0x34, 0xe0, 0xc6, 0xb3, 0x61, 0x9e, 0x15, 0xf7, 0xab, 0xc3, 0x30, 0x5b, 0xa4, 0x11, 0x9d,
+	0x91, 0xc7, 0xce, 0x5b, 0x10, 0xba, 0xb6, 0x20, 0x41, 0x6d, 0xa1, 0xbe, 0x56, 0x66, 0x89, 0xd7,
+	0xec, 0x4e, 0x9e, 0x15, 0xc3, 0xea, 0x61, 0x12, 0xab, 0xa4, 0x7d, 0x8e, 0x12, 0x7d, 0x45, 0x9e,
+	0x48, 0x34, 0x06, 0xa4, 0x57, 0x68, 0x6e, 0x72, 0xa3, 0xc8, 0x1d, 0xfd, 0x31, 0xfc, 0xcb, 0x9e,
+	0x92, 0x47, 0x5a, 0xec, 0x6a, 0x65, 0x24, 0x6a, 0x65, 0x56, 0x75, 0xca, 0x77, 0xec, 0x6e, 0x3c,
+	0x8d, 0x6a, 0xb1, 0xbb, 0xe8, 0xa4, 0x79, 0x52, 0xe8, 0x53, 0x42, 0xae, 0x00, 0x9a, 0x5a, 0xac,
+	0xd5, 0x16, 0xd8, 0x38, 0xcf, 0x8a, 0x71, 0x35, 0x09, 0x93, 0x37, 0x61, 0x10, 0x3a, 0x86, 0x40,
+	0x07, 0xce, 0x29, 0x34, 0x8e, 0x4d, 0x52, 0x47, 0x2d, 0x76, 0xf3, 0x6e, 0x44, 0x5f, 0x92, 0xa3,
+	0x68, 0x49, 0x81, 0x75, 0x03, 0xb6, 0xb7, 0x33, 0x12, 0xdd, 0xe1, 0xa4, 0x6e, 0xdd, 0x25, 0xd8,
+	0x8e, 0x7b, 0xfb, 0x91, 0x9c, 0x48, 0xd4, 0xfc, 0xff, 0x3f, 0xdb, 0x65, 0xf6, 0x65, 0x18, 0x9e,
+	0x3f, 0x0f, 0xa6, 0x9f, 0x66, 0x95, 0x68, 0xf9, 0x79, 0x30, 0x2f, 0xf6, 0xe6, 0x8b, 0xde, 0xfc,
+	0x61, 0xa3, 0xe4, 0xd7, 0x51, 0xfc, 0xee, 0x67, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x56, 0xfd,
+	0x28, 0xc1, 0xfb, 0x02, 0x00, 0x00,
 }
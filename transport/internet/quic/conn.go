@@ -4,6 +4,7 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"errors"
+	"sync/atomic"
 	"time"
 
 	quic "github.com/lucas-clemente/quic-go"
@@ -14,13 +15,14 @@ import (
 )
 
 type sysConn struct {
-	conn   net.PacketConn
-	header internet.PacketHeader
-	auth   cipher.AEAD
+	conn    net.PacketConn
+	header  internet.PacketHeader
+	headers *internet.PacketHeaderSessions
+	auth    cipher.AEAD
 }
 
-func wrapSysConn(rawConn net.PacketConn, config *Config) (*sysConn, error) {
-	header, err := getHeader(config)
+func wrapSysConn(rawConn net.PacketConn, config *Config, isServer bool) (*sysConn, error) {
+	header, err := getHeader(config, isServer)
 	if err != nil {
 		return nil, err
 	}
@@ -29,9 +31,10 @@ func wrapSysConn(rawConn net.PacketConn, config *Config) (*sysConn, error) {
 		return nil, err
 	}
 	return &sysConn{
-		conn:   rawConn,
-		header: header,
-		auth:   auth,
+		conn:    rawConn,
+		header:  header,
+		headers: internet.NewPacketHeaderSessions(header),
+		auth:    auth,
 	}, nil
 }
 
@@ -46,16 +49,18 @@ func (c *sysConn) readFromInternal(p []byte) (int, net.Addr, error) {
 		return 0, nil, err
 	}
 
-	payload := buffer[:nBytes]
+	packet := buffer[:nBytes]
+	payload := packet
 	if c.header != nil {
-		if len(payload) <= int(c.header.Size()) {
+		payload = internet.PacketPayload(c.header, packet)
+		if payload == nil {
 			return 0, nil, errInvalidPacket
 		}
-		payload = payload[c.header.Size():]
 	}
 
 	if c.auth == nil {
 		n := copy(p, payload)
+		c.headers.Receive(addr.String(), packet)
 		return n, addr, nil
 	}
 
@@ -70,6 +75,7 @@ func (c *sysConn) readFromInternal(p []byte) (int, net.Addr, error) {
 	if err != nil {
 		return 0, nil, errInvalidPacket
 	}
+	c.headers.Receive(addr.String(), packet)
 
 	return len(p), addr, nil
 }
@@ -100,8 +106,13 @@ func (c *sysConn) WriteTo(p []byte, addr net.Addr) (int, error) {
 
 	payload := buffer
 	n := 0
+	padding := 0
 	if c.header != nil {
-		c.header.Serialize(payload)
+		payloadSize := len(p)
+		if c.auth != nil {
+			payloadSize += c.auth.NonceSize() + c.auth.Overhead()
+		}
+		padding = internet.SerializePacketHeader(c.headers.Get(addr.String()), payload, payloadSize)
 		n = int(c.header.Size())
 	}
 
@@ -115,6 +126,10 @@ func (c *sysConn) WriteTo(p []byte, addr net.Addr) (int, error) {
 		pp := c.auth.Seal(payload[:n], nounce, p, nil)
 		n = len(pp)
 	}
+	if padding > 0 {
+		common.Must2(rand.Read(payload[n : n+padding]))
+		n += padding
+	}
 
 	return c.conn.WriteTo(payload[:n], addr)
 }
@@ -140,9 +155,11 @@ func (c *sysConn) SetWriteDeadline(t time.Time) error {
 }
 
 type interConn struct {
-	stream quic.Stream
-	local  net.Addr
-	remote net.Addr
+	stream  quic.Stream
+	local   net.Addr
+	remote  net.Addr
+	onClose func()
+	closed  int32
 }
 
 func (c *interConn) Read(b []byte) (int, error) {
@@ -210,6 +227,9 @@ func (c *interConn) Write(b []byte) (int, error) {
 }
 
 func (c *interConn) Close() error {
+	if c.onClose != nil && atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
+		defer c.onClose()
+	}
 	return c.stream.Close()
 }
 
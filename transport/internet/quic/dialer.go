@@ -3,6 +3,7 @@ package quic
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	quic "github.com/lucas-clemente/quic-go"
@@ -16,6 +17,9 @@ import (
 type sessionContext struct {
 	rawConn *sysConn
 	session quic.Session
+	// pooled is false if the session is not kept for reuse, and it is closed along with its last stream.
+	pooled  bool
+	streams int32
 }
 
 var errSessionClosed = newError("session closed")
@@ -30,18 +34,46 @@ func (c *sessionContext) openStream(destAddr net.Addr) (*interConn, error) {
 		return nil, err
 	}
 
+	atomic.AddInt32(&c.streams, 1)
 	conn := &interConn{
-		stream: stream,
-		local:  c.session.LocalAddr(),
-		remote: destAddr,
+		stream:  stream,
+		local:   c.session.LocalAddr(),
+		remote:  destAddr,
+		onClose: c.onStreamClosed,
 	}
 
 	return conn, nil
 }
 
+func (c *sessionContext) onStreamClosed() {
+	if atomic.AddInt32(&c.streams, -1) == 0 && !c.pooled {
+		c.close()
+	}
+}
+
+// canOpenStream returns true if a new stream is allowed on the session by the given config.
+func (c *sessionContext) canOpenStream(config *Config) bool {
+	return config.MaxStreamsPerSession == 0 || atomic.LoadInt32(&c.streams) < int32(config.MaxStreamsPerSession)
+}
+
+func (c *sessionContext) close() {
+	if err := c.session.Close(); err != nil {
+		newError("failed to close session").Base(err).WriteToLog()
+	}
+	if err := c.rawConn.Close(); err != nil {
+		newError("failed to close raw connection").Base(err).WriteToLog()
+	}
+}
+
+// sessionKey identifies pooled sessions. Sessions whose packets are sent with different dialers are not shared.
+type sessionKey struct {
+	dest   net.Destination
+	dialer internet.SystemDialer
+}
+
 type clientSessions struct {
 	access   sync.Mutex
-	sessions map[net.Destination][]*sessionContext
+	sessions map[sessionKey][]*sessionContext
 	cleanup  *task.Periodic
 }
 
@@ -61,12 +93,7 @@ func removeInactiveSessions(sessions []*sessionContext) []*sessionContext {
 			activeSessions = append(activeSessions, s)
 			continue
 		}
-		if err := s.session.Close(); err != nil {
-			newError("failed to close session").Base(err).WriteToLog()
-		}
-		if err := s.rawConn.Close(); err != nil {
-			newError("failed to close raw connection").Base(err).WriteToLog()
-		}
+		s.close()
 	}
 
 	if len(activeSessions) < len(sessions) {
@@ -76,9 +103,9 @@ func removeInactiveSessions(sessions []*sessionContext) []*sessionContext {
 	return sessions
 }
 
-func openStream(sessions []*sessionContext, destAddr net.Addr) *interConn {
+func openStream(sessions []*sessionContext, destAddr net.Addr, config *Config) *interConn {
 	for _, s := range sessions {
-		if !isActive(s.session) {
+		if !isActive(s.session) || !s.canOpenStream(config) {
 			continue
 		}
 
@@ -101,12 +128,12 @@ func (s *clientSessions) cleanSessions() error {
 		return nil
 	}
 
-	newSessionMap := make(map[net.Destination][]*sessionContext)
+	newSessionMap := make(map[sessionKey][]*sessionContext)
 
-	for dest, sessions := range s.sessions {
+	for key, sessions := range s.sessions {
 		sessions = removeInactiveSessions(sessions)
 		if len(sessions) > 0 {
-			newSessionMap[dest] = sessions
+			newSessionMap[key] = sessions
 		}
 	}
 
@@ -114,45 +141,38 @@ func (s *clientSessions) cleanSessions() error {
 	return nil
 }
 
-func (s *clientSessions) openConnection(destAddr net.Addr, config *Config, tlsConfig *tls.Config, sockopt *internet.SocketConfig) (internet.Connection, error) {
+func (s *clientSessions) openConnection(ctx context.Context, destAddr net.Addr, config *Config, tlsConfig *tls.Config, sockopt *internet.SocketConfig) (internet.Connection, error) {
 	s.access.Lock()
 	defer s.access.Unlock()
 
 	if s.sessions == nil {
-		s.sessions = make(map[net.Destination][]*sessionContext)
+		s.sessions = make(map[sessionKey][]*sessionContext)
 	}
 
 	dest := net.DestinationFromAddr(destAddr)
+	key := sessionKey{dest: dest, dialer: internet.SystemDialerFromContext(ctx)}
 
 	var sessions []*sessionContext
-	if s, found := s.sessions[dest]; found {
+	if s, found := s.sessions[key]; found {
 		sessions = s
 	}
 
-	if true {
-		conn := openStream(sessions, destAddr)
-		if conn != nil {
-			return conn, nil
-		}
+	if conn := openStream(sessions, destAddr, config); conn != nil {
+		return conn, nil
 	}
 
 	sessions = removeInactiveSessions(sessions)
 
-	rawConn, err := internet.ListenSystemPacket(context.Background(), &net.UDPAddr{
-		IP:   []byte{0, 0, 0, 0},
-		Port: 0,
-	}, sockopt)
+	// Sessions are shared by streams, so they don't live within the context of any stream.
+	dialCtx := internet.ContextWithSystemDialer(context.Background(), key.dialer)
+	rawConn, err := internet.DialSystemPacket(dialCtx, dest, sockopt)
 	if err != nil {
 		return nil, err
 	}
 
-	quicConfig := &quic.Config{
-		ConnectionIDLength: 12,
-		HandshakeTimeout:   time.Second * 8,
-		IdleTimeout:        time.Second * 30,
-	}
+	quicConfig := config.getQuicConfig()
 
-	conn, err := wrapSysConn(rawConn, config)
+	conn, err := wrapSysConn(rawConn, config, false)
 	if err != nil {
 		rawConn.Close()
 		return nil, err
@@ -167,15 +187,31 @@ func (s *clientSessions) openConnection(destAddr net.Addr, config *Config, tlsCo
 	context := &sessionContext{
 		session: session,
 		rawConn: conn,
+		pooled:  config.MaxSessions == 0 || len(sessions) < int(config.MaxSessions),
+	}
+	if context.pooled {
+		sessions = append(sessions, context)
+	}
+	if len(sessions) > 0 {
+		s.sessions[key] = sessions
+	} else {
+		delete(s.sessions, key)
+	}
+
+	stream, err := context.openStream(destAddr)
+	if err != nil {
+		if !context.pooled {
+			context.close()
+		}
+		return nil, err
 	}
-	s.sessions[dest] = append(sessions, context)
-	return context.openStream(destAddr)
+	return stream, nil
 }
 
 var client clientSessions
 
 func init() {
-	client.sessions = make(map[net.Destination][]*sessionContext)
+	client.sessions = make(map[sessionKey][]*sessionContext)
 	client.cleanup = &task.Periodic{
 		Interval: time.Minute,
 		Execute:  client.cleanSessions,
@@ -208,7 +244,7 @@ func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.Me
 
 	config := streamSettings.ProtocolSettings.(*Config)
 
-	return client.openConnection(destAddr, config, tlsConfig, streamSettings.SocketSettings)
+	return client.openConnection(ctx, destAddr, config, tlsConfig, streamSettings.SocketSettings)
 }
 
 func init() {
@@ -100,15 +100,9 @@ func Listen(ctx context.Context, address net.Address, port net.Port, streamSetti
 		return nil, err
 	}
 
-	quicConfig := &quic.Config{
-		ConnectionIDLength:    12,
-		HandshakeTimeout:      time.Second * 8,
-		IdleTimeout:           time.Second * 30,
-		MaxIncomingStreams:    128,
-		MaxIncomingUniStreams: 32,
-	}
+	quicConfig := config.getQuicConfig()
 
-	conn, err := wrapSysConn(rawConn, config)
+	conn, err := wrapSysConn(rawConn, config, true)
 	if err != nil {
 		conn.Close()
 		return nil, err
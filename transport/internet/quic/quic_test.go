@@ -3,6 +3,7 @@ package quic_test
 import (
 	"context"
 	"crypto/rand"
+	"io"
 	"testing"
 	"time"
 
@@ -226,3 +227,65 @@ func TestQuicConnectionAuthHeader(t *testing.T) {
 	common.Must2(b2.ReadFullFrom(conn, N))
 	assert(b2.Bytes(), Equals, b1)
 }
+
+func TestQuicSessionReuse(t *testing.T) {
+	assert := With(t)
+
+	testCases := []struct {
+		config   *quic.Config
+		sessions int
+	}{
+		{
+			config:   &quic.Config{},
+			sessions: 1,
+		},
+		{
+			config: &quic.Config{
+				MaxSessions:          1,
+				MaxStreamsPerSession: 1,
+			},
+			sessions: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		port := udp.PickPort()
+		remotes := make(chan string, 2)
+		listener, err := quic.Listen(context.Background(), net.LocalHostIP, port, &internet.MemoryStreamConfig{
+			ProtocolName:     "quic",
+			ProtocolSettings: tc.config,
+		}, func(conn internet.Connection) {
+			remotes <- conn.RemoteAddr().String()
+			go func() {
+				defer conn.Close()
+				buf.Copy(buf.NewReader(conn), buf.NewWriter(conn))
+			}()
+		})
+		assert(err, IsNil)
+
+		var conns []internet.Connection
+		for i := 0; i < 2; i++ {
+			conn, err := quic.Dial(context.Background(), net.UDPDestination(net.LocalHostIP, port), &internet.MemoryStreamConfig{
+				ProtocolName:     "quic",
+				ProtocolSettings: tc.config,
+			})
+			assert(err, IsNil)
+			conns = append(conns, conn)
+
+			b := []byte("test")
+			common.Must2(conn.Write(b))
+			common.Must2(io.ReadFull(conn, b))
+		}
+
+		sessions := map[string]bool{
+			<-remotes: true,
+			<-remotes: true,
+		}
+		assert(len(sessions), Equals, tc.sessions)
+
+		for _, conn := range conns {
+			assert(conn.Close(), IsNil)
+		}
+		listener.Close()
+	}
+}
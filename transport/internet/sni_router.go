@@ -0,0 +1,310 @@
+package internet
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol/tls"
+	"v2ray.com/core/common/signal/done"
+	"v2ray.com/core/common/task"
+	"v2ray.com/core/transport/internet/proxyproto"
+)
+
+const (
+	sniPeekTimeout = time.Second * 8
+	sniPeekSize    = 5 + 16384
+)
+
+var (
+	sniRouterAccess sync.Mutex
+	sniRouters      = make(map[string]*sniRouter)
+)
+
+// sniRouter accepts connections on an address shared by multiple inbounds, and routes them to the listener of the
+// inbound whose server names match the one in TLS ClientHello. TLS is not terminated by the router.
+type sniRouter struct {
+	sync.Mutex
+	key       string
+	listener  net.Listener
+	sockopt   *SocketConfig
+	listeners []*sniListener
+	fallback  net.Destination
+}
+
+// sniListener is a virtual listener that receives connections routed by sniRouter.
+type sniListener struct {
+	router      *sniRouter
+	serverNames []string
+	conns       chan net.Conn
+	done        *done.Instance
+}
+
+// peekedConn is a connection whose first bytes have been read for routing. They are replayed on Read.
+type peekedConn struct {
+	net.Conn
+	peeked []byte
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	if len(c.peeked) > 0 {
+		n := copy(b, c.peeked)
+		c.peeked = c.peeked[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
+
+// listenSNI registers a virtual listener for the server names in sockopt, on the router of the given address.
+func listenSNI(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.Listener, error) {
+	sniRouterAccess.Lock()
+	defer sniRouterAccess.Unlock()
+
+	key := addr.Network() + ":" + addr.String()
+	router, found := sniRouters[key]
+	if !found {
+		listener, err := effectiveListener.Listen(ctx, addr, sockopt)
+		if err != nil {
+			return nil, err
+		}
+		router = &sniRouter{
+			key:      key,
+			listener: listener,
+			sockopt:  sockopt,
+		}
+		sniRouters[key] = router
+		go router.keepAccepting()
+	} else if !sameListenerOptions(router.sockopt, sockopt) {
+		// The socket options of the shared listener are set by the first inbound on the address.
+		return nil, newError("socket options of inbounds sharing ", addr, " are different").AtError()
+	}
+
+	router.Lock()
+	defer router.Unlock()
+
+	if fallback := sockopt.SniFallback; fallback != nil {
+		dest := fallback.AsDestination()
+		if router.fallback.IsValid() && router.fallback != dest {
+			newError("ignoring SNI fallback ", dest, " as ", router.fallback, " is already set on ", addr).AtWarning().WriteToLog()
+		} else {
+			router.fallback = dest
+		}
+	}
+
+	serverNames := make([]string, 0, len(sockopt.SniServerName))
+	for _, name := range sockopt.SniServerName {
+		serverNames = append(serverNames, strings.ToLower(name))
+	}
+	l := &sniListener{
+		router:      router,
+		serverNames: serverNames,
+		conns:       make(chan net.Conn, 16),
+		done:        done.New(),
+	}
+	router.listeners = append(router.listeners, l)
+	return l, nil
+}
+
+// sameListenerOptions returns true if the options in a and b that apply to the shared listener are the same.
+func sameListenerOptions(a *SocketConfig, b *SocketConfig) bool {
+	return a.Mark == b.Mark &&
+		a.Tfo == b.Tfo &&
+		a.Tproxy == b.Tproxy &&
+		a.ReceiveOriginalDestAddress == b.ReceiveOriginalDestAddress &&
+		a.AcceptProxyProtocol == b.AcceptProxyProtocol &&
+		a.Interface == b.Interface
+}
+
+func matchServerName(pattern string, name string) bool {
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(name, pattern[1:])
+	}
+	return pattern == name
+}
+
+func (r *sniRouter) match(serverName string) *sniListener {
+	r.Lock()
+	defer r.Unlock()
+
+	serverName = strings.ToLower(serverName)
+	for _, l := range r.listeners {
+		for _, pattern := range l.serverNames {
+			if matchServerName(pattern, serverName) {
+				return l
+			}
+		}
+	}
+	return nil
+}
+
+func (r *sniRouter) remove(l *sniListener) error {
+	sniRouterAccess.Lock()
+	defer sniRouterAccess.Unlock()
+
+	r.Lock()
+	defer r.Unlock()
+
+	for i, listener := range r.listeners {
+		if listener == l {
+			r.listeners = append(r.listeners[:i], r.listeners[i+1:]...)
+			break
+		}
+	}
+	if len(r.listeners) > 0 {
+		return nil
+	}
+	delete(sniRouters, r.key)
+	return r.listener.Close()
+}
+
+func (r *sniRouter) keepAccepting() {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			errStr := err.Error()
+			if strings.Contains(errStr, "closed") {
+				break
+			}
+			newError("failed to accept raw connections").Base(err).AtWarning().WriteToLog()
+			if strings.Contains(errStr, "too many") {
+				time.Sleep(time.Millisecond * 500)
+			}
+			continue
+		}
+
+		go r.route(conn)
+	}
+}
+
+// peek reads from the connection until the server name in ClientHello is known.
+func peek(conn net.Conn) ([]byte, string) {
+	if err := conn.SetReadDeadline(time.Now().Add(sniPeekTimeout)); err != nil {
+		newError("failed to set read deadline").Base(err).WriteToLog()
+	}
+	defer conn.SetReadDeadline(time.Time{}) // nolint: errcheck
+
+	payload := make([]byte, 0, buf.Size)
+	for len(payload) < sniPeekSize {
+		if len(payload) == cap(payload) {
+			payload = append(payload, make([]byte, len(payload))...)[:len(payload)]
+		}
+		n, err := conn.Read(payload[len(payload):cap(payload)])
+		payload = payload[:len(payload)+n]
+		if err != nil {
+			return payload, ""
+		}
+
+		header, err := tls.SniffTLS(payload)
+		if err == nil {
+			return payload, header.Domain()
+		}
+		if err != common.ErrNoClue {
+			return payload, ""
+		}
+	}
+	return payload, ""
+}
+
+func (r *sniRouter) route(conn net.Conn) {
+	if r.sockopt.AcceptProxyProtocol {
+		// The PROXY protocol header comes before ClientHello. It is read here, so that it doesn't cut into the time
+		// for ClientHello.
+		proxyConn := proxyproto.NewConn(conn, false)
+		proxyConn.RemoteAddr()
+		conn = proxyConn
+	}
+
+	payload, serverName := peek(conn)
+	c := &peekedConn{
+		Conn:   conn,
+		peeked: payload,
+	}
+
+	if l := r.match(serverName); l != nil {
+		select {
+		case l.conns <- c:
+		case <-l.done.Wait():
+			conn.Close() // nolint: errcheck
+		}
+		return
+	}
+
+	r.Lock()
+	fallback := r.fallback
+	r.Unlock()
+
+	if !fallback.IsValid() {
+		newError("no inbound for server name \"", serverName, "\" on ", r.listener.Addr()).AtInfo().WriteToLog()
+		conn.Close() // nolint: errcheck
+		return
+	}
+	if err := forward(c, fallback); err != nil {
+		newError("failed to forward connection to ", fallback).Base(err).AtInfo().WriteToLog()
+	}
+}
+
+// forward relays the connection to the destination as is.
+func forward(conn net.Conn, dest net.Destination) error {
+	defer conn.Close() // nolint: errcheck
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	target, err := DialSystem(ctx, dest, nil)
+	if err != nil {
+		return newError("failed to dial to ", dest).Base(err)
+	}
+	defer target.Close() // nolint: errcheck
+
+	request := func() error {
+		if err := buf.Copy(buf.NewReader(conn), buf.NewWriter(target)); err != nil {
+			return err
+		}
+		if tcpConn, ok := target.(*net.TCPConn); ok {
+			return tcpConn.CloseWrite()
+		}
+		return nil
+	}
+	response := func() error {
+		return buf.Copy(buf.NewReader(target), buf.NewWriter(conn))
+	}
+	if err := task.Run(task.WithContext(ctx), task.Parallel(request, response))(); err != nil {
+		return newError("connection ends").Base(err)
+	}
+	return nil
+}
+
+// Accept implements net.Listener.
+func (l *sniListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done.Wait():
+		return nil, newError("listener closed")
+	}
+}
+
+// Close implements net.Listener.
+func (l *sniListener) Close() error {
+	if l.done.Done() {
+		return nil
+	}
+	common.Must(l.done.Close())
+	for {
+		select {
+		case conn := <-l.conns:
+			conn.Close() // nolint: errcheck
+		default:
+			return l.router.remove(l)
+		}
+	}
+}
+
+// Addr implements net.Listener.
+func (l *sniListener) Addr() net.Addr {
+	return l.router.listener.Addr()
+}
@@ -0,0 +1,163 @@
+package internet_test
+
+import (
+	"bytes"
+	"context"
+	gotls "crypto/tls"
+	"io"
+	gonet "net"
+	"testing"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol/tls"
+	"v2ray.com/core/testing/servers/tcp"
+	. "v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/proxyproto"
+)
+
+func clientHello(serverName string) []byte {
+	client, server := gonet.Pipe()
+	defer server.Close()
+
+	go func() {
+		_ = gotls.Client(client, &gotls.Config{ServerName: serverName}).Handshake()
+	}()
+
+	header := make([]byte, 5)
+	common.Must2(io.ReadFull(server, header))
+	body := make([]byte, int(header[3])<<8|int(header[4]))
+	common.Must2(io.ReadFull(server, body))
+	return append(header, body...)
+}
+
+func TestSNIRouter(t *testing.T) {
+	fallbackServer := tcp.Server{
+		MsgProcessor: func(b []byte) []byte {
+			return b
+		},
+	}
+	fallback, err := fallbackServer.Start()
+	common.Must(err)
+	defer fallbackServer.Close()
+
+	addr := &net.TCPAddr{
+		IP:   net.LocalHostIP.IP(),
+		Port: int(tcp.PickPort()),
+	}
+	listenerA, err := ListenSystem(context.Background(), addr, &SocketConfig{
+		SniServerName: []string{"a.v2ray.com"},
+		SniFallback: &net.Endpoint{
+			Network: net.Network_TCP,
+			Address: net.NewIPOrDomain(fallback.Address),
+			Port:    uint32(fallback.Port),
+		},
+	})
+	common.Must(err)
+	defer listenerA.Close()
+
+	listenerB, err := ListenSystem(context.Background(), addr, &SocketConfig{
+		SniServerName: []string{"*.b.v2ray.com"},
+	})
+	common.Must(err)
+	defer listenerB.Close()
+
+	testRoute := func(serverName string, listener net.Listener) {
+		hello := clientHello(serverName)
+		conn, err := net.Dial("tcp", addr.String())
+		common.Must(err)
+		defer conn.Close()
+		common.Must2(conn.Write(hello))
+
+		accepted, err := listener.Accept()
+		common.Must(err)
+		defer accepted.Close()
+
+		received := make([]byte, len(hello))
+		common.Must2(io.ReadFull(accepted, received))
+		header, err := tls.SniffTLS(received)
+		common.Must(err)
+		if header.Domain() != serverName {
+			t.Error("unexpected server name: ", header.Domain(), " want ", serverName)
+		}
+	}
+
+	testRoute("a.v2ray.com", listenerA)
+	testRoute("www.b.v2ray.com", listenerB)
+
+	hello := clientHello("www.v2ray.com")
+	conn, err := net.Dial("tcp", addr.String())
+	common.Must(err)
+	defer conn.Close()
+	common.Must2(conn.Write(hello))
+
+	received := make([]byte, len(hello))
+	common.Must2(io.ReadFull(conn, received))
+	if !bytes.Equal(received, hello) {
+		t.Error("unmatched connection is not forwarded to fallback")
+	}
+}
+
+func TestSNIRouterProxyProtocol(t *testing.T) {
+	addr := &net.TCPAddr{
+		IP:   net.LocalHostIP.IP(),
+		Port: int(tcp.PickPort()),
+	}
+	listenerA, err := ListenSystem(context.Background(), addr, &SocketConfig{
+		SniServerName:       []string{"a.v2ray.com"},
+		AcceptProxyProtocol: true,
+	})
+	common.Must(err)
+	defer listenerA.Close()
+
+	listenerB, err := ListenSystem(context.Background(), addr, &SocketConfig{
+		SniServerName:       []string{"b.v2ray.com"},
+		AcceptProxyProtocol: true,
+	})
+	common.Must(err)
+	defer listenerB.Close()
+
+	source := &net.TCPAddr{
+		IP:   net.ParseIP("192.0.2.1"),
+		Port: 12345,
+	}
+	hello := clientHello("b.v2ray.com")
+	conn, err := net.Dial("tcp", addr.String())
+	common.Must(err)
+	defer conn.Close()
+	common.Must(proxyproto.WriteHeader(conn, 2, source, addr))
+	common.Must2(conn.Write(hello))
+
+	accepted, err := listenerB.Accept()
+	common.Must(err)
+	defer accepted.Close()
+
+	if accepted.RemoteAddr().String() != source.String() {
+		t.Error("unexpected remote address: ", accepted.RemoteAddr(), " want ", source)
+	}
+	received := make([]byte, len(hello))
+	common.Must2(io.ReadFull(accepted, received))
+	if !bytes.Equal(received, hello) {
+		t.Error("ClientHello is not passed on as is")
+	}
+}
+
+func TestSNIRouterConflictingOptions(t *testing.T) {
+	addr := &net.TCPAddr{
+		IP:   net.LocalHostIP.IP(),
+		Port: int(tcp.PickPort()),
+	}
+	listener, err := ListenSystem(context.Background(), addr, &SocketConfig{
+		SniServerName: []string{"a.v2ray.com"},
+	})
+	common.Must(err)
+	defer listener.Close()
+
+	if l, err := ListenSystem(context.Background(), addr, &SocketConfig{
+		SniServerName:       []string{"b.v2ray.com"},
+		AcceptProxyProtocol: true,
+	}); err == nil {
+		l.Close()
+		t.Error("expected error for inbounds with different socket options on the same address")
+	}
+}
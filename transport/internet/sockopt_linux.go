@@ -40,6 +40,18 @@ func bindAddr(fd uintptr, ip []byte, port uint32) error {
 	return syscall.Bind(int(fd), sockaddr)
 }
 
+// bindInterface binds the socket to the interface in config, if any. The socket must not be used if it fails, as it
+// would then go through the default route.
+func bindInterface(fd uintptr, config *SocketConfig) error {
+	if len(config.Interface) == 0 {
+		return nil
+	}
+	if err := syscall.SetsockoptString(int(fd), syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, config.Interface); err != nil {
+		return newError("failed to set SO_BINDTODEVICE to ", config.Interface).Base(err)
+	}
+	return nil
+}
+
 func applyOutboundSocketOptions(network string, address string, fd uintptr, config *SocketConfig) error {
 	if config.Mark != 0 {
 		if err := syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_MARK, int(config.Mark)); err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"syscall"
 	"testing"
+	"unsafe"
 
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
@@ -40,3 +41,94 @@ func TestSockOptMark(t *testing.T) {
 	})
 	common.Must(err)
 }
+
+func getBoundDevice(t *testing.T, conn syscall.Conn) string {
+	t.Helper()
+
+	rawConn, err := conn.SyscallConn()
+	common.Must(err)
+
+	var device string
+	common.Must(rawConn.Control(func(fd uintptr) {
+		b := make([]byte, syscall.IFNAMSIZ)
+		size := uint32(len(b))
+		if _, _, errno := syscall.Syscall6(syscall.SYS_GETSOCKOPT, fd, syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, uintptr(unsafe.Pointer(&b[0])), uintptr(unsafe.Pointer(&size)), 0); errno != 0 {
+			t.Fatal("failed to get SO_BINDTODEVICE: ", errno)
+		}
+		for size > 0 && b[size-1] == 0 {
+			size--
+		}
+		device = string(b[:size])
+	}))
+	return device
+}
+
+func TestSockOptInterface(t *testing.T) {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_STREAM, 0)
+	common.Must(err)
+	defer syscall.Close(fd)
+	if err := syscall.SetsockoptString(fd, syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, "lo"); err == syscall.EPERM {
+		t.Skip("requires CAP_NET_RAW")
+	}
+
+	sockopt := &SocketConfig{Interface: "lo"}
+	ctx := context.Background()
+
+	listener, err := ListenSystem(ctx, &net.TCPAddr{IP: net.LocalHostIP.IP()}, sockopt)
+	common.Must(err)
+	defer listener.Close()
+	if device := getBoundDevice(t, listener.(*net.TCPListener)); device != "lo" {
+		t.Error("TCP listener bound to ", device)
+	}
+
+	dest := net.DestinationFromAddr(listener.Addr())
+	tcpConn, err := DefaultSystemDialer{}.Dial(ctx, nil, dest, sockopt)
+	common.Must(err)
+	defer tcpConn.Close()
+	if device := getBoundDevice(t, tcpConn.(*net.TCPConn)); device != "lo" {
+		t.Error("TCP connection bound to ", device)
+	}
+
+	packetConn, err := ListenSystemPacket(ctx, &net.UDPAddr{IP: net.LocalHostIP.IP()}, sockopt)
+	common.Must(err)
+	defer packetConn.Close()
+	if device := getBoundDevice(t, packetConn.(*net.UDPConn)); device != "lo" {
+		t.Error("UDP listener bound to ", device)
+	}
+
+	udpConn, err := DefaultSystemDialer{}.Dial(ctx, nil, net.DestinationFromAddr(packetConn.LocalAddr()), sockopt)
+	common.Must(err)
+	defer udpConn.Close()
+	if device := getBoundDevice(t, udpConn.(*net.UDPConn)); device != "lo" {
+		t.Error("UDP connection bound to ", device)
+	}
+
+	// Traffic still flows over the loopback device.
+	common.Must2(udpConn.Write([]byte("ping")))
+	b := make([]byte, 16)
+	n, _, err := packetConn.ReadFrom(b)
+	common.Must(err)
+	if string(b[:n]) != "ping" {
+		t.Error("unexpected payload: ", string(b[:n]))
+	}
+}
+
+func TestSockOptInvalidInterface(t *testing.T) {
+	sockopt := &SocketConfig{Interface: "v2ray-invalid"}
+	ctx := context.Background()
+
+	if listener, err := ListenSystem(ctx, &net.TCPAddr{IP: net.LocalHostIP.IP()}, sockopt); err == nil {
+		listener.Close()
+		t.Error("expected listening on an invalid interface to fail")
+	}
+
+	if conn, err := ListenSystemPacket(ctx, &net.UDPAddr{IP: net.LocalHostIP.IP()}, sockopt); err == nil {
+		conn.Close()
+		t.Error("expected listening for packets on an invalid interface to fail")
+	}
+
+	if conn, err := (DefaultSystemDialer{}).Dial(ctx, nil, net.TCPDestination(net.LocalHostIP, 80), sockopt); err == nil {
+		conn.Close()
+		t.Error("expected dialing through an invalid interface to fail")
+	}
+}
@@ -13,3 +13,7 @@ func applyInboundSocketOptions(network string, fd uintptr, config *SocketConfig)
 func bindAddr(fd uintptr, ip []byte, port uint32) error {
 	return nil
 }
+
+func bindInterface(fd uintptr, config *SocketConfig) error {
+	return nil
+}
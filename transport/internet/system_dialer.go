@@ -17,6 +17,12 @@ type SystemDialer interface {
 	Dial(ctx context.Context, source net.Address, destination net.Destination, sockopt *SocketConfig) (net.Conn, error)
 }
 
+// PacketDialer is implemented by SystemDialers that can also open packet connections. Packets written to such a
+// connection are sent to the destination it is opened for, regardless of the address they are written to.
+type PacketDialer interface {
+	DialPacket(ctx context.Context, destination net.Destination, sockopt *SocketConfig) (net.PacketConn, error)
+}
+
 type DefaultSystemDialer struct {
 }
 
@@ -28,7 +34,11 @@ func (DefaultSystemDialer) Dial(ctx context.Context, src net.Address, dest net.D
 
 	if sockopt != nil {
 		dialer.Control = func(network, address string, c syscall.RawConn) error {
-			return c.Control(func(fd uintptr) {
+			var bindErr error
+			err := c.Control(func(fd uintptr) {
+				if bindErr = bindInterface(fd, sockopt); bindErr != nil {
+					return
+				}
 				if err := applyOutboundSocketOptions(network, address, fd, sockopt); err != nil {
 					newError("failed to apply socket options").Base(err).WriteToLog(session.ExportIDToError(ctx))
 				}
@@ -38,6 +48,10 @@ func (DefaultSystemDialer) Dial(ctx context.Context, src net.Address, dest net.D
 					}
 				}
 			})
+			if err != nil {
+				return err
+			}
+			return bindErr
 		}
 	}
 
@@ -56,9 +70,172 @@ func (DefaultSystemDialer) Dial(ctx context.Context, src net.Address, dest net.D
 		}
 		dialer.LocalAddr = addr
 	}
+
+	if dest.Network == net.Network_TCP && dest.Address.Family().IsDomain() {
+		addresses, err := lookupAddresses(ctx, dest.Address.Domain(), src)
+		if err != nil {
+			return nil, err
+		}
+		return DialHappyEyeballs(ctx, addresses, sockopt.getHappyEyeballsDelay(), func(ctx context.Context, addr net.Address) (net.Conn, error) {
+			return dialer.DialContext(ctx, dest.Network.SystemString(), net.TCPDestination(addr, dest.Port).NetAddr())
+		})
+	}
 	return dialer.DialContext(ctx, dest.Network.SystemString(), dest.NetAddr())
 }
 
+// lookupAddresses resolves the domain with the system resolver. Only addresses in the family of src are returned if src is set.
+func lookupAddresses(ctx context.Context, domain string, src net.Address) ([]net.Address, error) {
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, domain)
+	if err != nil {
+		return nil, newError("failed to resolve ", domain).Base(err)
+	}
+	addresses := make([]net.Address, 0, len(ips))
+	for _, ip := range ips {
+		addr := net.IPAddress(ip.IP)
+		if src != nil && src != net.AnyIP && src.Family() != addr.Family() {
+			continue
+		}
+		addresses = append(addresses, addr)
+	}
+	if len(addresses) == 0 {
+		return nil, newError("no suitable address for ", domain)
+	}
+	return addresses, nil
+}
+
+// sortHappyEyeballs orders the addresses so that IPv6 and IPv4 addresses alternate, starting with IPv6.
+func sortHappyEyeballs(addresses []net.Address) []net.Address {
+	var ipv6, ipv4 []net.Address
+	for _, addr := range addresses {
+		if addr.Family().IsIPv6() {
+			ipv6 = append(ipv6, addr)
+		} else {
+			ipv4 = append(ipv4, addr)
+		}
+	}
+	sorted := make([]net.Address, 0, len(addresses))
+	for i := 0; i < len(ipv6) || i < len(ipv4); i++ {
+		if i < len(ipv6) {
+			sorted = append(sorted, ipv6[i])
+		}
+		if i < len(ipv4) {
+			sorted = append(sorted, ipv4[i])
+		}
+	}
+	return sorted
+}
+
+// DialHappyEyeballs connects to one of the given addresses as in Happy Eyeballs (RFC 8305). IPv6 and IPv4 addresses are
+// tried alternately, starting with IPv6. The next attempt starts when the previous one fails or after the given delay,
+// whichever comes first. The first established connection is returned, and the other attempts are cancelled. The
+// attempts are not cancelled through ctx, but only by DialHappyEyeballs, so the context of the established connection
+// stays alive after return.
+func DialHappyEyeballs(ctx context.Context, addresses []net.Address, delay time.Duration, dial func(context.Context, net.Address) (net.Conn, error)) (net.Conn, error) {
+	if len(addresses) == 0 {
+		return nil, newError("no address to dial")
+	}
+	addresses = sortHappyEyeballs(addresses)
+
+	type result struct {
+		index int
+		conn  net.Conn
+		addr  net.Address
+		err   error
+	}
+	// Buffered so that the attempts still running never block after return.
+	results := make(chan result, len(addresses))
+	next := 0
+	pending := 0
+	var timeout <-chan time.Time
+	cancels := make([]context.CancelFunc, 0, len(addresses))
+
+	start := func() {
+		index := next
+		addr := addresses[index]
+		next++
+		pending++
+		attemptCtx, cancel := context.WithCancel(detachedContext{ctx})
+		cancels = append(cancels, cancel)
+		go func() {
+			conn, err := dial(attemptCtx, addr)
+			results <- result{index: index, conn: conn, addr: addr, err: err}
+		}()
+		if next < len(addresses) {
+			timeout = time.After(delay)
+		} else {
+			timeout = nil
+		}
+	}
+	// closeLosers cancels the attempts other than the winner, and closes connections that are established after the
+	// winner is chosen. winner is -1 if there is none.
+	closeLosers := func(winner int) {
+		for i, cancel := range cancels {
+			if i != winner {
+				cancel()
+			}
+		}
+		go func(n int) {
+			for i := 0; i < n; i++ {
+				if r := <-results; r.conn != nil {
+					r.conn.Close() // nolint: errcheck
+				}
+			}
+		}(pending)
+	}
+
+	start()
+	var lastErr error
+	for {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				closeLosers(r.index)
+				newError("connected to ", r.addr, " over ", familyName(r.addr)).WriteToLog(session.ExportIDToError(ctx))
+				return r.conn, nil
+			}
+			lastErr = r.err
+			cancels[r.index]()
+			newError("failed to connect to ", r.addr).Base(r.err).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+			if next < len(addresses) {
+				start()
+			} else if pending == 0 {
+				return nil, lastErr
+			}
+		case <-timeout:
+			start()
+		case <-ctx.Done():
+			closeLosers(-1)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// detachedContext carries the values of its parent, but is never cancelled. A context derived from it is not registered
+// on the parent, so it doesn't have to be cancelled to be released.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func familyName(addr net.Address) string {
+	if addr.Family().IsIPv6() {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
 type SystemDialerAdapter interface {
 	Dial(network string, address string) (net.Conn, error)
 }
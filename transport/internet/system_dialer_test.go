@@ -0,0 +1,191 @@
+package internet_test
+
+import (
+	"context"
+	"errors"
+	gonet "net"
+	"sync"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common/net"
+	. "v2ray.com/core/transport/internet"
+)
+
+type fakeDialer struct {
+	sync.Mutex
+	attempts  []string
+	cancelled []string
+	dial      func(ctx context.Context, addr net.Address) (net.Conn, error)
+}
+
+func (d *fakeDialer) Dial(ctx context.Context, addr net.Address) (net.Conn, error) {
+	d.Lock()
+	d.attempts = append(d.attempts, addr.String())
+	d.Unlock()
+
+	conn, err := d.dial(ctx, addr)
+	if err == context.Canceled {
+		d.Lock()
+		d.cancelled = append(d.cancelled, addr.String())
+		d.Unlock()
+	}
+	return conn, err
+}
+
+func pipeConn() net.Conn {
+	conn, _ := gonet.Pipe()
+	return conn
+}
+
+func TestHappyEyeballsFallbackToIPv4(t *testing.T) {
+	var winnerCtx context.Context
+	dialer := &fakeDialer{
+		dial: func(ctx context.Context, addr net.Address) (net.Conn, error) {
+			if addr.Family().IsIPv6() {
+				// A black-holed IPv6 route.
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			winnerCtx = ctx
+			return pipeConn(), nil
+		},
+	}
+
+	addresses := []net.Address{net.ParseAddress("1.2.3.4"), net.ParseAddress("2001:db8::1")}
+	start := time.Now()
+	conn, err := DialHappyEyeballs(context.Background(), addresses, 50*time.Millisecond, dialer.Dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if d := time.Since(start); d < 50*time.Millisecond || d > 2*time.Second {
+		t.Error("unexpected dial time: ", d)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	dialer.Lock()
+	defer dialer.Unlock()
+	if len(dialer.attempts) != 2 || dialer.attempts[0] != "[2001:db8::1]" {
+		t.Error("unexpected attempts: ", dialer.attempts)
+	}
+	if len(dialer.cancelled) != 1 || dialer.cancelled[0] != "[2001:db8::1]" {
+		t.Error("IPv6 attempt not cancelled: ", dialer.cancelled)
+	}
+	// The connection may depend on the context it is dialed with.
+	if err := winnerCtx.Err(); err != nil {
+		t.Error("context of the established connection is cancelled: ", err)
+	}
+}
+
+func TestHappyEyeballsPreferIPv6(t *testing.T) {
+	dialer := &fakeDialer{
+		dial: func(ctx context.Context, addr net.Address) (net.Conn, error) {
+			return pipeConn(), nil
+		},
+	}
+
+	addresses := []net.Address{net.ParseAddress("1.2.3.4"), net.ParseAddress("2001:db8::1")}
+	conn, err := DialHappyEyeballs(context.Background(), addresses, time.Second, dialer.Dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	dialer.Lock()
+	defer dialer.Unlock()
+	if len(dialer.attempts) != 1 || dialer.attempts[0] != "[2001:db8::1]" {
+		t.Error("unexpected attempts: ", dialer.attempts)
+	}
+}
+
+func TestHappyEyeballsAllFailed(t *testing.T) {
+	dialer := &fakeDialer{
+		dial: func(ctx context.Context, addr net.Address) (net.Conn, error) {
+			return nil, errors.New("refused")
+		},
+	}
+
+	addresses := []net.Address{
+		net.ParseAddress("1.2.3.4"),
+		net.ParseAddress("1.2.3.5"),
+		net.ParseAddress("1.2.3.6"),
+		net.ParseAddress("2001:db8::1"),
+		net.ParseAddress("2001:db8::2"),
+	}
+	start := time.Now()
+	if _, err := DialHappyEyeballs(context.Background(), addresses, time.Second, dialer.Dial); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+	// Failed attempts start the next one without waiting.
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Error("unexpected dial time: ", d)
+	}
+
+	dialer.Lock()
+	defer dialer.Unlock()
+	expected := []string{"[2001:db8::1]", "1.2.3.4", "[2001:db8::2]", "1.2.3.5", "1.2.3.6"}
+	if len(dialer.attempts) != len(expected) {
+		t.Fatal("unexpected attempts: ", dialer.attempts)
+	}
+	for i, addr := range expected {
+		if dialer.attempts[i] != addr {
+			t.Error("unexpected attempts: ", dialer.attempts)
+			break
+		}
+	}
+}
+
+func TestHappyEyeballsContext(t *testing.T) {
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+
+	var attemptCtx context.Context
+	dialer := &fakeDialer{
+		dial: func(ctx context.Context, addr net.Address) (net.Conn, error) {
+			attemptCtx = ctx
+			return pipeConn(), nil
+		},
+	}
+
+	conn, err := DialHappyEyeballs(ctx, []net.Address{net.ParseAddress("1.2.3.4")}, time.Second, dialer.Dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if v := attemptCtx.Value(key{}); v != "value" {
+		t.Error("unexpected value in attempt context: ", v)
+	}
+	// The established connection doesn't depend on ctx, so nothing is left on it.
+	cancel()
+	if err := attemptCtx.Err(); err != nil {
+		t.Error("attempt context is cancelled: ", err)
+	}
+}
+
+func TestDefaultSystemDialerDomain(t *testing.T) {
+	listener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	port := net.Port(listener.Addr().(*gonet.TCPAddr).Port)
+	conn, err := DefaultSystemDialer{}.Dial(context.Background(), nil, net.TCPDestination(net.DomainAddress("localhost"), port), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if addr := conn.RemoteAddr().(*gonet.TCPAddr); !addr.IP.IsLoopback() || addr.Port != int(port) {
+		t.Error("unexpected remote address: ", addr)
+	}
+}
@@ -20,8 +20,12 @@ type DefaultListener struct {
 
 func getControlFunc(ctx context.Context, sockopt *SocketConfig, contollers []controller) func(network, address string, c syscall.RawConn) error {
 	return func(network, address string, c syscall.RawConn) error {
-		return c.Control(func(fd uintptr) {
+		var bindErr error
+		err := c.Control(func(fd uintptr) {
 			if sockopt != nil {
+				if bindErr = bindInterface(fd, sockopt); bindErr != nil {
+					return
+				}
 				if err := applyInboundSocketOptions(network, fd, sockopt); err != nil {
 					newError("failed to apply socket options to incoming connection").Base(err).WriteToLog(session.ExportIDToError(ctx))
 				}
@@ -33,6 +37,10 @@ func getControlFunc(ctx context.Context, sockopt *SocketConfig, contollers []con
 				}
 			}
 		})
+		if err != nil {
+			return err
+		}
+		return bindErr
 	}
 }
 
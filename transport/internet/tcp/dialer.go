@@ -7,6 +7,7 @@ import (
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/proxyproto"
 	"v2ray.com/core/transport/internet/tls"
 )
 
@@ -18,8 +19,27 @@ func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.Me
 		return nil, err
 	}
 
+	if version := streamSettings.SocketSettings.GetProxyProtocol(); version > 0 {
+		var source net.Addr
+		if inbound := session.InboundFromContext(ctx); inbound != nil && inbound.Source.Address != nil && inbound.Source.Address.Family().IsIP() {
+			source = &net.TCPAddr{
+				IP:   inbound.Source.Address.IP(),
+				Port: int(inbound.Source.Port),
+			}
+		}
+		if err := proxyproto.WriteHeader(conn, version, source, conn.RemoteAddr()); err != nil {
+			conn.Close() // nolint: errcheck
+			return nil, err
+		}
+	}
+
 	if config := tls.ConfigFromStreamSettings(streamSettings); config != nil {
-		conn = tls.Client(conn, config.GetTLSConfig(tls.WithDestination(dest), tls.WithNextProto("h2")))
+		tlsConfig := config.GetTLSConfig(tls.WithDestination(dest), tls.WithNextProto("h2"))
+		if fingerprint := tls.GetFingerprint(config.Fingerprint); fingerprint != nil {
+			conn = tls.UClient(conn, tlsConfig, fingerprint)
+		} else {
+			conn = tls.Client(conn, tlsConfig)
+		}
 	}
 
 	tcpSettings := streamSettings.ProtocolSettings.(*Config)
@@ -9,6 +9,7 @@ import (
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/proxyproto"
 	"v2ray.com/core/transport/internet/tls"
 )
 
@@ -32,6 +33,11 @@ func ListenTCP(ctx context.Context, address net.Address, port net.Port, streamSe
 	}
 	newError("listening TCP on ", address, ":", port).WriteToLog(session.ExportIDToError(ctx))
 
+	// The SNI router reads PROXY protocol headers itself, before routing connections by server name.
+	if streamSettings.SocketSettings.GetAcceptProxyProtocol() && len(streamSettings.SocketSettings.GetSniServerName()) == 0 {
+		listener = proxyproto.NewListener(listener, nil)
+	}
+
 	tcpSettings := streamSettings.ProtocolSettings.(*Config)
 	l := &Listener{
 		listener: listener,
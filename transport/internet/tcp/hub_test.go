@@ -0,0 +1,55 @@
+package tcp_test
+
+import (
+	"context"
+	"testing"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/transport/internet"
+	. "v2ray.com/core/transport/internet/tcp"
+)
+
+func TestProxyProtocol(t *testing.T) {
+	for _, version := range []uint32{1, 2} {
+		conns := make(chan internet.Connection, 1)
+		listener, err := ListenTCP(context.Background(), net.LocalHostIP, 0, &internet.MemoryStreamConfig{
+			ProtocolName:     "tcp",
+			ProtocolSettings: &Config{},
+			SocketSettings: &internet.SocketConfig{
+				AcceptProxyProtocol: true,
+			},
+		}, func(conn internet.Connection) {
+			conns <- conn
+		})
+		common.Must(err)
+
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+			Source: net.TCPDestination(net.ParseAddress("1.2.3.4"), 1234),
+		})
+		conn, err := Dial(ctx, net.DestinationFromAddr(listener.Addr()), &internet.MemoryStreamConfig{
+			ProtocolName:     "tcp",
+			ProtocolSettings: &Config{},
+			SocketSettings: &internet.SocketConfig{
+				ProxyProtocol: version,
+			},
+		})
+		common.Must(err)
+		common.Must2(conn.Write([]byte("test")))
+
+		serverConn := <-conns
+		if addr := serverConn.RemoteAddr().String(); addr != "1.2.3.4:1234" {
+			t.Error("version ", version, ": unexpected remote address ", addr)
+		}
+		b := make([]byte, 4)
+		common.Must2(serverConn.Read(b))
+		if string(b) != "test" {
+			t.Error("version ", version, ": unexpected payload ", string(b))
+		}
+
+		conn.Close()
+		serverConn.Close()
+		listener.Close()
+	}
+}
@@ -56,6 +56,9 @@ func ListenTCP(ctx context.Context, address net.Address, port net.Port, settings
 //
 // v2ray:api:beta
 func ListenSystem(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.Listener, error) {
+	if sockopt != nil && len(sockopt.SniServerName) > 0 {
+		return listenSNI(ctx, addr, sockopt)
+	}
 	return effectiveListener.Listen(ctx, addr, sockopt)
 }
 
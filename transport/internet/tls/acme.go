@@ -0,0 +1,96 @@
+package tls
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+var (
+	acmeAccess   sync.Mutex
+	acmeManagers = make(map[string]*autocert.Manager)
+)
+
+// GetDirectoryUrlValue returns the URL of ACME directory, or the Let's Encrypt one if not set.
+func (c *AcmeConfig) GetDirectoryUrlValue() string {
+	if len(c.DirectoryUrl) == 0 {
+		return autocert.DefaultACMEDirectory
+	}
+	return c.DirectoryUrl
+}
+
+func (c *Config) getAcmeDomains() []string {
+	domains := make([]string, 0, len(c.Acme.Domain)+1)
+	if len(c.ServerName) > 0 {
+		domains = append(domains, strings.ToLower(c.ServerName))
+	}
+	for _, domain := range c.Acme.Domain {
+		domains = append(domains, strings.ToLower(domain))
+	}
+	return domains
+}
+
+// getAcmeManager returns the ACME manager for this config. Configs with the same ACME settings share one manager,
+// so that certificates are not requested more than once.
+func (c *Config) getAcmeManager(domains []string) *autocert.Manager {
+	settings := c.Acme
+	id := strings.Join(append([]string{settings.GetDirectoryUrlValue(), settings.Email, settings.CacheDir}, domains...), "\x00")
+
+	acmeAccess.Lock()
+	defer acmeAccess.Unlock()
+
+	if m, found := acmeManagers[id]; found {
+		return m
+	}
+
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Email:      settings.Email,
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Client: &acme.Client{
+			DirectoryURL: settings.GetDirectoryUrlValue(),
+			HTTPClient: &http.Client{
+				Transport: &http.Transport{
+					Proxy: http.ProxyFromEnvironment,
+					TLSClientConfig: &tls.Config{
+						RootCAs: c.getCertPool(),
+					},
+				},
+			},
+		},
+	}
+	if len(settings.CacheDir) > 0 {
+		m.Cache = autocert.DirCache(settings.CacheDir)
+	}
+	acmeManagers[id] = m
+	return m
+}
+
+// getCertificateFromAcme returns a GetCertificate callback that serves certificates of the given domains from the
+// ACME manager, including TLS-ALPN-01 challenge certificates. Other server names are passed to next if not nil,
+// or served with static certificates in config. The certificate of the first domain is used as last resort.
+func getCertificateFromAcme(config *tls.Config, m *autocert.Manager, domains []string, next func(*tls.ClientHelloInfo) (*tls.Certificate, error)) func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		serverName := strings.TrimSuffix(strings.ToLower(hello.ServerName), ".")
+		for _, domain := range domains {
+			if serverName == domain {
+				return m.GetCertificate(hello)
+			}
+		}
+
+		if next != nil {
+			return next(hello)
+		}
+		if len(config.Certificates) > 0 {
+			return nil, nil
+		}
+
+		fallback := *hello
+		fallback.ServerName = domains[0]
+		return m.GetCertificate(&fallback)
+	}
+}
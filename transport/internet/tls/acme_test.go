@@ -0,0 +1,280 @@
+package tls_test
+
+import (
+	gotls "crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/protocol/tls/cert"
+	. "v2ray.com/core/transport/internet/tls"
+)
+
+var idPeAcmeIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+// testCA is a minimal ACME CA in the style of Pebble. It validates TLS-ALPN-01 challenges by connecting to target.
+type testCA struct {
+	sync.Mutex
+	server *httptest.Server
+	domain string
+	target string
+	ca     *cert.Certificate
+	caCert *x509.Certificate
+
+	validated bool
+	issued    []byte
+}
+
+func newTestCA(domain string) *testCA {
+	ca := &testCA{
+		domain: domain,
+		ca:     cert.MustGenerate(nil, cert.Authority(true), cert.KeyUsage(x509.KeyUsageCertSign), cert.CommonName("Test CA")),
+	}
+	caCert, err := x509.ParseCertificate(ca.ca.Certificate)
+	common.Must(err)
+	ca.caCert = caCert
+	ca.server = httptest.NewTLSServer(http.HandlerFunc(ca.handle))
+	return ca
+}
+
+func (ca *testCA) url(path string) string {
+	return ca.server.URL + path
+}
+
+func (ca *testCA) order() map[string]interface{} {
+	status := "pending"
+	if ca.validated {
+		status = "ready"
+	}
+	order := map[string]interface{}{
+		"status":         status,
+		"identifiers":    []interface{}{map[string]string{"type": "dns", "value": ca.domain}},
+		"authorizations": []string{ca.url("/authz")},
+		"finalize":       ca.url("/finalize"),
+	}
+	if ca.issued != nil {
+		order["status"] = "valid"
+		order["certificate"] = ca.url("/cert")
+	}
+	return order
+}
+
+func (ca *testCA) challenge() map[string]interface{} {
+	status := "pending"
+	if ca.validated {
+		status = "valid"
+	}
+	return map[string]interface{}{
+		"type":   "tls-alpn-01",
+		"url":    ca.url("/challenge"),
+		"token":  "token",
+		"status": status,
+	}
+}
+
+func (ca *testCA) validate() bool {
+	conn, err := gotls.Dial("tcp", ca.target, &gotls.Config{
+		ServerName:         ca.domain,
+		NextProtos:         []string{"acme-tls/1"},
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	if state.NegotiatedProtocol != "acme-tls/1" {
+		return false
+	}
+	for _, ext := range state.PeerCertificates[0].Extensions {
+		if ext.Id.Equal(idPeAcmeIdentifier) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ca *testCA) issue(payload []byte) error {
+	var request struct {
+		CSR string `json:"csr"`
+	}
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return err
+	}
+	der, err := base64.RawURLEncoding.DecodeString(request.CSR)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour * 24 * 90),
+		DNSNames:     csr.DNSNames,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	caKey, err := x509.ParsePKCS1PrivateKey(ca.ca.PrivateKey)
+	if err != nil {
+		return err
+	}
+	issued, err := x509.CreateCertificate(nil, template, ca.caCert, csr.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	ca.issued = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: issued}), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.ca.Certificate})...)
+	return nil
+}
+
+func (ca *testCA) handle(w http.ResponseWriter, r *http.Request) {
+	ca.Lock()
+	defer ca.Unlock()
+
+	w.Header().Set("Replay-Nonce", base64.RawURLEncoding.EncodeToString(big.NewInt(time.Now().UnixNano()).Bytes()))
+
+	var payload []byte
+	if r.Method == http.MethodPost {
+		var jws struct {
+			Payload string `json:"payload"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		payload, _ = base64.RawURLEncoding.DecodeString(jws.Payload)
+	}
+
+	var response interface{}
+	status := http.StatusOK
+
+	switch r.URL.Path {
+	case "/directory":
+		response = map[string]string{
+			"newNonce":   ca.url("/nonce"),
+			"newAccount": ca.url("/account"),
+			"newOrder":   ca.url("/order"),
+			"revokeCert": ca.url("/revoke"),
+			"keyChange":  ca.url("/key-change"),
+		}
+	case "/nonce":
+		return
+	case "/account":
+		w.Header().Set("Location", ca.url("/account/1"))
+		status = http.StatusCreated
+		response = map[string]string{"status": "valid"}
+	case "/order":
+		w.Header().Set("Location", ca.url("/order/1"))
+		status = http.StatusCreated
+		response = ca.order()
+	case "/order/1":
+		w.Header().Set("Location", ca.url("/order/1"))
+		response = ca.order()
+	case "/authz":
+		status := "pending"
+		if ca.validated {
+			status = "valid"
+		}
+		response = map[string]interface{}{
+			"status":     status,
+			"identifier": map[string]string{"type": "dns", "value": ca.domain},
+			"challenges": []interface{}{ca.challenge()},
+		}
+	case "/challenge":
+		ca.validated = ca.validate()
+		response = ca.challenge()
+	case "/finalize":
+		if err := ca.issue(payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Location", ca.url("/order/1"))
+		response = ca.order()
+	case "/cert":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		common.Must2(w.Write(ca.issued))
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	common.Must(json.NewEncoder(w).Encode(response))
+}
+
+func TestAcmeCertificate(t *testing.T) {
+	const domain = "www.v2ray.com"
+
+	ca := newTestCA(domain)
+	defer ca.server.Close()
+
+	cacheDir, err := ioutil.TempDir("", "v2ray-acme")
+	common.Must(err)
+	defer os.RemoveAll(cacheDir)
+
+	c := &Config{
+		ServerName: domain,
+		Certificate: []*Certificate{{
+			Certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.server.Certificate().Raw}),
+			Usage:       Certificate_AUTHORITY_VERIFY,
+		}},
+		Acme: &AcmeConfig{
+			DirectoryUrl: ca.url("/directory"),
+			CacheDir:     cacheDir,
+		},
+	}
+
+	listener, err := gotls.Listen("tcp", "127.0.0.1:0", c.GetTLSConfig())
+	common.Must(err)
+	defer listener.Close()
+	ca.target = listener.Addr().String()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				_ = conn.(*gotls.Conn).Handshake()
+				conn.Close()
+			}(conn)
+		}
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.caCert)
+	conn, err := gotls.Dial("tcp", ca.target, &gotls.Config{
+		ServerName: domain,
+		RootCAs:    roots,
+	})
+	if err != nil {
+		t.Fatal("failed to handshake with ACME certificate: ", err)
+	}
+	conn.Close()
+
+	if _, err := os.Stat(filepath.Join(cacheDir, "acme_account+key")); err != nil {
+		t.Error("account key is not cached: ", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, domain)); err != nil {
+		t.Error("certificate is not cached: ", err)
+	}
+}
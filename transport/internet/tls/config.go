@@ -6,6 +6,8 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/crypto/acme"
+
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol/tls/cert"
 	"v2ray.com/core/transport/internet"
@@ -25,10 +27,22 @@ func ParseCertificate(c *cert.Certificate) *Certificate {
 }
 
 // BuildCertificates builds a list of TLS certificates from proto definition.
+// Certificates given as file paths are included as currently loaded.
 func (c *Config) BuildCertificates() []tls.Certificate {
+	certs := c.buildStaticCertificates()
+	for _, w := range c.getCertificateWatchers() {
+		if keyPair := w.get(); keyPair != nil {
+			certs = append(certs, *keyPair)
+		}
+	}
+	return certs
+}
+
+// buildStaticCertificates builds the TLS certificates that are embedded in proto definition.
+func (c *Config) buildStaticCertificates() []tls.Certificate {
 	certs := make([]tls.Certificate, 0, len(c.Certificate))
 	for _, entry := range c.Certificate {
-		if entry.Usage != Certificate_ENCIPHERMENT {
+		if entry.Usage != Certificate_ENCIPHERMENT || len(entry.CertificateFile) > 0 {
 			continue
 		}
 		keyPair, err := tls.X509KeyPair(entry.Certificate, entry.Key)
@@ -154,6 +168,8 @@ func (c *Config) GetTLSConfig(opts ...Option) *tls.Config {
 		opt(config)
 	}
 
+	c.applyParams(config)
+
 	if !c.AllowInsecureCiphers && len(config.CipherSuites) == 0 {
 		config.CipherSuites = []uint16{
 			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
@@ -172,7 +188,8 @@ func (c *Config) GetTLSConfig(opts ...Option) *tls.Config {
 	}
 
 	config.InsecureSkipVerify = c.AllowInsecure
-	config.Certificates = c.BuildCertificates()
+	applyPeerVerification(c, config)
+	config.Certificates = c.buildStaticCertificates()
 	config.BuildNameToCertificate()
 
 	caCerts := c.getCustomCA()
@@ -180,14 +197,33 @@ func (c *Config) GetTLSConfig(opts ...Option) *tls.Config {
 		config.GetCertificate = getGetCertificateFunc(config, caCerts)
 	}
 
+	if watchers := c.getCertificateWatchers(); len(watchers) > 0 {
+		config.GetCertificate = getCertificateFromFiles(config, watchers, config.GetCertificate)
+	}
+
+	if c.Acme != nil {
+		if domains := c.getAcmeDomains(); len(domains) > 0 {
+			config.GetCertificate = getCertificateFromAcme(config, c.getAcmeManager(domains), domains, config.GetCertificate)
+		} else {
+			newError("no domain configured for ACME").AtWarning().WriteToLog()
+		}
+	}
+
 	if len(c.ServerName) > 0 {
 		config.ServerName = c.ServerName
 	}
-	if len(c.NextProtocol) > 0 {
+	if c.ExplicitAlpn {
 		config.NextProtos = c.NextProtocol
+	} else {
+		if len(c.NextProtocol) > 0 {
+			config.NextProtos = c.NextProtocol
+		}
+		if len(config.NextProtos) == 0 {
+			config.NextProtos = []string{"http/1.1"}
+		}
 	}
-	if len(config.NextProtos) == 0 {
-		config.NextProtos = []string{"http/1.1"}
+	if c.Acme != nil {
+		config.NextProtos = append(append([]string(nil), config.NextProtos...), acme.ALPNProto)
 	}
 
 	return config
@@ -20,7 +20,10 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type Certificate_Usage int32
 
 const (
-	Certificate_ENCIPHERMENT     Certificate_Usage = 0
+	Certificate_ENCIPHERMENT Certificate_Usage = 0
+	// Certificate of a CA that verifies peer certificates. On server side,
+	// client certificates are verified by such CAs if
+	// require_client_certificate is set.
 	Certificate_AUTHORITY_VERIFY Certificate_Usage = 1
 	Certificate_AUTHORITY_ISSUE  Certificate_Usage = 2
 )
@@ -49,11 +52,17 @@ type Certificate struct {
 	// TLS certificate in x509 format.
 	Certificate []byte `protobuf:"bytes,1,opt,name=Certificate,proto3" json:"Certificate,omitempty"`
 	// TLS key in x509 format.
-	Key                  []byte            `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
-	Usage                Certificate_Usage `protobuf:"varint,3,opt,name=usage,proto3,enum=v2ray.core.transport.internet.tls.Certificate_Usage" json:"usage,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Key   []byte            `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+	Usage Certificate_Usage `protobuf:"varint,3,opt,name=usage,proto3,enum=v2ray.core.transport.internet.tls.Certificate_Usage" json:"usage,omitempty"`
+	// Path to the TLS certificate file in PEM format. If set, the certificate is
+	// loaded from the file instead of Certificate, and reloaded when the file
+	// changes. Only effective for ENCIPHERMENT usage.
+	CertificateFile string `protobuf:"bytes,4,opt,name=certificate_file,json=certificateFile,proto3" json:"certificate_file,omitempty"`
+	// Path to the TLS key file in PEM format. Used together with certificate_file.
+	KeyFile              string   `protobuf:"bytes,5,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Certificate) Reset()         { *m = Certificate{} }
@@ -102,6 +111,88 @@ func (m *Certificate) GetUsage() Certificate_Usage {
 	return Certificate_ENCIPHERMENT
 }
 
+func (m *Certificate) GetCertificateFile() string {
+	if m != nil {
+		return m.CertificateFile
+	}
+	return ""
+}
+
+func (m *Certificate) GetKeyFile() string {
+	if m != nil {
+		return m.KeyFile
+	}
+	return ""
+}
+
+type AcmeConfig struct {
+	// URL of the ACME directory. Let's Encrypt is used if empty.
+	DirectoryUrl string `protobuf:"bytes,1,opt,name=directory_url,json=directoryUrl,proto3" json:"directory_url,omitempty"`
+	// Contact email of the ACME account.
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// Directory to cache the account key and certificates. Certificates are
+	// only kept in memory if empty.
+	CacheDir string `protobuf:"bytes,3,opt,name=cache_dir,json=cacheDir,proto3" json:"cache_dir,omitempty"`
+	// Domains to obtain certificates for, in addition to server_name.
+	Domain               []string `protobuf:"bytes,4,rep,name=domain,proto3" json:"domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcmeConfig) Reset()         { *m = AcmeConfig{} }
+func (m *AcmeConfig) String() string { return proto.CompactTextString(m) }
+func (*AcmeConfig) ProtoMessage()    {}
+func (*AcmeConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42ed70cad60a2736, []int{1}
+}
+
+func (m *AcmeConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcmeConfig.Unmarshal(m, b)
+}
+func (m *AcmeConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcmeConfig.Marshal(b, m, deterministic)
+}
+func (m *AcmeConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcmeConfig.Merge(m, src)
+}
+func (m *AcmeConfig) XXX_Size() int {
+	return xxx_messageInfo_AcmeConfig.Size(m)
+}
+func (m *AcmeConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcmeConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcmeConfig proto.InternalMessageInfo
+
+func (m *AcmeConfig) GetDirectoryUrl() string {
+	if m != nil {
+		return m.DirectoryUrl
+	}
+	return ""
+}
+
+func (m *AcmeConfig) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *AcmeConfig) GetCacheDir() string {
+	if m != nil {
+		return m.CacheDir
+	}
+	return ""
+}
+
+func (m *AcmeConfig) GetDomain() []string {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
 type Config struct {
 	// Whether or not to allow self-signed certificates.
 	AllowInsecure bool `protobuf:"varint,1,opt,name=allow_insecure,json=allowInsecure,proto3" json:"allow_insecure,omitempty"`
@@ -114,7 +205,45 @@ type Config struct {
 	// Lists of string as ALPN values.
 	NextProtocol []string `protobuf:"bytes,4,rep,name=next_protocol,json=nextProtocol,proto3" json:"next_protocol,omitempty"`
 	// Whether or not to disable session (ticket) resumption.
-	DisableSessionResumption bool     `protobuf:"varint,6,opt,name=disable_session_resumption,json=disableSessionResumption,proto3" json:"disable_session_resumption,omitempty"`
+	DisableSessionResumption bool `protobuf:"varint,6,opt,name=disable_session_resumption,json=disableSessionResumption,proto3" json:"disable_session_resumption,omitempty"`
+	// Interval in seconds to check certificate files for changes. Default 60.
+	CertificateReloadInterval uint32 `protobuf:"varint,7,opt,name=certificate_reload_interval,json=certificateReloadInterval,proto3" json:"certificate_reload_interval,omitempty"`
+	// If set, certificates are obtained and renewed automatically from an ACME
+	// CA, using TLS-ALPN-01 challenge on the listener itself.
+	Acme *AcmeConfig `protobuf:"bytes,8,opt,name=acme,proto3" json:"acme,omitempty"`
+	// SHA-256 hashes of the peer leaf certificates in DER format. If any pin is
+	// set, the peer certificate is accepted only if it matches one of the pins,
+	// and chain verification is skipped.
+	PinnedPeerCertificateSha256 [][]byte `protobuf:"bytes,9,rep,name=pinned_peer_certificate_sha256,json=pinnedPeerCertificateSha256,proto3" json:"pinned_peer_certificate_sha256,omitempty"`
+	// SHA-256 hashes of the peer leaf public keys, in DER-encoded
+	// SubjectPublicKeyInfo format. Works together with
+	// pinned_peer_certificate_sha256.
+	PinnedPeerPublicKeySha256 [][]byte `protobuf:"bytes,10,rep,name=pinned_peer_public_key_sha256,json=pinnedPeerPublicKeySha256,proto3" json:"pinned_peer_public_key_sha256,omitempty"`
+	// Minimum TLS version, one of "1.0", "1.1", "1.2" and "1.3".
+	MinVersion string `protobuf:"bytes,11,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	// Maximum TLS version, one of "1.0", "1.1", "1.2" and "1.3".
+	MaxVersion string `protobuf:"bytes,12,opt,name=max_version,json=maxVersion,proto3" json:"max_version,omitempty"`
+	// Names of enabled cipher suites, such as
+	// "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256". Overrides the default list and
+	// allow_insecure_ciphers. TLS 1.3 cipher suites are not configurable.
+	CipherSuites []string `protobuf:"bytes,13,rep,name=cipher_suites,json=cipherSuites,proto3" json:"cipher_suites,omitempty"`
+	// Names of elliptic curves in preference order, among "X25519", "P-256",
+	// "P-384" and "P-521".
+	CurvePreferences []string `protobuf:"bytes,14,rep,name=curve_preferences,json=curvePreferences,proto3" json:"curve_preferences,omitempty"`
+	// If true, next_protocol is used as the exact list of ALPN values, even if
+	// empty. Otherwise transports may set their own defaults, and "http/1.1" is
+	// used if nothing is set.
+	ExplicitAlpn bool `protobuf:"varint,15,opt,name=explicit_alpn,json=explicitAlpn,proto3" json:"explicit_alpn,omitempty"`
+	// Name of the browser whose ClientHello is mimicked on client side, one of
+	// "chrome", "firefox", "safari", "ios" and "edge". Go's default ClientHello
+	// is used if empty.
+	Fingerprint string `protobuf:"bytes,16,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// On server side, whether clients are required to present a certificate
+	// signed by a CA of AUTHORITY_VERIFY usage. The common name of the
+	// certificate is used as the email of the user in routing and stats. The
+	// user is available on TCP, mKCP, WebSocket, HTTP/2, gRPC and domain
+	// socket transports, but not on QUIC.
+	RequireClientCertificate bool     `protobuf:"varint,17,opt,name=require_client_certificate,json=requireClientCertificate,proto3" json:"require_client_certificate,omitempty"`
 	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
 	XXX_unrecognized         []byte   `json:"-"`
 	XXX_sizecache            int32    `json:"-"`
@@ -124,7 +253,7 @@ func (m *Config) Reset()         { *m = Config{} }
 func (m *Config) String() string { return proto.CompactTextString(m) }
 func (*Config) ProtoMessage()    {}
 func (*Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42ed70cad60a2736, []int{1}
+	return fileDescriptor_42ed70cad60a2736, []int{2}
 }
 
 func (m *Config) XXX_Unmarshal(b []byte) error {
@@ -187,9 +316,87 @@ func (m *Config) GetDisableSessionResumption() bool {
 	return false
 }
 
+func (m *Config) GetCertificateReloadInterval() uint32 {
+	if m != nil {
+		return m.CertificateReloadInterval
+	}
+	return 0
+}
+
+func (m *Config) GetAcme() *AcmeConfig {
+	if m != nil {
+		return m.Acme
+	}
+	return nil
+}
+
+func (m *Config) GetPinnedPeerCertificateSha256() [][]byte {
+	if m != nil {
+		return m.PinnedPeerCertificateSha256
+	}
+	return nil
+}
+
+func (m *Config) GetPinnedPeerPublicKeySha256() [][]byte {
+	if m != nil {
+		return m.PinnedPeerPublicKeySha256
+	}
+	return nil
+}
+
+func (m *Config) GetMinVersion() string {
+	if m != nil {
+		return m.MinVersion
+	}
+	return ""
+}
+
+func (m *Config) GetMaxVersion() string {
+	if m != nil {
+		return m.MaxVersion
+	}
+	return ""
+}
+
+func (m *Config) GetCipherSuites() []string {
+	if m != nil {
+		return m.CipherSuites
+	}
+	return nil
+}
+
+func (m *Config) GetCurvePreferences() []string {
+	if m != nil {
+		return m.CurvePreferences
+	}
+	return nil
+}
+
+func (m *Config) GetExplicitAlpn() bool {
+	if m != nil {
+		return m.ExplicitAlpn
+	}
+	return false
+}
+
+func (m *Config) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+func (m *Config) GetRequireClientCertificate() bool {
+	if m != nil {
+		return m.RequireClientCertificate
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterEnum("v2ray.core.transport.internet.tls.Certificate_Usage", Certificate_Usage_name, Certificate_Usage_value)
 	proto.RegisterType((*Certificate)(nil), "v2ray.core.transport.internet.tls.Certificate")
+	proto.RegisterType((*AcmeConfig)(nil), "v2ray.core.transport.internet.tls.AcmeConfig")
 	proto.RegisterType((*Config)(nil), "v2ray.core.transport.internet.tls.Config")
 }
 
@@ -198,31 +405,53 @@ func init() {
 }
 
 var fileDescriptor_42ed70cad60a2736 = []byte{
-	// 413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xd1, 0x6e, 0xd3, 0x30,
-	0x14, 0x86, 0x49, 0x42, 0x2b, 0x76, 0xda, 0x8d, 0xc8, 0x4c, 0x28, 0xe2, 0x86, 0xac, 0x68, 0x52,
-	0xaf, 0x1c, 0x29, 0xec, 0x92, 0x1b, 0x08, 0x41, 0x0b, 0x88, 0x52, 0xb9, 0xe9, 0xa4, 0x71, 0x13,
-	0x79, 0xe6, 0x6c, 0x58, 0x4a, 0xec, 0xca, 0x76, 0x07, 0x7d, 0x25, 0x5e, 0x81, 0xc7, 0xe0, 0x85,
-	0x50, 0x93, 0xb6, 0xb4, 0x57, 0x13, 0x77, 0x3e, 0xff, 0xf9, 0xce, 0xb1, 0xff, 0xdf, 0x90, 0xde,
-	0xa7, 0x86, 0xaf, 0xa8, 0xd0, 0x4d, 0x22, 0xb4, 0xc1, 0xc4, 0x19, 0xae, 0xec, 0x42, 0x1b, 0x97,
-	0x48, 0xe5, 0xd0, 0x28, 0x74, 0x89, 0xab, 0x6d, 0x22, 0xb4, 0xba, 0x95, 0x77, 0x74, 0x61, 0xb4,
-	0xd3, 0xe4, 0x6c, 0x3b, 0x63, 0x90, 0xee, 0x78, 0xba, 0xe5, 0xa9, 0xab, 0xed, 0xe8, 0x8f, 0x07,
-	0x83, 0x0c, 0x8d, 0x93, 0xb7, 0x52, 0x70, 0x87, 0x24, 0x3e, 0x28, 0x23, 0x2f, 0xf6, 0xc6, 0x43,
-	0x76, 0x40, 0x84, 0x10, 0x7c, 0xc2, 0x55, 0xe4, 0xb7, 0x9d, 0xf5, 0x91, 0x7c, 0x84, 0xde, 0xd2,
-	0xf2, 0x3b, 0x8c, 0x82, 0xd8, 0x1b, 0x9f, 0xa4, 0x17, 0xf4, 0xc1, 0x6b, 0xe9, 0xde, 0x42, 0x3a,
-	0x5f, 0xcf, 0xb2, 0x6e, 0xc5, 0xe8, 0x3d, 0xf4, 0xda, 0x9a, 0x84, 0x30, 0xcc, 0x27, 0x59, 0x31,
-	0xbd, 0xcc, 0xd9, 0xe7, 0x7c, 0x52, 0x86, 0x8f, 0xc8, 0x29, 0x84, 0x6f, 0xe7, 0xe5, 0xe5, 0x17,
-	0x56, 0x94, 0xd7, 0xd5, 0x55, 0xce, 0x8a, 0x0f, 0xd7, 0xa1, 0x47, 0x9e, 0xc1, 0xd3, 0x7f, 0x6a,
-	0x31, 0x9b, 0xcd, 0xf3, 0xd0, 0x1f, 0xfd, 0xf6, 0xa1, 0x9f, 0xb5, 0x49, 0x90, 0x73, 0x38, 0xe1,
-	0x75, 0xad, 0x7f, 0x54, 0x52, 0x59, 0x14, 0x4b, 0xd3, 0x79, 0x7a, 0xc2, 0x8e, 0x5b, 0xb5, 0xd8,
-	0x88, 0xe4, 0x02, 0x9e, 0x1f, 0x62, 0x95, 0x90, 0x8b, 0xef, 0x68, 0x6c, 0xd4, 0x6b, 0xf1, 0xd3,
-	0x03, 0x3c, 0xeb, 0x7a, 0x64, 0x0a, 0x03, 0xb1, 0x97, 0x96, 0x1f, 0x07, 0xe3, 0x41, 0x4a, 0xff,
-	0xcf, 0x3f, 0xdb, 0x5f, 0x41, 0x5e, 0xc2, 0xc0, 0xa2, 0xb9, 0x47, 0x53, 0x29, 0xde, 0x74, 0x89,
-	0x1e, 0x31, 0xe8, 0xa4, 0x09, 0x6f, 0x90, 0xbc, 0x82, 0x63, 0x85, 0x3f, 0x5d, 0xd5, 0xfe, 0xb0,
-	0xd0, 0x75, 0xf4, 0x38, 0x0e, 0xc6, 0x47, 0x6c, 0xb8, 0x16, 0xa7, 0x1b, 0x8d, 0xbc, 0x81, 0x17,
-	0xdf, 0xa4, 0xe5, 0x37, 0x35, 0x56, 0x16, 0xad, 0x95, 0x5a, 0x55, 0x06, 0xed, 0xb2, 0x59, 0x38,
-	0xa9, 0x55, 0xd4, 0x6f, 0x1d, 0x45, 0x1b, 0x62, 0xd6, 0x01, 0x6c, 0xd7, 0x7f, 0xc7, 0xe0, 0x5c,
-	0xe8, 0xe6, 0x61, 0x17, 0x53, 0xef, 0x6b, 0xe0, 0x6a, 0xfb, 0xcb, 0x3f, 0xbb, 0x4a, 0x19, 0x5f,
-	0xd1, 0x6c, 0x8d, 0x96, 0x3b, 0xb4, 0xd8, 0xa2, 0x65, 0x6d, 0x6f, 0xfa, 0xed, 0x7b, 0x5f, 0xff,
-	0x0d, 0x00, 0x00, 0xff, 0xff, 0x80, 0x63, 0x12, 0xa7, 0xc7, 0x02, 0x00, 0x00,
+	// 759 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xd1, 0x6e, 0x23, 0x35,
+	0x14, 0x86, 0x49, 0xd2, 0x66, 0x13, 0x27, 0x69, 0x67, 0x4d, 0xb5, 0x72, 0xa9, 0x60, 0x67, 0xbb,
+	0x5a, 0x69, 0x10, 0x62, 0x22, 0x85, 0x85, 0x2b, 0x84, 0x28, 0xd9, 0xac, 0x36, 0xac, 0x28, 0x91,
+	0x93, 0x56, 0x5a, 0x6e, 0x2c, 0xd7, 0x39, 0x69, 0xad, 0xf5, 0x78, 0x06, 0xdb, 0x13, 0x1a, 0x89,
+	0x17, 0xe1, 0x15, 0x78, 0x28, 0x9e, 0x05, 0x8d, 0x67, 0x92, 0x4c, 0xaf, 0x0a, 0x77, 0xe3, 0xff,
+	0x7c, 0xfe, 0x6d, 0xff, 0xe7, 0x68, 0xd0, 0x68, 0x3d, 0x32, 0x7c, 0x13, 0x8b, 0x34, 0x19, 0x8a,
+	0xd4, 0xc0, 0xd0, 0x19, 0xae, 0x6d, 0x96, 0x1a, 0x37, 0x94, 0xda, 0x81, 0xd1, 0xe0, 0x86, 0x4e,
+	0xd9, 0xa1, 0x48, 0xf5, 0x4a, 0xde, 0xc6, 0x99, 0x49, 0x5d, 0x8a, 0x5f, 0x6c, 0xf7, 0x18, 0x88,
+	0x77, 0x7c, 0xbc, 0xe5, 0x63, 0xa7, 0xec, 0xf9, 0x5f, 0x4d, 0xd4, 0x1b, 0x83, 0x71, 0x72, 0x25,
+	0x05, 0x77, 0x80, 0xc3, 0x07, 0x4b, 0xd2, 0x08, 0x1b, 0x51, 0x9f, 0x3e, 0x20, 0x02, 0xd4, 0x7a,
+	0x0f, 0x1b, 0xd2, 0xf4, 0x95, 0xe2, 0x13, 0xff, 0x8c, 0x0e, 0x73, 0xcb, 0x6f, 0x81, 0xb4, 0xc2,
+	0x46, 0x74, 0x34, 0x7a, 0x1d, 0x3f, 0x7a, 0x6c, 0x5c, 0x33, 0x8c, 0xaf, 0x8a, 0xbd, 0xb4, 0xb4,
+	0xc0, 0x5f, 0xa2, 0x40, 0xec, 0x6b, 0x6c, 0x25, 0x15, 0x90, 0x83, 0xb0, 0x11, 0x75, 0xe9, 0x71,
+	0x4d, 0x7f, 0x2b, 0x15, 0xe0, 0x53, 0xd4, 0xf9, 0x08, 0x9b, 0x12, 0x39, 0xf4, 0xc8, 0x93, 0x8f,
+	0xb0, 0x29, 0x4a, 0xe7, 0x6f, 0xd0, 0xa1, 0x77, 0xc5, 0x01, 0xea, 0x4f, 0x2e, 0xc7, 0xd3, 0xd9,
+	0xbb, 0x09, 0xfd, 0x65, 0x72, 0xb9, 0x08, 0x3e, 0xc1, 0x27, 0x28, 0xb8, 0xb8, 0x5a, 0xbc, 0xfb,
+	0x95, 0x4e, 0x17, 0x1f, 0xd8, 0xf5, 0x84, 0x4e, 0xdf, 0x7e, 0x08, 0x1a, 0xf8, 0x53, 0x74, 0xbc,
+	0x57, 0xa7, 0xf3, 0xf9, 0xd5, 0x24, 0x68, 0x9e, 0xff, 0x89, 0xd0, 0x85, 0x48, 0x60, 0xec, 0x23,
+	0xc5, 0x2f, 0xd1, 0x60, 0x29, 0x0d, 0x08, 0x97, 0x9a, 0x0d, 0xcb, 0x8d, 0xf2, 0xd9, 0x74, 0x69,
+	0x7f, 0x27, 0x5e, 0x19, 0x85, 0x4f, 0xd0, 0x21, 0x24, 0x5c, 0x2a, 0x1f, 0x4f, 0x97, 0x96, 0x0b,
+	0x7c, 0x86, 0xba, 0x82, 0x8b, 0x3b, 0x60, 0x4b, 0x69, 0x7c, 0x48, 0x5d, 0xda, 0xf1, 0xc2, 0x1b,
+	0x69, 0xf0, 0x33, 0xd4, 0x5e, 0xa6, 0x09, 0x97, 0x9a, 0x1c, 0x84, 0xad, 0xa8, 0x4b, 0xab, 0xd5,
+	0xf9, 0x3f, 0x6d, 0xd4, 0xae, 0x8e, 0x7e, 0x85, 0x8e, 0xb8, 0x52, 0xe9, 0x1f, 0x4c, 0x6a, 0x0b,
+	0x22, 0x37, 0x65, 0x5f, 0x3a, 0x74, 0xe0, 0xd5, 0x69, 0x25, 0xe2, 0xd7, 0xe8, 0xd9, 0x43, 0x8c,
+	0x09, 0x99, 0xdd, 0x81, 0xb1, 0x3e, 0x9e, 0x0e, 0x3d, 0x79, 0x80, 0x8f, 0xcb, 0x1a, 0x9e, 0xa1,
+	0x5e, 0x2d, 0x59, 0xd2, 0x0c, 0x5b, 0x51, 0x6f, 0x14, 0xff, 0xbf, 0x1e, 0xd2, 0xba, 0x05, 0x7e,
+	0x8e, 0x7a, 0x16, 0xcc, 0x1a, 0x0c, 0xd3, 0x3c, 0x81, 0xea, 0xc1, 0xa8, 0x94, 0x2e, 0x79, 0x02,
+	0x45, 0x94, 0x1a, 0xee, 0x1d, 0xf3, 0x53, 0x2a, 0x52, 0x55, 0xbd, 0xbc, 0x5f, 0x88, 0xb3, 0x4a,
+	0xc3, 0xdf, 0xa3, 0xcf, 0x96, 0xd2, 0xf2, 0x1b, 0x05, 0xcc, 0x82, 0xb5, 0x32, 0xd5, 0xcc, 0x80,
+	0xcd, 0x93, 0xcc, 0xc9, 0x54, 0x93, 0xb6, 0x7f, 0x11, 0xa9, 0x88, 0x79, 0x09, 0xd0, 0x5d, 0x1d,
+	0xff, 0x80, 0xce, 0xea, 0x73, 0x64, 0x40, 0xa5, 0x7c, 0xc9, 0xfc, 0xfd, 0xd7, 0x5c, 0x91, 0x27,
+	0x61, 0x23, 0x1a, 0xd0, 0xd3, 0x1a, 0x42, 0x3d, 0x31, 0xad, 0x00, 0x7c, 0x81, 0x0e, 0xb8, 0x48,
+	0x80, 0x74, 0xc2, 0x46, 0xd4, 0x1b, 0x7d, 0xfd, 0x1f, 0xe2, 0xd8, 0x8f, 0x0a, 0xf5, 0x5b, 0xf1,
+	0x18, 0x7d, 0x91, 0x49, 0xad, 0x61, 0xc9, 0x32, 0x00, 0xc3, 0xea, 0xd7, 0xb1, 0x77, 0x7c, 0xf4,
+	0xed, 0x77, 0xa4, 0x1b, 0xb6, 0xa2, 0x3e, 0x3d, 0x2b, 0xa9, 0x19, 0x80, 0xa9, 0x45, 0x3a, 0xf7,
+	0x08, 0xfe, 0x11, 0x7d, 0x5e, 0x37, 0xc9, 0xf2, 0x1b, 0x25, 0x05, 0x2b, 0xe6, 0xbe, 0xf2, 0x40,
+	0xde, 0xe3, 0x74, 0xef, 0x31, 0xf3, 0xc8, 0x7b, 0xd8, 0x54, 0x0e, 0xcf, 0x51, 0x2f, 0x91, 0x9a,
+	0xad, 0xc1, 0x14, 0x11, 0x91, 0x5e, 0xd9, 0x8d, 0x44, 0xea, 0xeb, 0x52, 0xf1, 0x00, 0xbf, 0xdf,
+	0x01, 0xfd, 0x0a, 0xe0, 0xf7, 0x5b, 0xe0, 0x25, 0x1a, 0x94, 0x83, 0xc4, 0x6c, 0x2e, 0x1d, 0x58,
+	0x32, 0x28, 0xdb, 0x55, 0x8a, 0x73, 0xaf, 0xe1, 0xaf, 0xd0, 0x53, 0x91, 0x9b, 0x35, 0xb0, 0xcc,
+	0xc0, 0x0a, 0x0c, 0x68, 0x01, 0x96, 0x1c, 0x79, 0x30, 0xf0, 0x85, 0xd9, 0x5e, 0x2f, 0x1c, 0xe1,
+	0x3e, 0x53, 0x52, 0x48, 0xc7, 0xb8, 0xca, 0x34, 0x39, 0xf6, 0xed, 0xec, 0x6f, 0xc5, 0x0b, 0x95,
+	0xe9, 0xe2, 0x57, 0xb4, 0x92, 0xfa, 0x16, 0x4c, 0x66, 0xa4, 0x76, 0x24, 0xf0, 0xf7, 0xaa, 0x4b,
+	0xc5, 0x88, 0x18, 0xf8, 0x3d, 0x97, 0xc5, 0xa4, 0x2b, 0x09, 0xda, 0xd5, 0x43, 0x26, 0x4f, 0xcb,
+	0x11, 0xa9, 0x88, 0xb1, 0x07, 0x6a, 0x01, 0xff, 0x44, 0xd1, 0x2b, 0x91, 0x26, 0x8f, 0x77, 0x76,
+	0xd6, 0xf8, 0xad, 0xe5, 0x94, 0xfd, 0xbb, 0xf9, 0xe2, 0x7a, 0x44, 0xf9, 0x26, 0x1e, 0x17, 0xe8,
+	0x62, 0x87, 0x4e, 0xb7, 0xe8, 0x42, 0xd9, 0x9b, 0xb6, 0x1f, 0xe9, 0x6f, 0xfe, 0x1d, 0x00, 0x3d,
+	0xe8, 0x36, 0x00, 0xae, 0x05, 0x00, 0x00,
 }
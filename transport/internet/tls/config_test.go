@@ -1,11 +1,18 @@
 package tls_test
 
 import (
+	"crypto/sha256"
 	gotls "crypto/tls"
 	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/common/protocol/tls/cert"
 	. "v2ray.com/core/transport/internet/tls"
 	. "v2ray.com/ext/assert"
@@ -74,6 +81,258 @@ func TestInsecureCertificates(t *testing.T) {
 	}
 }
 
+func TestCertificateReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "v2ray-tls")
+	common.Must(err)
+	defer os.RemoveAll(dir)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	modTime := time.Now()
+
+	writeCert := func(certPEM, keyPEM []byte) {
+		common.Must(ioutil.WriteFile(certFile, certPEM, 0600))
+		common.Must(ioutil.WriteFile(keyFile, keyPEM, 0600))
+		modTime = modTime.Add(time.Minute)
+		common.Must(os.Chtimes(certFile, modTime, modTime))
+		common.Must(os.Chtimes(keyFile, modTime, modTime))
+	}
+
+	getCommonName := func(tlsConfig *gotls.Config) string {
+		c, err := tlsConfig.GetCertificate(&gotls.ClientHelloInfo{
+			ServerName: "www.v2ray.com",
+		})
+		common.Must(err)
+		leaf, err := x509.ParseCertificate(c.Certificate[0])
+		common.Must(err)
+		return leaf.Subject.CommonName
+	}
+
+	writeCert(cert.MustGenerate(nil, cert.CommonName("www.v2ray.com"), cert.DNSNames("www.v2ray.com")).ToPEM())
+
+	c := &Config{
+		Certificate: []*Certificate{{
+			CertificateFile: certFile,
+			KeyFile:         keyFile,
+		}},
+		CertificateReloadInterval: 1,
+	}
+	tlsConfig := c.GetTLSConfig()
+	if name := getCommonName(tlsConfig); name != "www.v2ray.com" {
+		t.Fatal("unexpected certificate: ", name)
+	}
+
+	writeCert(cert.MustGenerate(nil, cert.CommonName("v2ray.com"), cert.DNSNames("www.v2ray.com")).ToPEM())
+	time.Sleep(time.Second * 2)
+	if name := getCommonName(tlsConfig); name != "v2ray.com" {
+		t.Fatal("certificate is not reloaded: ", name)
+	}
+
+	writeCert([]byte("invalid"), []byte("invalid"))
+	time.Sleep(time.Second * 2)
+	if name := getCommonName(tlsConfig); name != "v2ray.com" {
+		t.Fatal("previous certificate is not kept: ", name)
+	}
+}
+
+type handshaker interface {
+	Handshake() error
+}
+
+func handshake(clientConfig *Config, serverConfig *Config) (net.Conn, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	clientRaw, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	serverRaw, err := listener.Accept()
+	common.Must(err)
+
+	client := Client(clientRaw, clientConfig.GetTLSConfig())
+	server := Server(serverRaw, serverConfig.GetTLSConfig())
+	defer client.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.(handshaker).Handshake()
+	}()
+	if err := client.(handshaker).Handshake(); err != nil {
+		server.Close()
+		<-errCh
+		return nil, err
+	}
+	if err := <-errCh; err != nil {
+		server.Close()
+		return nil, err
+	}
+	return server, nil
+}
+
+func TestPinnedPeerCertificate(t *testing.T) {
+	serverCert := cert.MustGenerate(nil, cert.CommonName("www.v2ray.com"), cert.DNSNames("www.v2ray.com"))
+	serverConfig := &Config{
+		Certificate: []*Certificate{ParseCertificate(serverCert)},
+	}
+
+	certHash := sha256.Sum256(serverCert.Certificate)
+	leaf, err := x509.ParseCertificate(serverCert.Certificate)
+	common.Must(err)
+	keyHash := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+
+	if _, err := handshake(&Config{ServerName: "www.v2ray.com", PinnedPeerCertificateSha256: [][]byte{certHash[:]}}, serverConfig); err != nil {
+		t.Error("pinned certificate is rejected: ", err)
+	}
+	if _, err := handshake(&Config{ServerName: "www.v2ray.com", PinnedPeerPublicKeySha256: [][]byte{keyHash[:]}}, serverConfig); err != nil {
+		t.Error("pinned public key is rejected: ", err)
+	}
+	if _, err := handshake(&Config{ServerName: "www.v2ray.com", PinnedPeerCertificateSha256: [][]byte{keyHash[:]}}, serverConfig); err == nil {
+		t.Error("certificate not matching pin is accepted")
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	caCert := cert.MustGenerate(nil, cert.Authority(true), cert.KeyUsage(x509.KeyUsageCertSign), cert.ExtKeyUsage())
+	clientCert := cert.MustGenerate(caCert, cert.CommonName("love@v2ray.com"), cert.ExtKeyUsage(x509.ExtKeyUsageClientAuth))
+	serverCert := cert.MustGenerate(nil, cert.CommonName("www.v2ray.com"), cert.DNSNames("www.v2ray.com"))
+
+	ca := ParseCertificate(caCert)
+	ca.Usage = Certificate_AUTHORITY_VERIFY
+	serverConfig := &Config{
+		Certificate: []*Certificate{ParseCertificate(serverCert), ca},
+	}
+
+	// Client certificates are optional unless required explicitly.
+	if _, err := handshake(&Config{AllowInsecure: true}, serverConfig); err != nil {
+		t.Error("client without certificate is rejected: ", err)
+	}
+
+	serverConfig.RequireClientCertificate = true
+	if _, err := handshake(&Config{AllowInsecure: true}, serverConfig); err == nil {
+		t.Error("client without certificate is accepted")
+	}
+
+	otherCert := cert.MustGenerate(nil, cert.CommonName("love@v2ray.com"), cert.ExtKeyUsage(x509.ExtKeyUsageClientAuth))
+	if _, err := handshake(&Config{AllowInsecure: true, Certificate: []*Certificate{ParseCertificate(otherCert)}}, serverConfig); err == nil {
+		t.Error("client certificate from unknown CA is accepted")
+	}
+
+	conn, err := handshake(&Config{AllowInsecure: true, Certificate: []*Certificate{ParseCertificate(clientCert)}}, serverConfig)
+	if err != nil {
+		t.Fatal("client certificate is rejected: ", err)
+	}
+	defer conn.Close()
+
+	type hasVerifiedUser interface {
+		VerifiedUser(time.Duration) *protocol.MemoryUser
+	}
+	user := conn.(hasVerifiedUser).VerifiedUser(time.Second * 4)
+	if user == nil || user.Email != "love@v2ray.com" {
+		t.Error("unexpected user: ", user)
+	}
+
+	// Connections carried by TLS, such as WebSocket connections, take the user from the TLS state.
+	state := conn.(interface {
+		ConnectionState() gotls.ConnectionState
+	}).ConnectionState()
+	inner, _ := net.Pipe()
+	user = WithVerifiedUser(inner, &state).(hasVerifiedUser).VerifiedUser(0)
+	if user == nil || user.Email != "love@v2ray.com" {
+		t.Error("unexpected user of carried connection: ", user)
+	}
+}
+
+func TestClientCertificateWithoutAuthority(t *testing.T) {
+	clientCert := cert.MustGenerate(nil, cert.CommonName("love@v2ray.com"), cert.ExtKeyUsage(x509.ExtKeyUsageClientAuth))
+	serverCert := cert.MustGenerate(nil, cert.CommonName("www.v2ray.com"), cert.DNSNames("www.v2ray.com"))
+	serverConfig := &Config{
+		Certificate:              []*Certificate{ParseCertificate(serverCert)},
+		RequireClientCertificate: true,
+	}
+
+	if err := serverConfig.Validate(); err == nil {
+		t.Error("require_client_certificate without CA is accepted")
+	}
+	// Even if not validated, no client is accepted.
+	if _, err := handshake(&Config{AllowInsecure: true, Certificate: []*Certificate{ParseCertificate(clientCert)}}, serverConfig); err == nil {
+		t.Error("client certificate is accepted without CA")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		Config *Config
+		Valid  bool
+	}{
+		{
+			Config: &Config{},
+			Valid:  true,
+		},
+		{
+			Config: &Config{
+				MinVersion:       "1.2",
+				MaxVersion:       "1.3",
+				CipherSuites:     []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+				CurvePreferences: []string{"X25519", "p-256"},
+				NextProtocol:     []string{"h2"},
+			},
+			Valid: true,
+		},
+		{
+			Config: &Config{MinVersion: "1.4"},
+		},
+		{
+			Config: &Config{MinVersion: "1.3", MaxVersion: "1.2"},
+		},
+		{
+			Config: &Config{CipherSuites: []string{"TLS_UNKNOWN"}},
+		},
+		{
+			Config: &Config{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}},
+		},
+		{
+			Config: &Config{CurvePreferences: []string{"P-224"}},
+		},
+		{
+			Config: &Config{NextProtocol: []string{""}},
+		},
+	}
+
+	for _, c := range cases {
+		err := c.Config.Validate()
+		if c.Valid && err != nil {
+			t.Error("unexpected error: ", err)
+		}
+		if !c.Valid && err == nil {
+			t.Error("expected error for ", c.Config)
+		}
+	}
+}
+
+func TestTLSParams(t *testing.T) {
+	c := &Config{
+		MinVersion:       "1.2",
+		MaxVersion:       "1.2",
+		CipherSuites:     []string{"TLS_RSA_WITH_AES_128_GCM_SHA256"},
+		CurvePreferences: []string{"P-384"},
+		ExplicitAlpn:     true,
+	}
+
+	tlsConfig := c.GetTLSConfig(WithNextProto("h2"))
+	if tlsConfig.MinVersion != gotls.VersionTLS12 || tlsConfig.MaxVersion != gotls.VersionTLS12 {
+		t.Error("unexpected versions: ", tlsConfig.MinVersion, " ", tlsConfig.MaxVersion)
+	}
+	if len(tlsConfig.CipherSuites) != 1 || tlsConfig.CipherSuites[0] != gotls.TLS_RSA_WITH_AES_128_GCM_SHA256 {
+		t.Error("unexpected cipher suites: ", tlsConfig.CipherSuites)
+	}
+	if len(tlsConfig.CurvePreferences) != 1 || tlsConfig.CurvePreferences[0] != gotls.CurveP384 {
+		t.Error("unexpected curves: ", tlsConfig.CurvePreferences)
+	}
+	if len(tlsConfig.NextProtos) != 0 {
+		t.Error("unexpected ALPN: ", tlsConfig.NextProtos)
+	}
+}
+
 func BenchmarkCertificateIssuing(b *testing.B) {
 	certificate := ParseCertificate(cert.MustGenerate(nil, cert.Authority(true), cert.KeyUsage(x509.KeyUsageCertSign)))
 	certificate.Usage = Certificate_AUTHORITY_ISSUE
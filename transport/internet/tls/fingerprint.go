@@ -0,0 +1,98 @@
+package tls
+
+import (
+	"crypto/tls"
+
+	utls "github.com/refraction-networking/utls"
+
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+)
+
+var (
+	fingerprints = map[string]*utls.ClientHelloID{
+		"chrome":  &utls.HelloChrome_Auto,
+		"firefox": &utls.HelloFirefox_Auto,
+		"safari":  &utls.HelloSafari_Auto,
+		"ios":     &utls.HelloIOS_Auto,
+		"edge":    &utls.HelloEdge_Auto,
+	}
+
+	globalUSessionCache = utls.NewLRUClientSessionCache(128)
+)
+
+// GetFingerprint returns the ClientHello of the browser with the given name, or nil if name is empty or unknown.
+func GetFingerprint(name string) *utls.ClientHelloID {
+	if len(name) == 0 {
+		return nil
+	}
+	return fingerprints[name]
+}
+
+type uConn struct {
+	*utls.UConn
+
+	mergingWriter *buf.BufferedWriter
+}
+
+func (c *uConn) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if c.mergingWriter == nil {
+		c.mergingWriter = buf.NewBufferedWriter(buf.NewWriter(c.UConn))
+	}
+	if err := c.mergingWriter.WriteMultiBuffer(mb); err != nil {
+		return err
+	}
+	return c.mergingWriter.Flush()
+}
+
+func copyConfig(config *tls.Config) *utls.Config {
+	uConfig := &utls.Config{
+		ClientSessionCache:    globalUSessionCache,
+		RootCAs:               config.RootCAs,
+		ServerName:            config.ServerName,
+		InsecureSkipVerify:    config.InsecureSkipVerify,
+		VerifyPeerCertificate: config.VerifyPeerCertificate,
+		NextProtos:            config.NextProtos,
+		MinVersion:            config.MinVersion,
+		MaxVersion:            config.MaxVersion,
+	}
+	for _, c := range config.Certificates {
+		uConfig.Certificates = append(uConfig.Certificates, utls.Certificate{
+			Certificate: c.Certificate,
+			PrivateKey:  c.PrivateKey,
+			Leaf:        c.Leaf,
+		})
+	}
+	return uConfig
+}
+
+// UClient initiates a TLS client handshake on the given connection, with a ClientHello that mimics the given browser
+// fingerprint in cipher suites, extensions, GREASE and padding. ALPN values in config replace the ones of the browser.
+func UClient(c net.Conn, config *tls.Config, fingerprint *utls.ClientHelloID) net.Conn {
+	uConfig := copyConfig(config)
+
+	spec, err := utls.UTLSIdToSpec(*fingerprint)
+	if err != nil {
+		newError("failed to get ClientHello spec of ", fingerprint.Str()).Base(err).AtWarning().WriteToLog()
+		return &uConn{UConn: utls.UClient(c, uConfig, *fingerprint)}
+	}
+
+	extensions := make([]utls.TLSExtension, 0, len(spec.Extensions))
+	for _, ext := range spec.Extensions {
+		if alpn, ok := ext.(*utls.ALPNExtension); ok {
+			if len(config.NextProtos) == 0 {
+				continue
+			}
+			alpn.AlpnProtocols = config.NextProtos
+		}
+		extensions = append(extensions, ext)
+	}
+	spec.Extensions = extensions
+
+	tlsConn := utls.UClient(c, uConfig, utls.HelloCustom)
+	if err := tlsConn.ApplyPreset(&spec); err != nil {
+		newError("failed to apply ClientHello spec of ", fingerprint.Str()).Base(err).AtWarning().WriteToLog()
+		return &uConn{UConn: utls.UClient(c, uConfig, *fingerprint)}
+	}
+	return &uConn{UConn: tlsConn}
+}
@@ -0,0 +1,76 @@
+package tls_test
+
+import (
+	gotls "crypto/tls"
+	"net"
+	"testing"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/protocol/tls/cert"
+	. "v2ray.com/core/transport/internet/tls"
+)
+
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+func TestChromeFingerprint(t *testing.T) {
+	serverCert := cert.MustGenerate(nil, cert.CommonName("www.v2ray.com"), cert.DNSNames("www.v2ray.com"))
+	serverConfig := (&Config{
+		Certificate:  []*Certificate{ParseCertificate(serverCert)},
+		NextProtocol: []string{"h2"},
+	}).GetTLSConfig()
+
+	helloCh := make(chan *gotls.ClientHelloInfo, 1)
+	serverConfig.GetConfigForClient = func(hello *gotls.ClientHelloInfo) (*gotls.Config, error) {
+		helloCh <- hello
+		return nil, nil
+	}
+
+	listener, err := gotls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	common.Must(err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var b [1]byte
+		_, _ = conn.Read(b[:])
+	}()
+
+	rawConn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+
+	clientConfig := (&Config{
+		ServerName:    "www.v2ray.com",
+		AllowInsecure: true,
+		Fingerprint:   "chrome",
+	}).GetTLSConfig(WithNextProto("h2"))
+	conn := UClient(rawConn, clientConfig, GetFingerprint("chrome"))
+	defer conn.Close()
+
+	common.Must2(conn.Write([]byte{1}))
+
+	hello := <-helloCh
+	if len(hello.CipherSuites) == 0 || !isGREASE(hello.CipherSuites[0]) {
+		t.Error("no GREASE cipher suite: ", hello.CipherSuites)
+	}
+	if len(hello.SupportedProtos) != 1 || hello.SupportedProtos[0] != "h2" {
+		t.Error("unexpected ALPN: ", hello.SupportedProtos)
+	}
+	if hello.ServerName != "www.v2ray.com" {
+		t.Error("unexpected server name: ", hello.ServerName)
+	}
+}
+
+func TestUnknownFingerprint(t *testing.T) {
+	if GetFingerprint("netscape") != nil {
+		t.Error("unknown fingerprint is returned")
+	}
+	if err := (&Config{Fingerprint: "netscape"}).Validate(); err == nil {
+		t.Error("unknown fingerprint passes validation")
+	}
+}
@@ -0,0 +1,123 @@
+package tls
+
+import (
+	"crypto/tls"
+	"strings"
+)
+
+var (
+	tlsVersions = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+
+	tlsCurves = map[string]tls.CurveID{
+		"X25519": tls.X25519,
+		"P-256":  tls.CurveP256,
+		"P-384":  tls.CurveP384,
+		"P-521":  tls.CurveP521,
+	}
+)
+
+func parseVersion(v string) (uint16, error) {
+	if len(v) == 0 {
+		return 0, nil
+	}
+	version, found := tlsVersions[v]
+	if !found {
+		return 0, newError("unknown TLS version: ", v)
+	}
+	return version, nil
+}
+
+func parseCipherSuites(names []string) ([]uint16, error) {
+	suites := make(map[string]*tls.CipherSuite)
+	for _, s := range tls.CipherSuites() {
+		suites[s.Name] = s
+	}
+	for _, s := range tls.InsecureCipherSuites() {
+		suites[s.Name] = s
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		suite, found := suites[strings.ToUpper(name)]
+		if !found {
+			return nil, newError("unknown cipher suite: ", name)
+		}
+		if len(suite.SupportedVersions) == 1 && suite.SupportedVersions[0] == tls.VersionTLS13 {
+			return nil, newError("TLS 1.3 cipher suite is not configurable: ", name)
+		}
+		ids = append(ids, suite.ID)
+	}
+	return ids, nil
+}
+
+func parseCurves(names []string) ([]tls.CurveID, error) {
+	curves := make([]tls.CurveID, 0, len(names))
+	for _, name := range names {
+		curve, found := tlsCurves[strings.ToUpper(name)]
+		if !found {
+			return nil, newError("unknown curve: ", name)
+		}
+		curves = append(curves, curve)
+	}
+	return curves, nil
+}
+
+// Validate checks whether the TLS parameters in this config are valid.
+func (c *Config) Validate() error {
+	minVersion, err := parseVersion(c.MinVersion)
+	if err != nil {
+		return newError("invalid min_version").Base(err)
+	}
+	maxVersion, err := parseVersion(c.MaxVersion)
+	if err != nil {
+		return newError("invalid max_version").Base(err)
+	}
+	if minVersion != 0 && maxVersion != 0 && minVersion > maxVersion {
+		return newError("min_version ", c.MinVersion, " is greater than max_version ", c.MaxVersion)
+	}
+	if _, err := parseCipherSuites(c.CipherSuites); err != nil {
+		return newError("invalid cipher_suites").Base(err)
+	}
+	if _, err := parseCurves(c.CurvePreferences); err != nil {
+		return newError("invalid curve_preferences").Base(err)
+	}
+	if len(c.Fingerprint) > 0 && GetFingerprint(c.Fingerprint) == nil {
+		return newError("unknown fingerprint: ", c.Fingerprint)
+	}
+	for _, proto := range c.NextProtocol {
+		if len(proto) == 0 || len(proto) > 255 {
+			return newError("invalid ALPN value: \"", proto, "\"")
+		}
+	}
+	if c.RequireClientCertificate {
+		if _, count := c.getClientCertPool(); count == 0 {
+			return newError("require_client_certificate is set without a valid certificate of AUTHORITY_VERIFY usage")
+		}
+	}
+	return nil
+}
+
+// applyParams sets TLS parameters into the tls.Config. Invalid values are ignored, as they are rejected by Validate.
+func (c *Config) applyParams(config *tls.Config) {
+	if v, err := parseVersion(c.MinVersion); err == nil && v != 0 {
+		config.MinVersion = v
+	}
+	if v, err := parseVersion(c.MaxVersion); err == nil && v != 0 {
+		config.MaxVersion = v
+	}
+	if len(c.CipherSuites) > 0 {
+		if suites, err := parseCipherSuites(c.CipherSuites); err == nil {
+			config.CipherSuites = suites
+		}
+	}
+	if len(c.CurvePreferences) > 0 {
+		if curves, err := parseCurves(c.CurvePreferences); err == nil {
+			config.CurvePreferences = curves
+		}
+	}
+}
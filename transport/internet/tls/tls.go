@@ -2,9 +2,11 @@ package tls
 
 import (
 	"crypto/tls"
+	"time"
 
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
 )
 
 //go:generate errorgen
@@ -17,6 +19,7 @@ type conn struct {
 	*tls.Conn
 
 	mergingWriter *buf.BufferedWriter
+	clientAuth    tls.ClientAuthType
 }
 
 func (c *conn) WriteMultiBuffer(mb buf.MultiBuffer) error {
@@ -40,6 +43,55 @@ func (c *conn) HandshakeAddress() net.Address {
 	return net.ParseAddress(state.ServerName)
 }
 
+// VerifiedUser returns the user identified by the verified client certificate, or nil if client certificates are not
+// required. It waits for the handshake to complete within the given timeout.
+func (c *conn) VerifiedUser(handshakeTimeout time.Duration) *protocol.MemoryUser {
+	if c.clientAuth != tls.RequireAndVerifyClientCert {
+		return nil
+	}
+
+	if err := c.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return nil
+	}
+	err := c.Handshake()
+	if err := c.SetDeadline(time.Time{}); err != nil {
+		return nil
+	}
+	if err != nil {
+		return nil
+	}
+
+	return userFromState(c.Conn.ConnectionState())
+}
+
+// userConn is a connection carried by TLS, such as a WebSocket connection, whose client has been identified by a
+// verified certificate.
+type userConn struct {
+	net.Conn
+	user *protocol.MemoryUser
+}
+
+// VerifiedUser returns the user identified by the client certificate. The handshake has already completed.
+func (c *userConn) VerifiedUser(time.Duration) *protocol.MemoryUser {
+	return c.user
+}
+
+// WithVerifiedUser returns a connection that carries the user identified by the verified client certificate in the
+// state of the underlying TLS connection. conn is returned as is if there is no such user.
+func WithVerifiedUser(conn net.Conn, state *tls.ConnectionState) net.Conn {
+	if state == nil {
+		return conn
+	}
+	user := userFromState(*state)
+	if user == nil {
+		return conn
+	}
+	return &userConn{
+		Conn: conn,
+		user: user,
+	}
+}
+
 // Client initiates a TLS client handshake on the given connection.
 func Client(c net.Conn, config *tls.Config) net.Conn {
 	tlsConn := tls.Client(c, config)
@@ -49,5 +101,5 @@ func Client(c net.Conn, config *tls.Config) net.Conn {
 // Server initiates a TLS server handshake on the given connection.
 func Server(c net.Conn, config *tls.Config) net.Conn {
 	tlsConn := tls.Server(c, config)
-	return &conn{Conn: tlsConn}
+	return &conn{Conn: tlsConn, clientAuth: config.ClientAuth}
 }
@@ -0,0 +1,89 @@
+package tls
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+
+	"v2ray.com/core/common/protocol"
+)
+
+func (c *Config) hasPinnedPeerCertificate() bool {
+	return len(c.PinnedPeerCertificateSha256) > 0 || len(c.PinnedPeerPublicKeySha256) > 0
+}
+
+// verifyPinnedPeerCertificate accepts the peer if its leaf certificate or public key matches any of the pins.
+func (c *Config) verifyPinnedPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return newError("no peer certificate")
+	}
+
+	certHash := sha256.Sum256(rawCerts[0])
+	for _, pin := range c.PinnedPeerCertificateSha256 {
+		if bytes.Equal(pin, certHash[:]) {
+			return nil
+		}
+	}
+
+	if len(c.PinnedPeerPublicKeySha256) > 0 {
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return newError("failed to parse peer certificate").Base(err)
+		}
+		keyHash := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+		for _, pin := range c.PinnedPeerPublicKeySha256 {
+			if bytes.Equal(pin, keyHash[:]) {
+				return nil
+			}
+		}
+	}
+
+	return newError("peer certificate does not match any pin")
+}
+
+// getClientCertPool returns the pool of CAs that verify client certificates. The pool is empty if none is configured,
+// so that no client is accepted, instead of clients verified by the system roots.
+func (c *Config) getClientCertPool() (*x509.CertPool, int) {
+	pool := x509.NewCertPool()
+	count := 0
+	for _, entry := range c.Certificate {
+		if entry.Usage != Certificate_AUTHORITY_VERIFY {
+			continue
+		}
+		if !pool.AppendCertsFromPEM(entry.Certificate) {
+			newError("ignoring invalid CA certificate").AtWarning().WriteToLog()
+			continue
+		}
+		count++
+	}
+	return pool, count
+}
+
+func applyPeerVerification(c *Config, config *tls.Config) {
+	if c.hasPinnedPeerCertificate() {
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = c.verifyPinnedPeerCertificate
+	}
+
+	if c.RequireClientCertificate {
+		config.ClientCAs, _ = c.getClientCertPool()
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+}
+
+// userFromState returns the user identified by the verified client certificate of the connection, or nil if there is
+// none. The common name of the certificate subject, or the full subject if common name is empty, is used as email.
+func userFromState(state tls.ConnectionState) *protocol.MemoryUser {
+	if len(state.VerifiedChains) == 0 || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	subject := state.PeerCertificates[0].Subject
+	email := subject.CommonName
+	if len(email) == 0 {
+		email = subject.String()
+	}
+	return &protocol.MemoryUser{
+		Email: email,
+	}
+}
@@ -0,0 +1,169 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultCertificateReloadInterval = time.Minute
+
+// certificateWatcher holds a key pair loaded from files, and reloads it when the files are modified.
+type certificateWatcher struct {
+	sync.Mutex
+	certFile  string
+	keyFile   string
+	interval  time.Duration
+	lastCheck time.Time
+	certMod   time.Time
+	keyMod    time.Time
+	keyPair   *tls.Certificate
+}
+
+var (
+	watcherAccess sync.Mutex
+	watchers      = make(map[string]*certificateWatcher)
+)
+
+// getCertificateWatcher returns the watcher of the given files, creating one if not exist.
+func getCertificateWatcher(certFile, keyFile string, interval time.Duration) *certificateWatcher {
+	watcherAccess.Lock()
+	defer watcherAccess.Unlock()
+
+	id := certFile + "\x00" + keyFile
+	if w, found := watchers[id]; found {
+		w.Lock()
+		if interval < w.interval {
+			w.interval = interval
+		}
+		w.Unlock()
+		return w
+	}
+
+	w := &certificateWatcher{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+	}
+	w.Lock()
+	w.reload()
+	w.Unlock()
+	watchers[id] = w
+	return w
+}
+
+func modTime(file string) (time.Time, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// reload loads the key pair from files if they are changed. The previous key pair is kept on failure.
+// Caller must hold the lock.
+func (w *certificateWatcher) reload() {
+	w.lastCheck = time.Now()
+
+	certMod, err := modTime(w.certFile)
+	if err != nil {
+		newError("failed to read certificate file ", w.certFile).Base(err).AtWarning().WriteToLog()
+		return
+	}
+	keyMod, err := modTime(w.keyFile)
+	if err != nil {
+		newError("failed to read key file ", w.keyFile).Base(err).AtWarning().WriteToLog()
+		return
+	}
+	if w.keyPair != nil && certMod.Equal(w.certMod) && keyMod.Equal(w.keyMod) {
+		return
+	}
+
+	certPEM, err := ioutil.ReadFile(w.certFile)
+	if err != nil {
+		newError("failed to read certificate file ", w.certFile).Base(err).AtWarning().WriteToLog()
+		return
+	}
+	keyPEM, err := ioutil.ReadFile(w.keyFile)
+	if err != nil {
+		newError("failed to read key file ", w.keyFile).Base(err).AtWarning().WriteToLog()
+		return
+	}
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		newError("failed to load X509 key pair from ", w.certFile).Base(err).AtWarning().WriteToLog()
+		return
+	}
+	if leaf, err := x509.ParseCertificate(keyPair.Certificate[0]); err == nil {
+		keyPair.Leaf = leaf
+	}
+
+	w.certMod = certMod
+	w.keyMod = keyMod
+	w.keyPair = &keyPair
+	newError("certificate loaded from ", w.certFile).AtInfo().WriteToLog()
+}
+
+// get returns the latest key pair, or nil if no valid key pair has been loaded.
+func (w *certificateWatcher) get() *tls.Certificate {
+	w.Lock()
+	defer w.Unlock()
+
+	if time.Since(w.lastCheck) >= w.interval {
+		w.reload()
+	}
+	return w.keyPair
+}
+
+func (c *Config) getCertificateReloadInterval() time.Duration {
+	if c.CertificateReloadInterval == 0 {
+		return defaultCertificateReloadInterval
+	}
+	return time.Duration(c.CertificateReloadInterval) * time.Second
+}
+
+func (c *Config) getCertificateWatchers() []*certificateWatcher {
+	var watchers []*certificateWatcher
+	for _, entry := range c.Certificate {
+		if entry.Usage != Certificate_ENCIPHERMENT || len(entry.CertificateFile) == 0 {
+			continue
+		}
+		watchers = append(watchers, getCertificateWatcher(entry.CertificateFile, entry.KeyFile, c.getCertificateReloadInterval()))
+	}
+	return watchers
+}
+
+// getCertificateFromFiles returns a GetCertificate callback that serves the latest key pairs from the watchers.
+// If none of them matches the requested server name, next is called if not nil. Otherwise the static certificates
+// in config take precedence, and the first key pair from files is used if there is no static certificate.
+func getCertificateFromFiles(config *tls.Config, watchers []*certificateWatcher, next func(*tls.ClientHelloInfo) (*tls.Certificate, error)) func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		var first *tls.Certificate
+		for _, w := range watchers {
+			keyPair := w.get()
+			if keyPair == nil {
+				continue
+			}
+			if first == nil {
+				first = keyPair
+			}
+			if keyPair.Leaf != nil && keyPair.Leaf.VerifyHostname(hello.ServerName) == nil {
+				return keyPair, nil
+			}
+		}
+
+		if next != nil {
+			return next(hello)
+		}
+		if len(config.Certificates) > 0 {
+			return nil, nil
+		}
+		if first == nil {
+			return nil, newError("no certificate available")
+		}
+		return first, nil
+	}
+}
@@ -0,0 +1,80 @@
+package internet
+
+import (
+	"net/http"
+	"strings"
+
+	"v2ray.com/core/common/net"
+)
+
+// TrustedProxies is a list of networks of reverse proxies, such as CDNs, whose information about the original client
+// address is honored.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a list of IPs or CIDRs.
+func ParseTrustedProxies(cidrs []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, newError("invalid IP of trusted proxy: ", cidr)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+				bits = 8 * net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{
+				IP:   ip,
+				Mask: net.CIDRMask(bits, bits),
+			})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, newError("invalid CIDR of trusted proxy: ", cidr).Base(err)
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
+// Contains returns true if the IP belongs to a trusted proxy.
+func (p TrustedProxies) Contains(ip net.IP) bool {
+	for _, ipNet := range p {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP of the original client of an HTTP request from the given remote IP. If the remote is a
+// trusted proxy, the client is the last untrusted address in X-Forwarded-For, or the one in X-Real-IP.
+func (p TrustedProxies) ClientIP(remote net.IP, header http.Header) net.IP {
+	if !p.Contains(remote) {
+		return remote
+	}
+
+	if xff := header.Get("X-Forwarded-For"); len(xff) > 0 {
+		list := strings.Split(xff, ",")
+		client := remote
+		for i := len(list) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(list[i]))
+			if ip == nil {
+				break
+			}
+			client = ip
+			if !p.Contains(ip) {
+				break
+			}
+		}
+		return client
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(header.Get("X-Real-IP"))); ip != nil {
+		return ip
+	}
+	return remote
+}
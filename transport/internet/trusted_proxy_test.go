@@ -0,0 +1,51 @@
+package internet_test
+
+import (
+	"net/http"
+	"testing"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	. "v2ray.com/core/transport/internet"
+)
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "2001:db8::/32"})
+	common.Must(err)
+
+	testCases := []struct {
+		remote string
+		xff    string
+		realIP string
+		client string
+	}{
+		{remote: "1.2.3.4", xff: "5.6.7.8", client: "1.2.3.4"},
+		{remote: "10.1.2.3", xff: "5.6.7.8", client: "5.6.7.8"},
+		{remote: "10.1.2.3", xff: "9.9.9.9, 5.6.7.8, 192.168.1.1", client: "5.6.7.8"},
+		{remote: "192.168.1.1", xff: "10.0.0.1", client: "10.0.0.1"},
+		{remote: "2001:db8::1", realIP: "2001::1", client: "2001::1"},
+		{remote: "10.1.2.3", xff: "invalid, 5.6.7.8", client: "5.6.7.8"},
+		{remote: "10.1.2.3", client: "10.1.2.3"},
+		{remote: "192.168.1.2", realIP: "5.6.7.8", client: "192.168.1.2"},
+	}
+	for _, tc := range testCases {
+		header := http.Header{}
+		if len(tc.xff) > 0 {
+			header.Set("X-Forwarded-For", tc.xff)
+		}
+		if len(tc.realIP) > 0 {
+			header.Set("X-Real-IP", tc.realIP)
+		}
+		if client := proxies.ClientIP(net.ParseIP(tc.remote), header); !client.Equal(net.ParseIP(tc.client)) {
+			t.Error("remote ", tc.remote, ", X-Forwarded-For \"", tc.xff, "\": expect ", tc.client, ", but got ", client)
+		}
+	}
+}
+
+func TestParseTrustedProxiesError(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/33", "example.com", ""} {
+		if _, err := ParseTrustedProxies([]string{cidr}); err == nil {
+			t.Error("expect error for \"", cidr, "\"")
+		}
+	}
+}
@@ -28,6 +28,14 @@ func (c *Config) GetRequestHeader() http.Header {
 	return header
 }
 
+// Validate checks whether the settings are valid.
+func (c *Config) Validate() error {
+	if _, err := internet.ParseTrustedProxies(c.TrustedProxy); err != nil {
+		return newError("invalid trusted_proxy").Base(err)
+	}
+	return nil
+}
+
 func init() {
 	common.Must(internet.RegisterProtocolConfigCreatorByName(protocolName, func() interface{} {
 		return new(Config)
@@ -66,11 +66,24 @@ func (m *Header) GetValue() string {
 
 type Config struct {
 	// URL path to the WebSocket service. Empty value means root(/).
-	Path                 string    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	Header               []*Header `protobuf:"bytes,3,rep,name=header,proto3" json:"header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Path   string    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Header []*Header `protobuf:"bytes,3,rep,name=header,proto3" json:"header,omitempty"`
+	// Maximum number of payload bytes sent within the upgrade request, so that
+	// they don't wait for the handshake round trip. Early data is disabled if
+	// zero. If the server doesn't take early data, such as an older version,
+	// the client sends it again in a normal frame after the handshake.
+	MaxEarlyData uint32 `protobuf:"varint,4,opt,name=max_early_data,json=maxEarlyData,proto3" json:"max_early_data,omitempty"`
+	// Name of the HTTP header that carries early data in base64. If empty,
+	// early data is carried in the "ed" query parameter of the path.
+	EarlyDataHeaderName string `protobuf:"bytes,5,opt,name=early_data_header_name,json=earlyDataHeaderName,proto3" json:"early_data_header_name,omitempty"`
+	// IPs or CIDRs of reverse proxies in front of the server, such as CDNs. For
+	// connections from them, the client address is taken from PROXY protocol
+	// header, X-Forwarded-For or X-Real-IP. If empty, the first address in
+	// X-Forwarded-For is used.
+	TrustedProxy         []string `protobuf:"bytes,6,rep,name=trusted_proxy,json=trustedProxy,proto3" json:"trusted_proxy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Config) Reset()         { *m = Config{} }
@@ -112,6 +125,27 @@ func (m *Config) GetHeader() []*Header {
 	return nil
 }
 
+func (m *Config) GetMaxEarlyData() uint32 {
+	if m != nil {
+		return m.MaxEarlyData
+	}
+	return 0
+}
+
+func (m *Config) GetEarlyDataHeaderName() string {
+	if m != nil {
+		return m.EarlyDataHeaderName
+	}
+	return ""
+}
+
+func (m *Config) GetTrustedProxy() []string {
+	if m != nil {
+		return m.TrustedProxy
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Header)(nil), "v2ray.core.transport.internet.websocket.Header")
 	proto.RegisterType((*Config)(nil), "v2ray.core.transport.internet.websocket.Config")
@@ -122,20 +156,25 @@ func init() {
 }
 
 var fileDescriptor_c4869c9c0fc9b72f = []byte{
-	// 229 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xb2, 0x28, 0x33, 0x2a, 0x4a,
-	0xac, 0xd4, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xce, 0x2f, 0x4a, 0xd5, 0x2f, 0x29, 0x4a, 0xcc, 0x2b,
-	0x2e, 0xc8, 0x2f, 0x2a, 0xd1, 0xcf, 0xcc, 0x2b, 0x49, 0x2d, 0xca, 0x4b, 0x2d, 0xd1, 0x2f, 0x4f,
-	0x4d, 0x2a, 0xce, 0x4f, 0xce, 0x4e, 0x2d, 0xd1, 0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2b,
-	0x28, 0xca, 0x2f, 0xc9, 0x17, 0x52, 0x87, 0xe9, 0x2c, 0x4a, 0xd5, 0x83, 0xeb, 0xd2, 0x83, 0xe9,
-	0xd2, 0x83, 0xeb, 0x52, 0x32, 0xe0, 0x62, 0xf3, 0x48, 0x4d, 0x4c, 0x49, 0x2d, 0x12, 0x12, 0xe0,
-	0x62, 0xce, 0x4e, 0xad, 0x94, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x02, 0x31, 0x85, 0x44, 0xb8,
-	0x58, 0xcb, 0x12, 0x73, 0x4a, 0x53, 0x25, 0x98, 0xc0, 0x62, 0x10, 0x8e, 0x52, 0x36, 0x17, 0x9b,
-	0x33, 0xd8, 0x2a, 0x21, 0x21, 0x2e, 0x96, 0x82, 0xc4, 0x92, 0x0c, 0xa8, 0x34, 0x98, 0x2d, 0xe4,
-	0xce, 0xc5, 0x96, 0x01, 0x36, 0x4f, 0x82, 0x59, 0x81, 0x59, 0x83, 0xdb, 0x48, 0x5f, 0x8f, 0x48,
-	0x97, 0xe8, 0x41, 0x9c, 0x11, 0x04, 0xd5, 0xee, 0xc5, 0xc2, 0xc1, 0x28, 0xc0, 0xe4, 0x94, 0xc2,
-	0xa5, 0x9d, 0x9c, 0x9f, 0x4b, 0xac, 0x19, 0x01, 0x8c, 0x51, 0x9c, 0x70, 0xce, 0x2a, 0x26, 0xf5,
-	0x30, 0xa3, 0xa0, 0xc4, 0x4a, 0x3d, 0x67, 0x90, 0xb6, 0x10, 0xb8, 0x36, 0x4f, 0x98, 0xb6, 0x70,
-	0x98, 0xca, 0x24, 0x36, 0x70, 0xa0, 0x19, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xf5, 0x7e, 0x60,
-	0xf9, 0x70, 0x01, 0x00, 0x00,
+	// 309 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0x5f, 0x4b, 0xc3, 0x30,
+	0x14, 0xc5, 0xe9, 0xba, 0x15, 0x17, 0x37, 0x19, 0x51, 0x24, 0x8f, 0x65, 0x0a, 0x2b, 0x08, 0x89,
+	0x6c, 0x2f, 0x3e, 0x3b, 0xc5, 0x3f, 0x0f, 0x32, 0x8a, 0x28, 0xf8, 0x52, 0xee, 0xda, 0xab, 0x1b,
+	0x5b, 0x9a, 0x92, 0x65, 0x73, 0xfd, 0x4a, 0x7e, 0x35, 0xbf, 0x84, 0x34, 0xfd, 0xe3, 0xeb, 0xde,
+	0xee, 0x3d, 0x39, 0xbf, 0x93, 0x13, 0x42, 0x6e, 0x76, 0x63, 0x0d, 0x39, 0x8f, 0x95, 0x14, 0xb1,
+	0xd2, 0x28, 0x8c, 0x86, 0x74, 0x93, 0x29, 0x6d, 0xc4, 0x32, 0x35, 0xa8, 0x53, 0x34, 0xe2, 0x1b,
+	0xe7, 0x1b, 0x15, 0xaf, 0xd0, 0x88, 0x58, 0xa5, 0x9f, 0xcb, 0x2f, 0x9e, 0x69, 0x65, 0x14, 0x1d,
+	0xd5, 0xa4, 0x46, 0xde, 0x50, 0xbc, 0xa6, 0x78, 0x43, 0x0d, 0xaf, 0x89, 0xf7, 0x88, 0x90, 0xa0,
+	0xa6, 0x03, 0xe2, 0xae, 0x30, 0x67, 0x8e, 0xef, 0x04, 0xdd, 0xb0, 0x18, 0xe9, 0x19, 0xe9, 0xec,
+	0x60, 0xbd, 0x45, 0xd6, 0xb2, 0x5a, 0xb9, 0x0c, 0x7f, 0x1d, 0xe2, 0x4d, 0xed, 0x5d, 0x94, 0x92,
+	0x76, 0x06, 0x66, 0x51, 0x9d, 0xdb, 0x99, 0x3e, 0x10, 0x6f, 0x61, 0x03, 0x99, 0xeb, 0xbb, 0xc1,
+	0xf1, 0x58, 0xf0, 0x03, 0xab, 0xf0, 0xb2, 0x47, 0x58, 0xe1, 0xf4, 0x92, 0x9c, 0x48, 0xd8, 0x47,
+	0x08, 0x7a, 0x9d, 0x47, 0x09, 0x18, 0x60, 0x6d, 0xdf, 0x09, 0xfa, 0x61, 0x4f, 0xc2, 0xfe, 0xbe,
+	0x10, 0xef, 0xc0, 0x00, 0x9d, 0x90, 0xf3, 0x7f, 0x47, 0x54, 0xa2, 0x51, 0x0a, 0x12, 0x59, 0xc7,
+	0x96, 0x3a, 0xc5, 0xda, 0x5a, 0xc6, 0xbf, 0x80, 0x44, 0x7a, 0x41, 0xfa, 0x46, 0x6f, 0x37, 0x06,
+	0x93, 0x28, 0xd3, 0x6a, 0x9f, 0x33, 0xcf, 0x77, 0x83, 0x6e, 0xd8, 0xab, 0xc4, 0x59, 0xa1, 0x3d,
+	0xb7, 0x8f, 0x9c, 0x41, 0xeb, 0x36, 0x21, 0x57, 0xb1, 0x92, 0x87, 0xbe, 0x61, 0xe6, 0x7c, 0x74,
+	0x9b, 0xe5, 0xa7, 0x35, 0x7a, 0x1b, 0x87, 0x90, 0xf3, 0x69, 0x81, 0xbd, 0x36, 0xd8, 0x53, 0x8d,
+	0xbd, 0xd7, 0xce, 0xb9, 0x67, 0x7f, 0x6d, 0xf2, 0x17, 0x00, 0x00, 0xff, 0xff, 0xdf, 0xb0, 0xc1,
+	0xf7, 0xf1, 0x01, 0x00, 0x00,
 }
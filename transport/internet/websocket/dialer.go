@@ -9,6 +9,7 @@ import (
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal/done"
 	"v2ray.com/core/transport/internet"
 	"v2ray.com/core/transport/internet/tls"
 )
@@ -17,7 +18,16 @@ import (
 func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig) (internet.Connection, error) {
 	newError("creating connection to ", dest).WriteToLog(session.ExportIDToError(ctx))
 
-	conn, err := dialWebsocket(ctx, dest, streamSettings)
+	if streamSettings.ProtocolSettings.(*Config).MaxEarlyData > 0 {
+		return &delayedConnection{
+			ctx:            ctx,
+			dest:           dest,
+			streamSettings: streamSettings,
+			dialed:         done.New(),
+		}, nil
+	}
+
+	conn, err := dialWebsocket(ctx, dest, streamSettings, nil)
 	if err != nil {
 		return nil, newError("failed to dial WebSocket").Base(err)
 	}
@@ -28,7 +38,7 @@ func init() {
 	common.Must(internet.RegisterTransportDialer(protocolName, Dial))
 }
 
-func dialWebsocket(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig) (net.Conn, error) {
+func dialWebsocket(ctx context.Context, dest net.Destination, streamSettings *internet.MemoryStreamConfig, earlyData []byte) (net.Conn, error) {
 	wsSettings := streamSettings.ProtocolSettings.(*Config)
 
 	dialer := &websocket.Dialer{
@@ -41,19 +51,36 @@ func dialWebsocket(ctx context.Context, dest net.Destination, streamSettings *in
 	}
 
 	protocol := "ws"
+	host := dest.NetAddr()
 
 	if config := tls.ConfigFromStreamSettings(streamSettings); config != nil {
 		protocol = "wss"
-		dialer.TLSClientConfig = config.GetTLSConfig(tls.WithDestination(dest))
+		tlsConfig := config.GetTLSConfig(tls.WithDestination(dest))
+		if fingerprint := tls.GetFingerprint(config.Fingerprint); fingerprint != nil {
+			// TLS is established in NetDial, so the websocket library works on a plain connection.
+			protocol = "ws"
+			dialer.NetDial = func(network, addr string) (net.Conn, error) {
+				conn, err := internet.DialSystem(ctx, dest, streamSettings.SocketSettings)
+				if err != nil {
+					return nil, err
+				}
+				return tls.UClient(conn, tlsConfig, fingerprint), nil
+			}
+			if dest.Port == 443 {
+				host = dest.Address.String()
+			}
+		} else {
+			dialer.TLSClientConfig = tlsConfig
+		}
 	}
 
-	host := dest.NetAddr()
 	if (protocol == "ws" && dest.Port == 80) || (protocol == "wss" && dest.Port == 443) {
 		host = dest.Address.String()
 	}
-	uri := protocol + "://" + host + wsSettings.GetNormalizedPath()
+	header := wsSettings.GetRequestHeader()
+	uri := wsSettings.addEarlyData(protocol+"://"+host+wsSettings.GetNormalizedPath(), header, earlyData)
 
-	conn, resp, err := dialer.Dial(uri, wsSettings.GetRequestHeader())
+	conn, resp, err := dialer.Dial(uri, header)
 	if err != nil {
 		var reason string
 		if resp != nil {
@@ -62,5 +89,13 @@ func dialWebsocket(ctx context.Context, dest net.Destination, streamSettings *in
 		return nil, newError("failed to dial to (", uri, "): ", reason).Base(err)
 	}
 
-	return newConnection(conn, conn.RemoteAddr()), nil
+	wsConn := newConnection(conn, conn.RemoteAddr())
+	if len(earlyData) > 0 && resp.Header.Get(earlyDataAckHeader) == "" {
+		newError("server doesn't take early data, sending it in a frame").AtDebug().WriteToLog(session.ExportIDToError(ctx))
+		if _, err := wsConn.Write(earlyData); err != nil {
+			wsConn.Close() // nolint: errcheck
+			return nil, newError("failed to send early data").Base(err)
+		}
+	}
+	return wsConn, nil
 }
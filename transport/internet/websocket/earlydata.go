@@ -0,0 +1,231 @@
+package websocket
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/signal/done"
+	"v2ray.com/core/transport/internet"
+)
+
+const (
+	earlyDataQueryName = "ed"
+	// earlyDataAckHeader is set in the upgrade response by servers that have taken early data. Servers without early
+	// data support ignore it, and the client sends it again in a normal frame.
+	earlyDataAckHeader = "Sec-WebSocket-Early-Data"
+)
+
+// addEarlyData puts early data into the URI or request header, as configured.
+func (c *Config) addEarlyData(uri string, header http.Header, earlyData []byte) string {
+	if len(earlyData) == 0 {
+		return uri
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(earlyData)
+	if len(c.EarlyDataHeaderName) > 0 {
+		header.Set(c.EarlyDataHeaderName, encoded)
+		return uri
+	}
+	if strings.Contains(uri, "?") {
+		return uri + "&" + earlyDataQueryName + "=" + encoded
+	}
+	return uri + "?" + earlyDataQueryName + "=" + encoded
+}
+
+// readEarlyData returns the early data in the upgrade request, or nil if there is none.
+func (c *Config) readEarlyData(request *http.Request) ([]byte, error) {
+	if c.MaxEarlyData == 0 {
+		return nil, nil
+	}
+
+	var encoded string
+	if len(c.EarlyDataHeaderName) > 0 {
+		encoded = request.Header.Get(c.EarlyDataHeaderName)
+	} else {
+		encoded = request.URL.Query().Get(earlyDataQueryName)
+	}
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	if base64.RawURLEncoding.DecodedLen(len(encoded)) > int(c.MaxEarlyData) {
+		return nil, newError("early data exceeds limit of ", c.MaxEarlyData, " bytes")
+	}
+	earlyData, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, newError("failed to decode early data").Base(err)
+	}
+	return earlyData, nil
+}
+
+// delayedConnection dials the WebSocket connection on first Write, carrying the first bytes in the upgrade request.
+type delayedConnection struct {
+	ctx            context.Context
+	dest           net.Destination
+	streamSettings *internet.MemoryStreamConfig
+
+	access        sync.Mutex
+	dialed        *done.Instance
+	conn          net.Conn
+	err           error
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+func (c *delayedConnection) dial(earlyData []byte) error {
+	conn, err := dialWebsocket(c.ctx, c.dest, c.streamSettings, earlyData)
+	if err != nil {
+		c.err = newError("failed to dial WebSocket").Base(err)
+		common.Must(c.dialed.Close())
+		return c.err
+	}
+
+	if !c.readDeadline.IsZero() {
+		conn.SetReadDeadline(c.readDeadline) // nolint: errcheck
+	}
+	if !c.writeDeadline.IsZero() {
+		conn.SetWriteDeadline(c.writeDeadline) // nolint: errcheck
+	}
+	c.conn = conn
+	common.Must(c.dialed.Close())
+	return nil
+}
+
+func (c *delayedConnection) getConn() (net.Conn, error) {
+	<-c.dialed.Wait()
+	return c.conn, c.err
+}
+
+// Read implements io.Reader. It blocks until the connection is dialed.
+func (c *delayedConnection) Read(b []byte) (int, error) {
+	conn, err := c.getConn()
+	if err != nil {
+		return 0, err
+	}
+	return conn.Read(b)
+}
+
+// Write implements io.Writer.
+func (c *delayedConnection) Write(b []byte) (int, error) {
+	c.access.Lock()
+	if !c.dialed.Done() {
+		max := int(c.streamSettings.ProtocolSettings.(*Config).MaxEarlyData)
+		if max > len(b) {
+			max = len(b)
+		}
+		err := c.dial(b[:max])
+		c.access.Unlock()
+		if err != nil {
+			return 0, err
+		}
+		if max == len(b) {
+			return len(b), nil
+		}
+		n, err := c.conn.Write(b[max:])
+		return max + n, err
+	}
+	c.access.Unlock()
+
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.conn.Write(b)
+}
+
+func (c *delayedConnection) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	c.access.Lock()
+	dialed := c.dialed.Done()
+	c.access.Unlock()
+
+	if !dialed {
+		b := make([]byte, mb.Len())
+		mb.Copy(b)
+		buf.ReleaseMulti(mb)
+		_, err := c.Write(b)
+		return err
+	}
+
+	if c.err != nil {
+		buf.ReleaseMulti(mb)
+		return c.err
+	}
+	return c.conn.(buf.Writer).WriteMultiBuffer(mb)
+}
+
+func (c *delayedConnection) Close() error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if !c.dialed.Done() {
+		c.err = newError("connection closed")
+		return c.dialed.Close()
+	}
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+func (c *delayedConnection) LocalAddr() net.Addr {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.conn == nil {
+		return &net.TCPAddr{
+			IP: []byte{0, 0, 0, 0},
+		}
+	}
+	return c.conn.LocalAddr()
+}
+
+func (c *delayedConnection) RemoteAddr() net.Addr {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.conn == nil {
+		addr := &net.TCPAddr{
+			IP: []byte{0, 0, 0, 0},
+		}
+		if c.dest.Address.Family().IsIP() {
+			addr.IP = c.dest.Address.IP()
+			addr.Port = int(c.dest.Port)
+		}
+		return addr
+	}
+	return c.conn.RemoteAddr()
+}
+
+func (c *delayedConnection) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+func (c *delayedConnection) SetReadDeadline(t time.Time) error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.conn == nil {
+		c.readDeadline = t
+		return nil
+	}
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *delayedConnection) SetWriteDeadline(t time.Time) error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.conn == nil {
+		c.writeDeadline = t
+		return nil
+	}
+	return c.conn.SetWriteDeadline(t)
+}
@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	http_proto "v2ray.com/core/common/protocol/http"
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/transport/internet"
+	"v2ray.com/core/transport/internet/proxyproto"
 	v2tls "v2ray.com/core/transport/internet/tls"
 )
 
@@ -33,25 +35,47 @@ func (h *requestHandler) ServeHTTP(writer http.ResponseWriter, request *http.Req
 		writer.WriteHeader(http.StatusNotFound)
 		return
 	}
-	conn, err := upgrader.Upgrade(writer, request, nil)
+	earlyData, err := h.ln.config.readEarlyData(request)
+	if err != nil {
+		newError("invalid early data").Base(err).WriteToLog()
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var responseHeader http.Header
+	if len(earlyData) > 0 {
+		responseHeader = http.Header{}
+		responseHeader.Set(earlyDataAckHeader, "1")
+	}
+	conn, err := upgrader.Upgrade(writer, request, responseHeader)
 	if err != nil {
 		newError("failed to convert to WebSocket connection").Base(err).WriteToLog()
 		return
 	}
 
-	forwardedAddrs := http_proto.ParseXForwardedFor(request.Header)
 	remoteAddr := conn.RemoteAddr()
-	if len(forwardedAddrs) > 0 && forwardedAddrs[0].Family().IsIP() {
-		remoteAddr.(*net.TCPAddr).IP = forwardedAddrs[0].IP()
+	if tcpAddr, ok := remoteAddr.(*net.TCPAddr); ok {
+		clientAddr := *tcpAddr
+		if len(h.ln.trusted) > 0 {
+			clientAddr.IP = h.ln.trusted.ClientIP(tcpAddr.IP, request.Header)
+		} else if forwardedAddrs := http_proto.ParseXForwardedFor(request.Header); len(forwardedAddrs) > 0 && forwardedAddrs[0].Family().IsIP() {
+			clientAddr.IP = forwardedAddrs[0].IP()
+		}
+		remoteAddr = &clientAddr
 	}
 
-	h.ln.addConn(newConnection(conn, remoteAddr))
+	wsConn := newConnection(conn, remoteAddr)
+	if len(earlyData) > 0 {
+		wsConn.reader = bytes.NewReader(earlyData)
+	}
+	h.ln.addConn(v2tls.WithVerifiedUser(wsConn, request.TLS))
 }
 
 type Listener struct {
 	sync.Mutex
 	listener net.Listener
 	config   *Config
+	trusted  internet.TrustedProxies
 	addConn  internet.ConnHandler
 }
 
@@ -63,13 +87,19 @@ func ListenWS(ctx context.Context, address net.Address, port net.Port, streamSet
 		tlsConfig = config.GetTLSConfig()
 	}
 
-	listener, err := listenTCP(ctx, address, port, tlsConfig, streamSettings.SocketSettings)
+	trusted, err := internet.ParseTrustedProxies(wsSettings.TrustedProxy)
+	if err != nil {
+		return nil, newError("invalid trusted_proxy").Base(err)
+	}
+
+	listener, err := listenTCP(ctx, address, port, tlsConfig, trusted, streamSettings.SocketSettings)
 	if err != nil {
 		return nil, err
 	}
 
 	l := &Listener{
 		config:   wsSettings,
+		trusted:  trusted,
 		addConn:  addConn,
 		listener: listener,
 	}
@@ -83,7 +113,7 @@ func ListenWS(ctx context.Context, address net.Address, port net.Port, streamSet
 	return l, err
 }
 
-func listenTCP(ctx context.Context, address net.Address, port net.Port, tlsConfig *tls.Config, sockopt *internet.SocketConfig) (net.Listener, error) {
+func listenTCP(ctx context.Context, address net.Address, port net.Port, tlsConfig *tls.Config, trusted internet.TrustedProxies, sockopt *internet.SocketConfig) (net.Listener, error) {
 	listener, err := internet.ListenSystem(ctx, &net.TCPAddr{
 		IP:   address.IP(),
 		Port: int(port),
@@ -92,6 +122,10 @@ func listenTCP(ctx context.Context, address net.Address, port net.Port, tlsConfi
 		return nil, newError("failed to listen TCP on", address, ":", port).Base(err)
 	}
 
+	if len(trusted) > 0 {
+		listener = proxyproto.NewListener(listener, trusted.Contains)
+	}
+
 	if tlsConfig != nil {
 		return tls.NewListener(listener, tlsConfig), nil
 	}
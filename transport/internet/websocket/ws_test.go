@@ -3,10 +3,15 @@ package websocket_test
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"io"
 	"runtime"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+
+	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol/tls/cert"
 	"v2ray.com/core/transport/internet"
@@ -150,3 +155,171 @@ func Test_listenWSAndDial_TLS(t *testing.T) {
 	end := time.Now()
 	assert(end.Before(start.Add(time.Second*5)), IsTrue)
 }
+
+func TestDialWithEarlyData(t *testing.T) {
+	testCases := []struct {
+		port   net.Port
+		config *Config
+	}{
+		{
+			port:   13150,
+			config: &Config{Path: "ws", MaxEarlyData: 2048},
+		},
+		{
+			port:   13151,
+			config: &Config{Path: "ws", MaxEarlyData: 2048, EarlyDataHeaderName: "Sec-WebSocket-Protocol"},
+		},
+	}
+
+	for _, tc := range testCases {
+		streamSettings := &internet.MemoryStreamConfig{
+			ProtocolName:     "websocket",
+			ProtocolSettings: tc.config,
+		}
+		listen, err := ListenWS(context.Background(), net.LocalHostIP, tc.port, streamSettings, func(conn internet.Connection) {
+			go func(c internet.Connection) {
+				defer c.Close()
+
+				var b [4096]byte
+				n, err := io.ReadFull(c, b[:3000])
+				if err != nil {
+					return
+				}
+				c.Write(b[:n])
+			}(conn)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conn, err := Dial(context.Background(), net.TCPDestination(net.LocalHostIP, tc.port), streamSettings)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payload := make([]byte, 3000)
+		common.Must2(rand.Read(payload))
+		if _, err := conn.Write(payload); err != nil {
+			t.Fatal(err)
+		}
+
+		response := make([]byte, len(payload))
+		if _, err := io.ReadFull(conn, response); err != nil {
+			t.Fatal(err)
+		}
+		if r := cmp.Diff(response, payload); r != "" {
+			t.Error(r)
+		}
+
+		conn.Close()
+		listen.Close()
+	}
+}
+
+func TestDialWithEarlyDataToServerWithout(t *testing.T) {
+	// The server doesn't take early data, like an old version does.
+	listen, err := ListenWS(context.Background(), net.LocalHostIP, 13153, &internet.MemoryStreamConfig{
+		ProtocolName:     "websocket",
+		ProtocolSettings: &Config{Path: "ws"},
+	}, func(conn internet.Connection) {
+		go func(c internet.Connection) {
+			defer c.Close()
+			io.Copy(c, c)
+		}(conn)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listen.Close()
+
+	for _, config := range []*Config{
+		{Path: "ws", MaxEarlyData: 2048},
+		{Path: "ws", MaxEarlyData: 2048, EarlyDataHeaderName: "X-Early-Data"},
+	} {
+		conn, err := Dial(context.Background(), net.TCPDestination(net.LocalHostIP, 13153), &internet.MemoryStreamConfig{
+			ProtocolName:     "websocket",
+			ProtocolSettings: config,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payload := make([]byte, 3000)
+		common.Must2(rand.Read(payload))
+		if _, err := conn.Write(payload); err != nil {
+			t.Fatal(err)
+		}
+
+		response := make([]byte, len(payload))
+		if _, err := io.ReadFull(conn, response); err != nil {
+			t.Fatal(err)
+		}
+		if r := cmp.Diff(response, payload); r != "" {
+			t.Error(r)
+		}
+		conn.Close()
+	}
+}
+
+func TestRejectOversizedEarlyData(t *testing.T) {
+	listen, err := ListenWS(context.Background(), net.LocalHostIP, 13152, &internet.MemoryStreamConfig{
+		ProtocolName:     "websocket",
+		ProtocolSettings: &Config{Path: "ws", MaxEarlyData: 16},
+	}, func(conn internet.Connection) {
+		conn.Close()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listen.Close()
+
+	conn, err := Dial(context.Background(), net.TCPDestination(net.LocalHostIP, 13152), &internet.MemoryStreamConfig{
+		ProtocolName:     "websocket",
+		ProtocolSettings: &Config{Path: "ws", MaxEarlyData: 64},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(make([]byte, 64)); err == nil {
+		t.Error("expect error on oversized early data")
+	}
+}
+
+func TestTrustedProxy(t *testing.T) {
+	addrs := make(chan net.Addr, 1)
+	listen, err := ListenWS(context.Background(), net.LocalHostIP, 13153, &internet.MemoryStreamConfig{
+		ProtocolName: "websocket",
+		ProtocolSettings: &Config{
+			Path:         "ws",
+			TrustedProxy: []string{"127.0.0.0/8"},
+		},
+	}, func(conn internet.Connection) {
+		addrs <- conn.RemoteAddr()
+		conn.Close()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listen.Close()
+
+	conn, err := Dial(context.Background(), net.TCPDestination(net.LocalHostIP, 13153), &internet.MemoryStreamConfig{
+		ProtocolName: "websocket",
+		ProtocolSettings: &Config{
+			Path: "ws",
+			Header: []*Header{
+				{Key: "X-Forwarded-For", Value: "1.2.3.4, 5.6.7.8"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	addr := <-addrs
+	if ip := addr.(*net.TCPAddr).IP; !ip.Equal(net.ParseIP("5.6.7.8")) {
+		t.Error("unexpected client IP: ", ip)
+	}
+}